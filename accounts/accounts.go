@@ -18,6 +18,8 @@
 package accounts
 
 import (
+	"crypto/sha256"
+	"encoding/binary"
 	"fmt"
 	"math/big"
 
@@ -25,6 +27,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/params"
 	"golang.org/x/crypto/sha3"
 )
 
@@ -40,6 +43,9 @@ const (
 	MimetypeTypedData         = "data/typed"
 	MimetypeClique            = "application/x-clique-header"
 	MimetypeTextPlain         = "text/plain"
+	MimetypeZkscamVote        = "application/x-zkscam-vote"              // Consensus vote, see ZkscamVoteData. The domain separated ZkscamVoteHash is signed
+	MimetypeZkscamLegacyVote  = "application/x-zkscam-legacy-vote"       // Consensus vote before the vote digest fork, see ZkscamVoteData. Only the vote hash is signed, without hashing
+	MimetypeZkscamBLSAuth     = "application/x-zkscam-bls-authorization" // Serialized BLS public key, see ZkscamBLSAuthHash
 )
 
 // Wallet represents a software or hardware wallet that might contain one or more
@@ -201,6 +207,56 @@ func TextAndHash(data []byte) ([]byte, string) {
 	return hasher.Sum(nil), msg
 }
 
+// ZkscamVoteData encodes a consensus vote for signing with the MimetypeZkscamVote
+// and MimetypeZkscamLegacyVote mimetypes: the 8 byte big endian block height
+// followed by the voted hash. The height lets signers display the vote and
+// refuse conflicting ones.
+func ZkscamVoteData(number uint64, hash common.Hash) []byte {
+	data := make([]byte, 8+common.HashLength)
+	binary.BigEndian.PutUint64(data, number)
+	copy(data[8:], hash[:])
+	return data
+}
+
+// ParseZkscamVoteData decodes a consensus vote encoded by ZkscamVoteData.
+func ParseZkscamVoteData(data []byte) (uint64, common.Hash, error) {
+	if len(data) != 8+common.HashLength {
+		return 0, common.Hash{}, fmt.Errorf("invalid vote data length %d, want %d", len(data), 8+common.HashLength)
+	}
+	return binary.BigEndian.Uint64(data), common.BytesToHash(data[8:]), nil
+}
+
+// ZkscamVoteHash returns the digest signed for a consensus vote with the
+// MimetypeZkscamVote mimetype. It is calculated as
+//
+//	keccak256("zkscam-vote" ${8 byte big endian height} ${voted hash}).
+//
+// The prefix keeps vote signatures from being valid for anything else, such as
+// a transaction whose signing hash was passed off as a voted hash.
+func ZkscamVoteHash(number uint64, hash common.Hash) []byte {
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write([]byte("zkscam-vote"))
+	hasher.Write(ZkscamVoteData(number, hash))
+	return hasher.Sum(nil)
+}
+
+// ZkscamVoteDigest returns the digest the consensus vote at the given height is
+// signed over: the ZkscamVoteHash from the vote digest fork on, the raw voted
+// hash before.
+func ZkscamVoteDigest(config *params.ChainConfig, number *big.Int, hash common.Hash) []byte {
+	if config != nil && config.IsZkscamVoteDigest(number) {
+		return ZkscamVoteHash(number.Uint64(), hash)
+	}
+	return hash.Bytes()
+}
+
+// ZkscamBLSAuthHash returns the digest signed to authorize a BLS voting key with
+// the MimetypeZkscamBLSAuth mimetype: the sha256 hash of the serialized key.
+func ZkscamBLSAuthHash(pub []byte) []byte {
+	hash := sha256.Sum256(pub)
+	return hash[:]
+}
+
 // WalletEventType represents the different event types that can be fired by
 // the wallet subscription subsystem.
 type WalletEventType int
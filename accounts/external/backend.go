@@ -155,6 +155,14 @@ func (api *ExternalSigner) SelfDerive(bases []accounts.DerivationPath, chain eth
 
 // SignData signs keccak256(data). The mimetype parameter describes the type of data being signed
 func (api *ExternalSigner) SignData(account accounts.Account, mimeType string, data []byte) ([]byte, error) {
+	switch mimeType {
+	case accounts.MimetypeZkscamVote:
+		return api.signVote(account, data, false)
+	case accounts.MimetypeZkscamLegacyVote:
+		return api.signVote(account, data, true)
+	case accounts.MimetypeZkscamBLSAuth:
+		return api.signBLSAuthorization(account, data)
+	}
 	var res hexutil.Bytes
 	var signAddress = common.NewMixedcaseAddress(account.Address)
 	if err := api.client.Call(&res, "account_signData",
@@ -170,6 +178,36 @@ func (api *ExternalSigner) SignData(account accounts.Account, mimeType string, d
 	return res, nil
 }
 
+// signVote requests a consensus vote signature via the dedicated clef endpoint,
+// which shows the voted height and refuses conflicting votes.
+func (api *ExternalSigner) signVote(account accounts.Account, data []byte, legacy bool) ([]byte, error) {
+	number, hash, err := accounts.ParseZkscamVoteData(data)
+	if err != nil {
+		return nil, err
+	}
+	var res hexutil.Bytes
+	var signAddress = common.NewMixedcaseAddress(account.Address)
+	if err := api.client.Call(&res, "account_signVote",
+		&signAddress, // Need to use the pointer here, because of how MarshalJSON is defined
+		apitypes.Vote{Number: hexutil.Uint64(number), Hash: hash, Legacy: legacy}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// signBLSAuthorization requests the authorization of a BLS public key via the
+// dedicated clef endpoint, which hashes the key itself before signing.
+func (api *ExternalSigner) signBLSAuthorization(account accounts.Account, pub []byte) ([]byte, error) {
+	var res hexutil.Bytes
+	var signAddress = common.NewMixedcaseAddress(account.Address)
+	if err := api.client.Call(&res, "account_signBLSAuthorization",
+		&signAddress, // Need to use the pointer here, because of how MarshalJSON is defined
+		hexutil.Bytes(pub)); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 func (api *ExternalSigner) SignText(account accounts.Account, text []byte) ([]byte, error) {
 	var signature hexutil.Bytes
 	var signAddress = common.NewMixedcaseAddress(account.Address)
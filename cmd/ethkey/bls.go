@@ -0,0 +1,207 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/crypto"
+	single "github.com/ethereum/go-ethereum/singleton"
+	"github.com/urfave/cli/v2"
+)
+
+type outputBLS struct {
+	Address    string
+	PublicKey  string
+	Authorized bool
+	PrivateKey string `json:",omitempty"`
+}
+
+var (
+	blsPassphraseFlag = &cli.StringFlag{
+		Name:  "blspasswordfile",
+		Usage: "the file that contains the password for the BLS keyfile",
+	}
+	blsPrivateKeyFlag = &cli.StringFlag{
+		Name:  "blsprivatekey",
+		Usage: "file containing a raw BLS private key to encrypt",
+	}
+)
+
+var commandBLSGenerate = &cli.Command{
+	Name:      "blsgenerate",
+	Usage:     "generate a new BLS keyfile for a miner account",
+	ArgsUsage: "<keyfile> [ <blskeyfile> ]",
+	Description: `
+Generate a new EIP-2335 style BLS keyfile for the miner account of <keyfile>.
+
+The BLS key is independent of the account key and is authorized by a signature
+of the account, so the keyfile of the account is decrypted to sign it. To copy
+the BLS key into a node, place it under <DATADIR>/blskeystore/<address>.json.
+
+If you want to encrypt an existing BLS private key, it can be specified by
+setting --blsprivatekey with the location of the file containing the key.
+`,
+	Flags: []cli.Flag{
+		passphraseFlag,
+		blsPassphraseFlag,
+		jsonFlag,
+		blsPrivateKeyFlag,
+		lightKDFFlag,
+	},
+	Action: func(ctx *cli.Context) error {
+		keyfilepath := ctx.Args().First()
+		if keyfilepath == "" {
+			utils.Fatalf("The keyfile of the miner account must be given.")
+		}
+		keyjson, err := os.ReadFile(keyfilepath)
+		if err != nil {
+			utils.Fatalf("Failed to read the keyfile at '%s': %v", keyfilepath, err)
+		}
+		key, err := keystore.DecryptKey(keyjson, getPassphrase(ctx, false))
+		if err != nil {
+			utils.Fatalf("Error decrypting key: %v", err)
+		}
+
+		// Check if BLS keyfile path given and make sure it doesn't already exist.
+		blskeyfilepath := ctx.Args().Get(1)
+		if blskeyfilepath == "" {
+			blskeyfilepath = single.BLSKeyFile(".", key.Address)
+		}
+		if _, err := os.Stat(blskeyfilepath); err == nil {
+			utils.Fatalf("BLS keyfile already exists at %s.", blskeyfilepath)
+		} else if !os.IsNotExist(err) {
+			utils.Fatalf("Error checking if BLS keyfile exists: %v", err)
+		}
+
+		blsKey := single.GenerateBLSKey(key.Address)
+		if file := ctx.String(blsPrivateKeyFlag.Name); file != "" {
+			content, err := os.ReadFile(file)
+			if err != nil {
+				utils.Fatalf("Can't read BLS private key: %v", err)
+			}
+			raw, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(content)), "0x"))
+			if err != nil {
+				utils.Fatalf("Can't decode BLS private key: %v", err)
+			}
+			if blsKey.Secret, err = single.ParseBLSSecret(raw); err != nil {
+				utils.Fatalf("Can't load BLS private key: %v", err)
+			}
+		}
+		if err := blsKey.Authorize(func(hash []byte) ([]byte, error) { return crypto.Sign(hash, key.PrivateKey) }); err != nil {
+			utils.Fatalf("Failed to authorize BLS key: %v", err)
+		}
+
+		// Encrypt key with the BLS passphrase.
+		fmt.Println("Please provide a password for the BLS key")
+		scryptN, scryptP := keystore.StandardScryptN, keystore.StandardScryptP
+		if ctx.Bool(lightKDFFlag.Name) {
+			scryptN, scryptP = keystore.LightScryptN, keystore.LightScryptP
+		}
+		blsjson, err := single.EncryptBLSKey(blsKey, getBLSPassphrase(ctx, true), scryptN, scryptP)
+		if err != nil {
+			utils.Fatalf("Error encrypting BLS key: %v", err)
+		}
+
+		// Store the file to disk.
+		if err := os.MkdirAll(filepath.Dir(blskeyfilepath), 0700); err != nil {
+			utils.Fatalf("Could not create directory %s", filepath.Dir(blskeyfilepath))
+		}
+		if err := os.WriteFile(blskeyfilepath, blsjson, 0600); err != nil {
+			utils.Fatalf("Failed to write BLS keyfile to %s: %v", blskeyfilepath, err)
+		}
+		printBLSKey(ctx, blsKey, false)
+		return nil
+	},
+}
+
+var commandBLSInspect = &cli.Command{
+	Name:      "blsinspect",
+	Usage:     "inspect a BLS keyfile",
+	ArgsUsage: "<blskeyfile>",
+	Description: `
+Print the miner account, public key and authorization status of a BLS keyfile.
+
+Private key information can be printed by using the --private flag;
+make sure to use this feature with great caution!`,
+	Flags: []cli.Flag{
+		blsPassphraseFlag,
+		jsonFlag,
+		privateFlag,
+	},
+	Action: func(ctx *cli.Context) error {
+		blskeyfilepath := ctx.Args().First()
+
+		// Read key from file.
+		blsjson, err := os.ReadFile(blskeyfilepath)
+		if err != nil {
+			utils.Fatalf("Failed to read the BLS keyfile at '%s': %v", blskeyfilepath, err)
+		}
+
+		// Decrypt key with passphrase.
+		blsKey, err := single.DecryptBLSKey(blsjson, getBLSPassphrase(ctx, false))
+		if err != nil {
+			utils.Fatalf("Error decrypting BLS key: %v", err)
+		}
+		printBLSKey(ctx, blsKey, ctx.Bool(privateFlag.Name))
+		return nil
+	},
+}
+
+// getBLSPassphrase obtains the passphrase of a BLS keyfile, either from the
+// --blspasswordfile flag or by prompting the user.
+func getBLSPassphrase(ctx *cli.Context, confirmation bool) string {
+	if file := ctx.String(blsPassphraseFlag.Name); file != "" {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			utils.Fatalf("Failed to read BLS password file '%s': %v", file, err)
+		}
+		return strings.TrimRight(string(content), "\r\n")
+	}
+	return utils.GetPassPhrase("", confirmation)
+}
+
+func printBLSKey(ctx *cli.Context, key *single.BLSKey, showPrivate bool) {
+	out := outputBLS{
+		Address:    key.Address.Hex(),
+		PublicKey:  hex.EncodeToString(key.PublicKey()),
+		Authorized: key.Authorized(),
+	}
+	if showPrivate {
+		secret, err := key.Secret.MarshalBinary()
+		if err != nil {
+			utils.Fatalf("Failed to encode BLS private key: %v", err)
+		}
+		out.PrivateKey = hex.EncodeToString(secret)
+	}
+	if ctx.Bool(jsonFlag.Name) {
+		mustPrintJSON(out)
+	} else {
+		fmt.Println("Address:       ", out.Address)
+		fmt.Println("BLS public key:", out.PublicKey)
+		fmt.Println("Authorized:    ", out.Authorized)
+		if showPrivate {
+			fmt.Println("Private key:   ", out.PrivateKey)
+		}
+	}
+}
@@ -38,6 +38,8 @@ func init() {
 		commandChangePassphrase,
 		commandSignMessage,
 		commandVerifyMessage,
+		commandBLSGenerate,
+		commandBLSInspect,
 	}
 }
 
@@ -56,6 +56,9 @@ type header struct {
 	BlobGasUsed           *uint64           `json:"blobGasUsed"   rlp:"optional"`
 	ExcessBlobGas         *uint64           `json:"excessBlobGas"   rlp:"optional"`
 	ParentBeaconBlockRoot *common.Hash      `json:"parentBeaconBlockRoot" rlp:"optional"`
+	MinerAddresses        []common.Address  `json:"minerAddresses" rlp:"optional"`
+	Votes                 *big.Int          `json:"votes" rlp:"optional"`
+	TotalVotes            *big.Int          `json:"totalVotes" rlp:"optional"`
 }
 
 type headerMarshaling struct {
@@ -68,6 +71,8 @@ type headerMarshaling struct {
 	BaseFee       *math.HexOrDecimal256
 	BlobGasUsed   *math.HexOrDecimal64
 	ExcessBlobGas *math.HexOrDecimal64
+	Votes         *math.HexOrDecimal256
+	TotalVotes    *math.HexOrDecimal256
 }
 
 type bbInput struct {
@@ -136,6 +141,9 @@ func (i *bbInput) ToBlock() *types.Block {
 		BlobGasUsed:      i.Header.BlobGasUsed,
 		ExcessBlobGas:    i.Header.ExcessBlobGas,
 		ParentBeaconRoot: i.Header.ParentBeaconBlockRoot,
+		MinerAddresses:   i.Header.MinerAddresses,
+		Votes:            i.Header.Votes,
+		TotalVotes:       i.Header.TotalVotes,
 	}
 
 	// Fill optional values.
@@ -17,11 +17,13 @@
 package t8ntool
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/consensus/clique"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/consensus/misc"
 	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
@@ -62,6 +64,10 @@ type ExecutionResult struct {
 	WithdrawalsRoot      *common.Hash          `json:"withdrawalsRoot,omitempty"`
 	CurrentExcessBlobGas *math.HexOrDecimal64  `json:"currentExcessBlobGas,omitempty"`
 	CurrentBlobGasUsed   *math.HexOrDecimal64  `json:"blobGasUsed,omitempty"`
+	MinerAddresses       []common.Address      `json:"minerAddresses,omitempty"`
+	Votes                *math.HexOrDecimal256 `json:"votes,omitempty"`
+	TotalVotes           *math.HexOrDecimal256 `json:"totalVotes,omitempty"`
+	Rewards              []*reward             `json:"rewards,omitempty"`
 }
 
 type ommer struct {
@@ -91,6 +97,11 @@ type stEnv struct {
 	ParentExcessBlobGas   *uint64                             `json:"parentExcessBlobGas,omitempty"`
 	ParentBlobGasUsed     *uint64                             `json:"parentBlobGasUsed,omitempty"`
 	ParentBeaconBlockRoot *common.Hash                        `json:"parentBeaconBlockRoot"`
+	MinerAddresses        []common.Address                    `json:"minerAddresses,omitempty"`
+	Votes                 *big.Int                            `json:"votes,omitempty"`
+	TotalVotes            *big.Int                            `json:"totalVotes,omitempty"`
+	BlockVotes            map[math.HexOrDecimal64]*voteData   `json:"blockVotes,omitempty"`
+	Stakes                map[common.Address]*big.Int         `json:"stakes,omitempty"`
 }
 
 type stEnvMarshaling struct {
@@ -109,6 +120,23 @@ type stEnvMarshaling struct {
 	ExcessBlobGas       *math.HexOrDecimal64
 	ParentExcessBlobGas *math.HexOrDecimal64
 	ParentBlobGasUsed   *math.HexOrDecimal64
+	Votes               *math.HexOrDecimal256
+	TotalVotes          *math.HexOrDecimal256
+	Stakes              map[common.Address]*math.HexOrDecimal256
+}
+
+// voteData is the consensus vote data of a block of the vote-based clique.
+type voteData struct {
+	ZkscamHash     common.Hash           `json:"zkscamHash"`
+	MinerAddresses []common.Address      `json:"minerAddresses"`
+	Votes          *math.HexOrDecimal256 `json:"votes"`
+	TotalVotes     *math.HexOrDecimal256 `json:"totalVotes"`
+}
+
+// reward is an amount credited to an account when the block is finalized.
+type reward struct {
+	Address common.Address        `json:"address"`
+	Amount  *math.HexOrDecimal256 `json:"amount"`
 }
 
 type rejectedTx struct {
@@ -134,6 +162,16 @@ func (pre *Prestate) Apply(vmConfig vm.Config, chainConfig *params.ChainConfig,
 		}
 		return h
 	}
+	// Likewise for the vote data precompile of the vote-based clique
+	var voteDataError error
+	getVoteData := func(num uint64) (vm.VoteData, bool) {
+		votes, ok := pre.Env.BlockVotes[math.HexOrDecimal64(num)]
+		if !ok {
+			voteDataError = fmt.Errorf("getVoteData(%d) invoked, vote data for that block not provided", num)
+			return vm.VoteData{}, false
+		}
+		return votes.toVoteData(), true
+	}
 	var (
 		statedb     = MakePreState(rawdb.NewMemoryDatabase(), pre.Pre)
 		signer      = types.MakeSigner(chainConfig, new(big.Int).SetUint64(pre.Env.Number), pre.Env.Timestamp)
@@ -156,6 +194,7 @@ func (pre *Prestate) Apply(vmConfig vm.Config, chainConfig *params.ChainConfig,
 		Difficulty:  pre.Env.Difficulty,
 		GasLimit:    pre.Env.GasLimit,
 		GetHash:     getHash,
+		GetVoteData: getVoteData,
 	}
 	// If currentBaseFee is defined, add it to the vmContext.
 	if pre.Env.BaseFee != nil {
@@ -249,6 +288,9 @@ func (pre *Prestate) Apply(vmConfig vm.Config, chainConfig *params.ChainConfig,
 		if hashError != nil {
 			return nil, nil, nil, NewError(ErrorMissingBlockhash, hashError)
 		}
+		if voteDataError != nil {
+			return nil, nil, nil, NewError(ErrorMissingVoteData, voteDataError)
+		}
 		blobGasUsed += txBlobGas
 		gasUsed += msgResult.UsedGas
 
@@ -290,8 +332,19 @@ func (pre *Prestate) Apply(vmConfig vm.Config, chainConfig *params.ChainConfig,
 		txIndex++
 	}
 	statedb.IntermediateRoot(chainConfig.IsEIP158(vmContext.BlockNumber))
-	// Add mining reward? (-1 means rewards are disabled)
-	if miningReward >= 0 {
+
+	// The vote-based clique distributes the gas fees instead of a mining reward
+	var rewards []*reward
+	if chainConfig.Clique != nil {
+		var err error
+		if rewards, err = pre.cliqueRewards(includedTxs, receipts); err != nil {
+			return nil, nil, nil, err
+		}
+		for _, r := range rewards {
+			statedb.AddBalance(r.Address, uint256.MustFromBig((*big.Int)(r.Amount)))
+		}
+	} else if miningReward >= 0 {
+		// Add mining reward? (-1 means rewards are disabled)
 		// Add mining reward. The mining reward may be `0`, which only makes a difference in the cases
 		// where
 		// - the coinbase self-destructed, or
@@ -341,6 +394,12 @@ func (pre *Prestate) Apply(vmConfig vm.Config, chainConfig *params.ChainConfig,
 		h := types.DeriveSha(types.Withdrawals(pre.Env.Withdrawals), trie.NewStackTrie(nil))
 		execRs.WithdrawalsRoot = &h
 	}
+	if chainConfig.Clique != nil {
+		execRs.MinerAddresses = pre.Env.MinerAddresses
+		execRs.Votes = (*math.HexOrDecimal256)(pre.Env.Votes)
+		execRs.TotalVotes = (*math.HexOrDecimal256)(pre.Env.TotalVotes)
+		execRs.Rewards = rewards
+	}
 	if vmContext.BlobBaseFee != nil {
 		execRs.CurrentExcessBlobGas = (*math.HexOrDecimal64)(&excessBlobGas)
 		execRs.CurrentBlobGasUsed = (*math.HexOrDecimal64)(&blobGasUsed)
@@ -355,6 +414,54 @@ func (pre *Prestate) Apply(vmConfig vm.Config, chainConfig *params.ChainConfig,
 	return statedb, execRs, body, nil
 }
 
+// cliqueRewards returns the gas fees the vote-based clique credits when
+// finalizing the block: the share of the buyback contract followed by the ones
+// of the voters of the parent block, in proportion of their stakes.
+func (pre *Prestate) cliqueRewards(txs types.Transactions, receipts types.Receipts) ([]*reward, error) {
+	if pre.Env.Number == 0 {
+		return nil, nil
+	}
+	parent := &types.Header{
+		Number:  new(big.Int).SetUint64(pre.Env.Number - 1),
+		BaseFee: pre.Env.ParentBaseFee,
+	}
+	if votes, ok := pre.Env.BlockVotes[math.HexOrDecimal64(pre.Env.Number-1)]; ok {
+		parent.MinerAddresses = votes.MinerAddresses
+	}
+	for _, tx := range txs {
+		if tx.Type() == types.DynamicFeeTxType && parent.BaseFee == nil {
+			return nil, NewError(ErrorConfig, errors.New("vote-based clique rewards of dynamic fee transactions require 'parentBaseFee' in env section"))
+		}
+	}
+	var stakeError error
+	rewards := clique.GasRewards(parent, txs, receipts, func(miner common.Address) (*big.Int, error) {
+		stake, ok := pre.Env.Stakes[miner]
+		if !ok {
+			stakeError = fmt.Errorf("stake of voter %v not provided", miner)
+			return nil, stakeError
+		}
+		return stake, nil
+	})
+	if stakeError != nil {
+		return nil, NewError(ErrorMissingStake, stakeError)
+	}
+	ret := make([]*reward, 0, len(rewards))
+	for _, r := range rewards {
+		ret = append(ret, &reward{Address: r.Address, Amount: (*math.HexOrDecimal256)(r.Amount)})
+	}
+	return ret, nil
+}
+
+// toVoteData converts the vote data of the env into the one of the EVM.
+func (v *voteData) toVoteData() vm.VoteData {
+	return vm.VoteData{
+		ZkscamHash:     v.ZkscamHash,
+		Votes:          (*big.Int)(v.Votes),
+		TotalVotes:     (*big.Int)(v.TotalVotes),
+		MinerAddresses: v.MinerAddresses,
+	}
+}
+
 func MakePreState(db ethdb.Database, accounts types.GenesisAlloc) *state.StateDB {
 	sdb := state.NewDatabaseWithConfig(db, &triedb.Config{Preimages: true})
 	statedb, _ := state.New(types.EmptyRootHash, sdb, nil)
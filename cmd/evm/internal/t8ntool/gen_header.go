@@ -38,6 +38,9 @@ func (h header) MarshalJSON() ([]byte, error) {
 		BlobGasUsed           *math.HexOrDecimal64  `json:"blobGasUsed"   rlp:"optional"`
 		ExcessBlobGas         *math.HexOrDecimal64  `json:"excessBlobGas"   rlp:"optional"`
 		ParentBeaconBlockRoot *common.Hash          `json:"parentBeaconBlockRoot" rlp:"optional"`
+		MinerAddresses        []common.Address      `json:"minerAddresses" rlp:"optional"`
+		Votes                 *math.HexOrDecimal256 `json:"votes" rlp:"optional"`
+		TotalVotes            *math.HexOrDecimal256 `json:"totalVotes" rlp:"optional"`
 	}
 	var enc header
 	enc.ParentHash = h.ParentHash
@@ -60,6 +63,9 @@ func (h header) MarshalJSON() ([]byte, error) {
 	enc.BlobGasUsed = (*math.HexOrDecimal64)(h.BlobGasUsed)
 	enc.ExcessBlobGas = (*math.HexOrDecimal64)(h.ExcessBlobGas)
 	enc.ParentBeaconBlockRoot = h.ParentBeaconBlockRoot
+	enc.MinerAddresses = h.MinerAddresses
+	enc.Votes = (*math.HexOrDecimal256)(h.Votes)
+	enc.TotalVotes = (*math.HexOrDecimal256)(h.TotalVotes)
 	return json.Marshal(&enc)
 }
 
@@ -86,6 +92,9 @@ func (h *header) UnmarshalJSON(input []byte) error {
 		BlobGasUsed           *math.HexOrDecimal64  `json:"blobGasUsed"   rlp:"optional"`
 		ExcessBlobGas         *math.HexOrDecimal64  `json:"excessBlobGas"   rlp:"optional"`
 		ParentBeaconBlockRoot *common.Hash          `json:"parentBeaconBlockRoot" rlp:"optional"`
+		MinerAddresses        []common.Address      `json:"minerAddresses" rlp:"optional"`
+		Votes                 *math.HexOrDecimal256 `json:"votes" rlp:"optional"`
+		TotalVotes            *math.HexOrDecimal256 `json:"totalVotes" rlp:"optional"`
 	}
 	var dec header
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -155,5 +164,14 @@ func (h *header) UnmarshalJSON(input []byte) error {
 	if dec.ParentBeaconBlockRoot != nil {
 		h.ParentBeaconBlockRoot = dec.ParentBeaconBlockRoot
 	}
+	if dec.MinerAddresses != nil {
+		h.MinerAddresses = dec.MinerAddresses
+	}
+	if dec.Votes != nil {
+		h.Votes = (*big.Int)(dec.Votes)
+	}
+	if dec.TotalVotes != nil {
+		h.TotalVotes = (*big.Int)(dec.TotalVotes)
+	}
 	return nil
 }
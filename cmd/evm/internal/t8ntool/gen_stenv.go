@@ -17,26 +17,31 @@ var _ = (*stEnvMarshaling)(nil)
 // MarshalJSON marshals as JSON.
 func (s stEnv) MarshalJSON() ([]byte, error) {
 	type stEnv struct {
-		Coinbase              common.UnprefixedAddress            `json:"currentCoinbase"   gencodec:"required"`
-		Difficulty            *math.HexOrDecimal256               `json:"currentDifficulty"`
-		Random                *math.HexOrDecimal256               `json:"currentRandom"`
-		ParentDifficulty      *math.HexOrDecimal256               `json:"parentDifficulty"`
-		ParentBaseFee         *math.HexOrDecimal256               `json:"parentBaseFee,omitempty"`
-		ParentGasUsed         math.HexOrDecimal64                 `json:"parentGasUsed,omitempty"`
-		ParentGasLimit        math.HexOrDecimal64                 `json:"parentGasLimit,omitempty"`
-		GasLimit              math.HexOrDecimal64                 `json:"currentGasLimit"   gencodec:"required"`
-		Number                math.HexOrDecimal64                 `json:"currentNumber"     gencodec:"required"`
-		Timestamp             math.HexOrDecimal64                 `json:"currentTimestamp"  gencodec:"required"`
-		ParentTimestamp       math.HexOrDecimal64                 `json:"parentTimestamp,omitempty"`
-		BlockHashes           map[math.HexOrDecimal64]common.Hash `json:"blockHashes,omitempty"`
-		Ommers                []ommer                             `json:"ommers,omitempty"`
-		Withdrawals           []*types.Withdrawal                 `json:"withdrawals,omitempty"`
-		BaseFee               *math.HexOrDecimal256               `json:"currentBaseFee,omitempty"`
-		ParentUncleHash       common.Hash                         `json:"parentUncleHash"`
-		ExcessBlobGas         *math.HexOrDecimal64                `json:"currentExcessBlobGas,omitempty"`
-		ParentExcessBlobGas   *math.HexOrDecimal64                `json:"parentExcessBlobGas,omitempty"`
-		ParentBlobGasUsed     *math.HexOrDecimal64                `json:"parentBlobGasUsed,omitempty"`
-		ParentBeaconBlockRoot *common.Hash                        `json:"parentBeaconBlockRoot"`
+		Coinbase              common.UnprefixedAddress                 `json:"currentCoinbase"   gencodec:"required"`
+		Difficulty            *math.HexOrDecimal256                    `json:"currentDifficulty"`
+		Random                *math.HexOrDecimal256                    `json:"currentRandom"`
+		ParentDifficulty      *math.HexOrDecimal256                    `json:"parentDifficulty"`
+		ParentBaseFee         *math.HexOrDecimal256                    `json:"parentBaseFee,omitempty"`
+		ParentGasUsed         math.HexOrDecimal64                      `json:"parentGasUsed,omitempty"`
+		ParentGasLimit        math.HexOrDecimal64                      `json:"parentGasLimit,omitempty"`
+		GasLimit              math.HexOrDecimal64                      `json:"currentGasLimit"   gencodec:"required"`
+		Number                math.HexOrDecimal64                      `json:"currentNumber"     gencodec:"required"`
+		Timestamp             math.HexOrDecimal64                      `json:"currentTimestamp"  gencodec:"required"`
+		ParentTimestamp       math.HexOrDecimal64                      `json:"parentTimestamp,omitempty"`
+		BlockHashes           map[math.HexOrDecimal64]common.Hash      `json:"blockHashes,omitempty"`
+		Ommers                []ommer                                  `json:"ommers,omitempty"`
+		Withdrawals           []*types.Withdrawal                      `json:"withdrawals,omitempty"`
+		BaseFee               *math.HexOrDecimal256                    `json:"currentBaseFee,omitempty"`
+		ParentUncleHash       common.Hash                              `json:"parentUncleHash"`
+		ExcessBlobGas         *math.HexOrDecimal64                     `json:"currentExcessBlobGas,omitempty"`
+		ParentExcessBlobGas   *math.HexOrDecimal64                     `json:"parentExcessBlobGas,omitempty"`
+		ParentBlobGasUsed     *math.HexOrDecimal64                     `json:"parentBlobGasUsed,omitempty"`
+		ParentBeaconBlockRoot *common.Hash                             `json:"parentBeaconBlockRoot"`
+		MinerAddresses        []common.Address                         `json:"minerAddresses,omitempty"`
+		Votes                 *math.HexOrDecimal256                    `json:"votes,omitempty"`
+		TotalVotes            *math.HexOrDecimal256                    `json:"totalVotes,omitempty"`
+		BlockVotes            map[math.HexOrDecimal64]*voteData        `json:"blockVotes,omitempty"`
+		Stakes                map[common.Address]*math.HexOrDecimal256 `json:"stakes,omitempty"`
 	}
 	var enc stEnv
 	enc.Coinbase = common.UnprefixedAddress(s.Coinbase)
@@ -59,32 +64,47 @@ func (s stEnv) MarshalJSON() ([]byte, error) {
 	enc.ParentExcessBlobGas = (*math.HexOrDecimal64)(s.ParentExcessBlobGas)
 	enc.ParentBlobGasUsed = (*math.HexOrDecimal64)(s.ParentBlobGasUsed)
 	enc.ParentBeaconBlockRoot = s.ParentBeaconBlockRoot
+	enc.MinerAddresses = s.MinerAddresses
+	enc.Votes = (*math.HexOrDecimal256)(s.Votes)
+	enc.TotalVotes = (*math.HexOrDecimal256)(s.TotalVotes)
+	enc.BlockVotes = s.BlockVotes
+	if s.Stakes != nil {
+		enc.Stakes = make(map[common.Address]*math.HexOrDecimal256, len(s.Stakes))
+		for k, v := range s.Stakes {
+			enc.Stakes[k] = (*math.HexOrDecimal256)(v)
+		}
+	}
 	return json.Marshal(&enc)
 }
 
 // UnmarshalJSON unmarshals from JSON.
 func (s *stEnv) UnmarshalJSON(input []byte) error {
 	type stEnv struct {
-		Coinbase              *common.UnprefixedAddress           `json:"currentCoinbase"   gencodec:"required"`
-		Difficulty            *math.HexOrDecimal256               `json:"currentDifficulty"`
-		Random                *math.HexOrDecimal256               `json:"currentRandom"`
-		ParentDifficulty      *math.HexOrDecimal256               `json:"parentDifficulty"`
-		ParentBaseFee         *math.HexOrDecimal256               `json:"parentBaseFee,omitempty"`
-		ParentGasUsed         *math.HexOrDecimal64                `json:"parentGasUsed,omitempty"`
-		ParentGasLimit        *math.HexOrDecimal64                `json:"parentGasLimit,omitempty"`
-		GasLimit              *math.HexOrDecimal64                `json:"currentGasLimit"   gencodec:"required"`
-		Number                *math.HexOrDecimal64                `json:"currentNumber"     gencodec:"required"`
-		Timestamp             *math.HexOrDecimal64                `json:"currentTimestamp"  gencodec:"required"`
-		ParentTimestamp       *math.HexOrDecimal64                `json:"parentTimestamp,omitempty"`
-		BlockHashes           map[math.HexOrDecimal64]common.Hash `json:"blockHashes,omitempty"`
-		Ommers                []ommer                             `json:"ommers,omitempty"`
-		Withdrawals           []*types.Withdrawal                 `json:"withdrawals,omitempty"`
-		BaseFee               *math.HexOrDecimal256               `json:"currentBaseFee,omitempty"`
-		ParentUncleHash       *common.Hash                        `json:"parentUncleHash"`
-		ExcessBlobGas         *math.HexOrDecimal64                `json:"currentExcessBlobGas,omitempty"`
-		ParentExcessBlobGas   *math.HexOrDecimal64                `json:"parentExcessBlobGas,omitempty"`
-		ParentBlobGasUsed     *math.HexOrDecimal64                `json:"parentBlobGasUsed,omitempty"`
-		ParentBeaconBlockRoot *common.Hash                        `json:"parentBeaconBlockRoot"`
+		Coinbase              *common.UnprefixedAddress                `json:"currentCoinbase"   gencodec:"required"`
+		Difficulty            *math.HexOrDecimal256                    `json:"currentDifficulty"`
+		Random                *math.HexOrDecimal256                    `json:"currentRandom"`
+		ParentDifficulty      *math.HexOrDecimal256                    `json:"parentDifficulty"`
+		ParentBaseFee         *math.HexOrDecimal256                    `json:"parentBaseFee,omitempty"`
+		ParentGasUsed         *math.HexOrDecimal64                     `json:"parentGasUsed,omitempty"`
+		ParentGasLimit        *math.HexOrDecimal64                     `json:"parentGasLimit,omitempty"`
+		GasLimit              *math.HexOrDecimal64                     `json:"currentGasLimit"   gencodec:"required"`
+		Number                *math.HexOrDecimal64                     `json:"currentNumber"     gencodec:"required"`
+		Timestamp             *math.HexOrDecimal64                     `json:"currentTimestamp"  gencodec:"required"`
+		ParentTimestamp       *math.HexOrDecimal64                     `json:"parentTimestamp,omitempty"`
+		BlockHashes           map[math.HexOrDecimal64]common.Hash      `json:"blockHashes,omitempty"`
+		Ommers                []ommer                                  `json:"ommers,omitempty"`
+		Withdrawals           []*types.Withdrawal                      `json:"withdrawals,omitempty"`
+		BaseFee               *math.HexOrDecimal256                    `json:"currentBaseFee,omitempty"`
+		ParentUncleHash       *common.Hash                             `json:"parentUncleHash"`
+		ExcessBlobGas         *math.HexOrDecimal64                     `json:"currentExcessBlobGas,omitempty"`
+		ParentExcessBlobGas   *math.HexOrDecimal64                     `json:"parentExcessBlobGas,omitempty"`
+		ParentBlobGasUsed     *math.HexOrDecimal64                     `json:"parentBlobGasUsed,omitempty"`
+		ParentBeaconBlockRoot *common.Hash                             `json:"parentBeaconBlockRoot"`
+		MinerAddresses        []common.Address                         `json:"minerAddresses,omitempty"`
+		Votes                 *math.HexOrDecimal256                    `json:"votes,omitempty"`
+		TotalVotes            *math.HexOrDecimal256                    `json:"totalVotes,omitempty"`
+		BlockVotes            map[math.HexOrDecimal64]*voteData        `json:"blockVotes,omitempty"`
+		Stakes                map[common.Address]*math.HexOrDecimal256 `json:"stakes,omitempty"`
 	}
 	var dec stEnv
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -154,5 +174,23 @@ func (s *stEnv) UnmarshalJSON(input []byte) error {
 	if dec.ParentBeaconBlockRoot != nil {
 		s.ParentBeaconBlockRoot = dec.ParentBeaconBlockRoot
 	}
+	if dec.MinerAddresses != nil {
+		s.MinerAddresses = dec.MinerAddresses
+	}
+	if dec.Votes != nil {
+		s.Votes = (*big.Int)(dec.Votes)
+	}
+	if dec.TotalVotes != nil {
+		s.TotalVotes = (*big.Int)(dec.TotalVotes)
+	}
+	if dec.BlockVotes != nil {
+		s.BlockVotes = dec.BlockVotes
+	}
+	if dec.Stakes != nil {
+		s.Stakes = make(map[common.Address]*big.Int, len(dec.Stakes))
+		for k, v := range dec.Stakes {
+			s.Stakes[k] = (*big.Int)(v)
+		}
+	}
 	return nil
 }
@@ -26,6 +26,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -42,6 +43,8 @@ const (
 	ErrorEVM              = 2
 	ErrorConfig           = 3
 	ErrorMissingBlockhash = 4
+	ErrorMissingVoteData  = 5
+	ErrorMissingStake     = 6
 
 	ErrorJson = 10
 	ErrorIO   = 11
@@ -182,6 +185,9 @@ func Transition(ctx *cli.Context) error {
 	if err := applyCancunChecks(&prestate.Env, chainConfig); err != nil {
 		return err
 	}
+	if err := applyCliqueChecks(&prestate.Env, chainConfig); err != nil {
+		return err
+	}
 	// Run the test and aggregate the result
 	s, result, body, err := prestate.Apply(vmConfig, chainConfig, txIt, ctx.Int64(RewardFlag.Name), getTracer)
 	if err != nil {
@@ -357,3 +363,20 @@ func dispatchOutput(ctx *cli.Context, baseDir string, result *ExecutionResult, a
 	}
 	return nil
 }
+
+func applyCliqueChecks(env *stEnv, chainConfig *params.ChainConfig) error {
+	if chainConfig.Clique == nil || env.TotalVotes != nil || env.Votes == nil {
+		return nil
+	}
+	// The total votes were not provided, they accumulate on top of the parent ones
+	if env.Number == 0 {
+		env.TotalVotes = new(big.Int).Set(env.Votes)
+		return nil
+	}
+	parent, ok := env.BlockVotes[math.HexOrDecimal64(env.Number-1)]
+	if !ok || parent.TotalVotes == nil {
+		return NewError(ErrorConfig, errors.New("totalVotes was not provided, and cannot be calculated due to missing parent blockVotes"))
+	}
+	env.TotalVotes = new(big.Int).Add((*big.Int)(parent.TotalVotes), env.Votes)
+	return nil
+}
@@ -284,6 +284,14 @@ func TestT8n(t *testing.T) {
 			output: t8nOutput{alloc: true, result: true},
 			expOut: "exp.json",
 		},
+		{ // Vote-based clique, fee distribution and vote data precompile
+			base: "./testdata/31",
+			input: t8nInput{
+				"alloc.json", "txs.json", "env.json", "Zkscam", "",
+			},
+			output: t8nOutput{alloc: true, result: true},
+			expOut: "exp.json",
+		},
 	} {
 		args := []string{"t8n"}
 		args = append(args, tc.output.get()...)
@@ -531,6 +539,15 @@ func TestB11r(t *testing.T) {
 			},
 			expOut: "exp.json",
 		},
+		{ // block with votes
+			base: "./testdata/31",
+			input: b11rInput{
+				inEnv:       "header.json",
+				inOmmersRlp: "ommers.json",
+				inTxsRlp:    "txs.rlp",
+			},
+			expOut: "exp-b11r.json",
+		},
 	} {
 		args := []string{"b11r"}
 		args = append(args, tc.input.get(tc.base)...)
@@ -184,6 +184,7 @@ this import mechanism is not needed when you transfer an account between
 nodes.
 `,
 			},
+			blsAccountCommand,
 		},
 	}
 )
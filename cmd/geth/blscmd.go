@@ -0,0 +1,276 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/common"
+	single "github.com/ethereum/go-ethereum/singleton"
+	"github.com/urfave/cli/v2"
+)
+
+var blsAccountCommand = &cli.Command{
+	Name:  "bls",
+	Usage: "Manage the BLS voting keys of miner accounts",
+	Description: `
+
+Manage the BLS voting keys of miner accounts. BLS keys are generated
+independently of the miner's account key, so either can be rotated without
+affecting the other.
+
+Each BLS key is authorized by a signature of its miner account, which is
+carried next to every vote and registers the key on-chain. Creating, importing
+or rotating a BLS key therefore unlocks the miner account from the keystore.
+
+When a --password file is given, its first line unlocks the miner account and
+the second line encrypts the BLS key.
+
+Keys are stored as EIP-2335 style keystores under <DATADIR>/blskeystore, keys
+replaced by a rotation are kept in <DATADIR>/blskeystore/retired.`,
+	Subcommands: []*cli.Command{
+		{
+			Name:      "new",
+			Usage:     "Create a new BLS key for a miner account",
+			Action:    blsCreate,
+			ArgsUsage: "<address>",
+			Flags:     blsFlags,
+			Description: `
+    geth account bls new <address>
+
+Generates a new BLS voting key for the miner account and prints its public key.
+Fails if the account already has a BLS key, use rotate to replace it.`,
+		},
+		{
+			Name:      "import",
+			Usage:     "Import a BLS key for a miner account",
+			Action:    blsImport,
+			ArgsUsage: "<address> <keyFile>",
+			Flags:     blsFlags,
+			Description: `
+    geth account bls import <address> <keyfile>
+
+Imports a BLS key from <keyfile>, either an EIP-2335 style keystore written by
+'geth account bls export' or an unencrypted 32 byte secret key in hexadecimal
+format. A previously stored key of the account is retired.
+
+When importing a keystore with a --password file, its third line decrypts the
+keystore.`,
+		},
+		{
+			Name:      "export",
+			Usage:     "Export the BLS key of a miner account",
+			Action:    blsExport,
+			ArgsUsage: "<address> <keyFile>",
+			Flags:     blsFlags,
+			Description: `
+    geth account bls export <address> <keyfile>
+
+Writes the BLS key of the miner account into <keyfile> as an EIP-2335 style
+keystore, encrypted with a new password. Exporting the key in unencrypted
+format is NOT supported.`,
+		},
+		{
+			Name:      "rotate",
+			Usage:     "Replace the BLS key of a miner account with a new one",
+			Action:    blsRotate,
+			ArgsUsage: "<address>",
+			Flags:     blsFlags,
+			Description: `
+    geth account bls rotate <address>
+
+Generates a new BLS voting key for the miner account and retires the current
+one, if any. The new key is registered on-chain with the first vote the miner
+casts after restarting.`,
+		},
+	},
+}
+
+var blsFlags = []cli.Flag{
+	utils.DataDirFlag,
+	utils.KeyStoreDirFlag,
+	utils.MinerBLSKeyDirFlag,
+	utils.PasswordFileFlag,
+	utils.LightKDFFlag,
+}
+
+// blsKeyDir returns the BLS keystore directory and the scrypt parameters
+// configured by the CLI flags.
+func blsKeyDir(ctx *cli.Context) (string, int, int) {
+	cfg := loadBaseConfig(ctx)
+
+	dir := cfg.Eth.Miner.BLSKeyDir
+	if ctx.IsSet(utils.MinerBLSKeyDirFlag.Name) {
+		dir = ctx.Path(utils.MinerBLSKeyDirFlag.Name)
+	}
+	if dir == "" {
+		dir = cfg.Node.ResolvePath(single.BLSKeyStoreDir)
+	}
+	if cfg.Node.UseLightweightKDF {
+		return dir, keystore.LightScryptN, keystore.LightScryptP
+	}
+	return dir, keystore.StandardScryptN, keystore.StandardScryptP
+}
+
+// blsAddress parses the miner account given as the first argument.
+func blsAddress(ctx *cli.Context, nargs int) common.Address {
+	if ctx.Args().Len() != nargs {
+		utils.Fatalf("Expected %d arguments, got %d", nargs, ctx.Args().Len())
+	}
+	addr := ctx.Args().First()
+	if !common.IsHexAddress(addr) {
+		utils.Fatalf("Invalid miner address %q", addr)
+	}
+	return common.HexToAddress(addr)
+}
+
+// storeBLSKey authorizes the BLS key with its miner account and stores it as
+// the active key of the account.
+func storeBLSKey(ctx *cli.Context, key *single.BLSKey) {
+	am := makeAccountManager(ctx)
+	backends := am.Backends(keystore.KeyStoreType)
+	if len(backends) == 0 {
+		utils.Fatalf("Keystore is not available")
+	}
+	ks := backends[0].(*keystore.KeyStore)
+	passwords := utils.MakePasswordList(ctx)
+
+	account, _ := unlockAccount(ks, key.Address.Hex(), 0, passwords)
+	if err := key.Authorize(func(hash []byte) ([]byte, error) { return ks.SignHash(account, hash) }); err != nil {
+		utils.Fatalf("Failed to authorize BLS key: %v", err)
+	}
+	dir, scryptN, scryptP := blsKeyDir(ctx)
+	password := utils.GetPassPhraseWithList("Your BLS key is locked with a password. Please give a password. Do not forget this password.", true, 1, passwords)
+
+	path, err := single.WriteBLSKey(dir, key, password, scryptN, scryptP)
+	if err != nil {
+		utils.Fatalf("Failed to store BLS key: %v", err)
+	}
+	proof, err := key.ProvePossession()
+	if err != nil {
+		utils.Fatalf("Failed to prove BLS key possession: %v", err)
+	}
+	fmt.Printf("Miner address:              %s\n", key.Address.Hex())
+	fmt.Printf("BLS public key:             %x\n", key.PublicKey())
+	fmt.Printf("BLS proof of possession:    %x\n", proof)
+	fmt.Printf("Path of the BLS key file:   %s\n", path)
+}
+
+// readStoredBLSKey decrypts the active BLS key of the account, prompting for its
+// password.
+func readStoredBLSKey(ctx *cli.Context, addr common.Address) *single.BLSKey {
+	dir, _, _ := blsKeyDir(ctx)
+	if _, err := os.Stat(single.BLSKeyFile(dir, addr)); err != nil {
+		utils.Fatalf("No BLS key stored for %s in %s", addr.Hex(), dir)
+	}
+	password := utils.GetPassPhraseWithList("Please give the password of the BLS key.", false, 1, utils.MakePasswordList(ctx))
+	key, err := single.ReadBLSKey(dir, addr, password)
+	if err != nil {
+		utils.Fatalf("Failed to read BLS key: %v", err)
+	}
+	return key
+}
+
+func blsCreate(ctx *cli.Context) error {
+	addr := blsAddress(ctx, 1)
+	dir, _, _ := blsKeyDir(ctx)
+	if _, err := os.Stat(single.BLSKeyFile(dir, addr)); err == nil {
+		utils.Fatalf("BLS key for %s already exists, use 'geth account bls rotate' to replace it", addr.Hex())
+	}
+	storeBLSKey(ctx, single.GenerateBLSKey(addr))
+	return nil
+}
+
+func blsImport(ctx *cli.Context) error {
+	addr := blsAddress(ctx, 2)
+	text, err := os.ReadFile(ctx.Args().Get(1))
+	if err != nil {
+		utils.Fatalf("Failed to read the BLS key file: %v", err)
+	}
+	storeBLSKey(ctx, readImportedBLSKey(ctx, addr, text))
+	return nil
+}
+
+// readImportedBLSKey loads the BLS key of the account from the contents of an
+// exported keystore, falling back to an unencrypted hex secret.
+func readImportedBLSKey(ctx *cli.Context, addr common.Address, text []byte) *single.BLSKey {
+	text = bytes.TrimSpace(text)
+	if !bytes.HasPrefix(text, []byte("{")) {
+		raw, err := hex.DecodeString(strings.TrimPrefix(string(text), "0x"))
+		if err != nil {
+			utils.Fatalf("Failed to decode the BLS key: %v", err)
+		}
+		secret, err := single.ParseBLSSecret(raw)
+		if err != nil {
+			utils.Fatalf("Failed to load the BLS key: %v", err)
+		}
+		return &single.BLSKey{Address: addr, Secret: secret}
+	}
+	password := utils.GetPassPhraseWithList("Please give the password of the BLS keystore.", false, 2, utils.MakePasswordList(ctx))
+	key, err := single.DecryptBLSKey(text, password)
+	if err != nil {
+		utils.Fatalf("Failed to decrypt the BLS keystore: %v", err)
+	}
+	if key.Address != addr {
+		utils.Fatalf("BLS keystore belongs to %s, not %s", key.Address.Hex(), addr.Hex())
+	}
+	return key
+}
+
+func blsExport(ctx *cli.Context) error {
+	addr := blsAddress(ctx, 2)
+	out := ctx.Args().Get(1)
+	if _, err := os.Stat(out); err == nil {
+		utils.Fatalf("Output file %s already exists", out)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		utils.Fatalf("Failed to check output file: %v", err)
+	}
+	key := readStoredBLSKey(ctx, addr)
+
+	_, scryptN, scryptP := blsKeyDir(ctx)
+	password := utils.GetPassPhraseWithList("Please give a password for the exported BLS key.", true, 2, utils.MakePasswordList(ctx))
+	keyjson, err := single.EncryptBLSKey(key, password, scryptN, scryptP)
+	if err != nil {
+		utils.Fatalf("Failed to encrypt BLS key: %v", err)
+	}
+	if err := os.WriteFile(out, keyjson, 0600); err != nil {
+		utils.Fatalf("Failed to write BLS key: %v", err)
+	}
+	fmt.Printf("BLS public key:  %x\n", key.PublicKey())
+	fmt.Printf("Exported to:     %s\n", out)
+	return nil
+}
+
+func blsRotate(ctx *cli.Context) error {
+	addr := blsAddress(ctx, 1)
+	dir, _, _ := blsKeyDir(ctx)
+	if _, err := os.Stat(single.BLSKeyFile(dir, addr)); err == nil {
+		old := readStoredBLSKey(ctx, addr)
+		fmt.Printf("Retiring BLS public key:    %x\n", old.PublicKey())
+	} else {
+		fmt.Printf("No BLS key stored for %s, creating one\n", addr.Hex())
+	}
+	storeBLSKey(ctx, single.GenerateBLSKey(addr))
+	return nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	single "github.com/ethereum/go-ethereum/singleton"
+)
+
+// Tests that a BLS key exported by geth account bls export can be imported back.
+func TestBLSExportImport(t *testing.T) {
+	t.Parallel()
+	var (
+		datadir  = t.TempDir()
+		keydir   = filepath.Join(datadir, "geth", single.BLSKeyStoreDir)
+		exported = filepath.Join(t.TempDir(), "exported.json")
+		password = filepath.Join(t.TempDir(), "password")
+	)
+	if err := os.WriteFile(password, []byte("account\nbls\nexport\n"), 0600); err != nil {
+		t.Fatalf("failed to write password file: %v", err)
+	}
+	runGeth(t, "zkscam", "init", "--datadir", datadir, "--password", password, "--lightkdf").WaitExit()
+
+	var cfg gethConfig
+	if err := loadConfig(filepath.Join(datadir, zkscamConfigFile), &cfg); err != nil {
+		t.Fatalf("failed to load the node config: %v", err)
+	}
+	miner := cfg.Eth.Miner.Etherbase
+	stored, err := single.ReadBLSKey(keydir, miner, "bls")
+	if err != nil {
+		t.Fatalf("failed to read the BLS key: %v", err)
+	}
+	runGeth(t, "account", "bls", "export", "--datadir", datadir, "--password", password, "--lightkdf", miner.Hex(), exported).WaitExit()
+
+	geth := runGeth(t, "account", "bls", "import", "--datadir", datadir, "--password", password, "--lightkdf", miner.Hex(), exported)
+	geth.WaitExit()
+	if geth.ExitStatus() != 0 {
+		t.Fatalf("import failed with exit status %d: %s", geth.ExitStatus(), geth.StderrText())
+	}
+	if retired, _ := os.ReadDir(filepath.Join(keydir, "retired")); len(retired) != 1 {
+		t.Fatalf("retired key count mismatch: have %d, want 1", len(retired))
+	}
+	imported, err := single.ReadBLSKey(keydir, miner, "bls")
+	if err != nil {
+		t.Fatalf("failed to read the imported BLS key: %v", err)
+	}
+	if !bytes.Equal(imported.PublicKey(), stored.PublicKey()) {
+		t.Fatalf("imported key mismatch: have %x, want %x", imported.PublicKey(), stored.PublicKey())
+	}
+}
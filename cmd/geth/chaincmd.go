@@ -54,6 +54,10 @@ var (
 			utils.CachePreimagesFlag,
 			utils.OverrideCancun,
 			utils.OverrideVerkle,
+			utils.OverrideZkscamBLS,
+			utils.OverrideZkscamVoteData,
+			utils.OverrideZkscamVotePayload,
+			utils.OverrideZkscamVoteDigest,
 		}, utils.DatabaseFlags),
 		Description: `
 The init command initializes a new genesis block and definition for the network.
@@ -220,6 +224,22 @@ func initGenesis(ctx *cli.Context) error {
 		v := ctx.Uint64(utils.OverrideVerkle.Name)
 		overrides.OverrideVerkle = &v
 	}
+	if ctx.IsSet(utils.OverrideZkscamBLS.Name) {
+		v := ctx.Uint64(utils.OverrideZkscamBLS.Name)
+		overrides.OverrideZkscamBLS = &v
+	}
+	if ctx.IsSet(utils.OverrideZkscamVoteData.Name) {
+		v := ctx.Uint64(utils.OverrideZkscamVoteData.Name)
+		overrides.OverrideZkscamVoteData = &v
+	}
+	if ctx.IsSet(utils.OverrideZkscamVotePayload.Name) {
+		v := ctx.Uint64(utils.OverrideZkscamVotePayload.Name)
+		overrides.OverrideZkscamVotePayload = &v
+	}
+	if ctx.IsSet(utils.OverrideZkscamVoteDigest.Name) {
+		v := ctx.Uint64(utils.OverrideZkscamVoteDigest.Name)
+		overrides.OverrideZkscamVoteDigest = &v
+	}
 	for _, name := range []string{"chaindata", "lightchaindata"} {
 		chaindb, err := stack.OpenDatabaseWithFreezer(name, 0, 0, ctx.String(utils.AncientFlag.Name), "", false)
 		if err != nil {
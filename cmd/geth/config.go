@@ -34,6 +34,9 @@ import (
 	"github.com/ethereum/go-ethereum/cmd/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/clique"
+	"github.com/ethereum/go-ethereum/contracts"
 	"github.com/ethereum/go-ethereum/eth/catalyst"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
@@ -177,6 +180,22 @@ func makeFullNode(ctx *cli.Context) (*node.Node, ethapi.Backend) {
 		v := ctx.Uint64(utils.OverrideVerkle.Name)
 		cfg.Eth.OverrideVerkle = &v
 	}
+	if ctx.IsSet(utils.OverrideZkscamBLS.Name) {
+		v := ctx.Uint64(utils.OverrideZkscamBLS.Name)
+		cfg.Eth.OverrideZkscamBLS = &v
+	}
+	if ctx.IsSet(utils.OverrideZkscamVoteData.Name) {
+		v := ctx.Uint64(utils.OverrideZkscamVoteData.Name)
+		cfg.Eth.OverrideZkscamVoteData = &v
+	}
+	if ctx.IsSet(utils.OverrideZkscamVotePayload.Name) {
+		v := ctx.Uint64(utils.OverrideZkscamVotePayload.Name)
+		cfg.Eth.OverrideZkscamVotePayload = &v
+	}
+	if ctx.IsSet(utils.OverrideZkscamVoteDigest.Name) {
+		v := ctx.Uint64(utils.OverrideZkscamVoteDigest.Name)
+		cfg.Eth.OverrideZkscamVoteDigest = &v
+	}
 	backend, eth := utils.RegisterEthService(stack, &cfg.Eth)
 
 	// Create gauge with geth system and build information
@@ -221,6 +240,23 @@ func makeFullNode(ctx *cli.Context) (*node.Node, ethapi.Backend) {
 		}
 		catalyst.RegisterSimulatedBeaconAPIs(stack, simBeacon)
 		stack.RegisterLifecycle(simBeacon)
+	} else if ctx.IsSet(utils.DeveloperZkscamFlag.Name) {
+		engine := eth.Engine()
+		if b, ok := engine.(*beacon.Beacon); ok {
+			engine = b.InnerEngine()
+		}
+		c, ok := engine.(*clique.Clique)
+		if !ok {
+			utils.Fatalf("vote-based dev mode requires the clique engine, have %T", engine)
+		}
+		// Serve the stake reads of the engine in-process, instead of over the
+		// HTTP-RPC endpoint of a node
+		contracts.UseClient(stack.Attach())
+
+		voters := clique.DeveloperVoterKeys(ctx.Int(utils.DeveloperZkscamVotersFlag.Name))
+		if err := c.EnableDevMode(voters); err != nil {
+			utils.Fatalf("failed to enable vote-based dev mode: %v", err)
+		}
 	} else {
 		err := catalyst.Register(stack, eth)
 		if err != nil {
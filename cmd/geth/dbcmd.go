@@ -18,6 +18,7 @@ package main
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
@@ -31,18 +32,29 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/console/prompt"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state/snapshot"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/internal/flags"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/trie"
 	"github.com/olekukonko/tablewriter"
 	"github.com/urfave/cli/v2"
 )
 
 var (
+	rollbackDryRunFlag = &cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "Only report what a rollback would discard",
+	}
+	rollbackReinjectFlag = &cli.BoolFlag{
+		Name:  "reinject",
+		Usage: "Append the transactions of the discarded blocks to the transaction journal",
+	}
 	removeStateDataFlag = &cli.BoolFlag{
 		Name:  "remove.state",
 		Usage: "If set, selects the state data for removal",
@@ -79,6 +91,7 @@ Remove blockchain and state databases`,
 			dbExportCmd,
 			dbMetadataCmd,
 			dbCheckStateContentCmd,
+			dbRollbackCmd,
 		},
 	}
 	dbInspectCmd = &cli.Command{
@@ -194,6 +207,27 @@ WARNING: This is a low-level operation which may cause database corruption!`,
 		}, utils.NetworkFlags, utils.DatabaseFlags),
 		Description: "Exports the specified chain data to an RLP encoded stream, optionally gzip-compressed.",
 	}
+	dbRollbackCmd = &cli.Command{
+		Action:    dbRollback,
+		Name:      "rollback",
+		Usage:     "Rewind the chain to a given block",
+		ArgsUsage: "<number|hash>",
+		Flags: flags.Merge([]cli.Flag{
+			utils.SyncModeFlag,
+			rollbackDryRunFlag,
+			rollbackReinjectFlag,
+		}, utils.NetworkFlags, utils.DatabaseFlags),
+		Description: `This command rewinds the chain to the given canonical block, e.g. to leave a fork
+the node followed after a bad vote. Headers, bodies, receipts, the freezer, the snapshot
+and the state history are rewound consistently, exactly as debug_setHead would. If the
+state of the target block is not available, the chain is rewound further to the
+nearest block with state.
+
+With --dry-run nothing is modified, only the discarded blocks are listed by number and
+hash, together with the hashes of the transactions --reinject would journal. With
+--reinject the transactions of the discarded blocks are appended to the local
+transaction journal, so the transaction pool picks them up again on the next start.`,
+	}
 	dbMetadataCmd = &cli.Command{
 		Action: showMetaData,
 		Name:   "metadata",
@@ -759,3 +793,143 @@ func showMetaData(ctx *cli.Context) error {
 	table.Render()
 	return nil
 }
+
+func dbRollback(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("required arguments: %v", ctx.Command.ArgsUsage)
+	}
+	stack, config := makeConfigNode(ctx)
+	defer stack.Close()
+
+	dryRun := ctx.Bool(rollbackDryRunFlag.Name)
+	chain, db := utils.MakeChain(ctx, stack, dryRun)
+	defer db.Close()
+	if !dryRun {
+		defer chain.Stop()
+	}
+
+	// Resolve the rollback target, which must be on the canonical chain
+	var (
+		arg    = ctx.Args().First()
+		number uint64
+	)
+	if strings.HasPrefix(arg, "0x") && len(arg) == 2+2*common.HashLength {
+		hash := common.HexToHash(arg)
+		num := rawdb.ReadHeaderNumber(db, hash)
+		if num == nil {
+			return fmt.Errorf("unknown block %s", hash.Hex())
+		}
+		if canon := rawdb.ReadCanonicalHash(db, *num); canon != hash {
+			return fmt.Errorf("block %s is not canonical, canonical #%d is %s", hash.Hex(), *num, canon.Hex())
+		}
+		number = *num
+	} else {
+		num, err := strconv.ParseUint(arg, 0, 64)
+		if err != nil {
+			return fmt.Errorf("invalid block number or hash %q", arg)
+		}
+		number = num
+	}
+	head := chain.CurrentBlock()
+	if number >= head.Number.Uint64() {
+		return fmt.Errorf("target #%d is not below the current head #%d", number, head.Number.Uint64())
+	}
+	target := chain.GetHeaderByNumber(number)
+	if target == nil {
+		return fmt.Errorf("block #%d not found", number)
+	}
+	// Collect the discarded blocks for the report and the journal
+	var (
+		blocks  []*types.Block
+		dropped types.Transactions
+	)
+	for n := number + 1; n <= head.Number.Uint64(); n++ {
+		block := chain.GetBlockByNumber(n)
+		if block == nil {
+			log.Warn("Discarded block missing, skipping its transactions", "number", n)
+			continue
+		}
+		blocks = append(blocks, block)
+		dropped = append(dropped, block.Transactions()...)
+	}
+	log.Info("Rollback target", "number", number, "hash", target.Hash(), "head", head.Number, "headhash", head.Hash(),
+		"blocks", head.Number.Uint64()-number, "txs", len(dropped), "frozen", frozenItems(db))
+	if !chain.HasState(target.Root) {
+		log.Warn("State of the target block is missing, the chain will be rewound further", "number", number, "root", target.Root)
+	}
+	// Validate the reinjection before touching the chain, as the discarded
+	// transactions can't be recovered once the chain is rewound
+	var journal string
+	if ctx.Bool(rollbackReinjectFlag.Name) && len(dropped) > 0 {
+		if config.Eth.TxPool.NoLocals || config.Eth.TxPool.Journal == "" {
+			return errors.New("transaction journal disabled, cannot reinject transactions")
+		}
+		journal = stack.ResolvePath(config.Eth.TxPool.Journal)
+	}
+	if dryRun {
+		fmt.Printf("Dry run: would discard %d blocks (#%d - #%d) with %d transactions\n",
+			head.Number.Uint64()-number, number+1, head.Number.Uint64(), len(dropped))
+		for _, block := range blocks {
+			fmt.Printf("  #%d %s (%d txs)\n", block.NumberU64(), block.Hash().Hex(), len(block.Transactions()))
+		}
+		if journal != "" {
+			fmt.Printf("Would reinject %d transactions into %s\n", len(dropped), journal)
+			for _, tx := range dropped {
+				fmt.Printf("  %s\n", tx.Hash().Hex())
+			}
+		}
+		return nil
+	}
+	return rollbackChain(chain, number, dropped, journal)
+}
+
+// rollbackChain rewinds the chain to the given block and appends the discarded
+// transactions to the journal at the given path, unless it's empty. The journal
+// is opened before rewinding, so an unusable journal leaves the chain untouched.
+func rollbackChain(chain *core.BlockChain, number uint64, dropped types.Transactions, journal string) error {
+	var file *os.File
+	if journal != "" {
+		var err error
+		if file, err = os.OpenFile(journal, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644); err != nil {
+			return fmt.Errorf("failed to open transaction journal: %v", err)
+		}
+	}
+	if err := chain.SetHead(number); err != nil {
+		if file != nil {
+			file.Close()
+		}
+		return fmt.Errorf("rollback failed: %v", err)
+	}
+	current := chain.CurrentBlock()
+	log.Info("Rolled back chain", "number", current.Number, "hash", current.Hash())
+
+	if file != nil {
+		err := appendTxJournal(file, dropped)
+		if cerr := file.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return fmt.Errorf("failed to reinject transactions: %v", err)
+		}
+		log.Info("Reinjected discarded transactions", "journal", journal, "txs", len(dropped))
+	}
+	return nil
+}
+
+// frozenItems returns the number of items in the freezer, for reporting.
+func frozenItems(db ethdb.Database) uint64 {
+	frozen, _ := db.Ancients()
+	return frozen
+}
+
+// appendTxJournal appends the transactions to the local transaction journal in
+// the format the transaction pool loads on startup. Closing the file is left to
+// the caller.
+func appendTxJournal(file *os.File, txs types.Transactions) error {
+	for _, tx := range txs {
+		if err := rlp.Encode(file, tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
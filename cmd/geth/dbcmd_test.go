@@ -0,0 +1,117 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// newRollbackChain creates a chain of the given length with one transaction in
+// every block. Blocks are voted on, as the fork choice relies on the votes.
+func newRollbackChain(t *testing.T, n int) *core.BlockChain {
+	var (
+		key, _ = crypto.GenerateKey()
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		gspec  = &core.Genesis{
+			Config:     params.TestChainConfig,
+			Alloc:      core.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+			Votes:      new(big.Int),
+			TotalVotes: new(big.Int),
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+	voter, err := core.NewVoter(key, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, ethash.NewFaker(), n, func(i int, b *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(b.TxNonce(addr), common.Address{0x01}, big.NewInt(1), params.TxGas, b.BaseFee(), nil), signer, key)
+		b.AddTx(tx)
+		b.SetVoters(voter)
+	})
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(chain.Stop)
+	return chain
+}
+
+// Tests that the rollback rewinds the chain and reinjects the discarded
+// transactions into the journal.
+func TestRollbackChain(t *testing.T) {
+	t.Parallel()
+	chain := newRollbackChain(t, 4)
+
+	var dropped types.Transactions
+	for n := uint64(3); n <= 4; n++ {
+		dropped = append(dropped, chain.GetBlockByNumber(n).Transactions()...)
+	}
+	journal := filepath.Join(t.TempDir(), "transactions.rlp")
+	if err := rollbackChain(chain, 2, dropped, journal); err != nil {
+		t.Fatalf("rollback failed: %v", err)
+	}
+	if head := chain.CurrentBlock().Number.Uint64(); head != 2 {
+		t.Fatalf("head mismatch: have %d, want 2", head)
+	}
+	blob, err := os.ReadFile(journal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream := rlp.NewStream(bytes.NewReader(blob), 0)
+	for i, want := range dropped {
+		tx := new(types.Transaction)
+		if err := stream.Decode(tx); err != nil {
+			t.Fatalf("journal tx %d: %v", i, err)
+		}
+		if tx.Hash() != want.Hash() {
+			t.Fatalf("journal tx %d: have %x, want %x", i, tx.Hash(), want.Hash())
+		}
+	}
+}
+
+// Tests that an unusable journal aborts the rollback before the chain is
+// rewound, so the discarded transactions aren't lost.
+func TestRollbackChainBadJournal(t *testing.T) {
+	t.Parallel()
+	chain := newRollbackChain(t, 4)
+
+	journal := filepath.Join(t.TempDir(), "missing", "transactions.rlp")
+	if err := rollbackChain(chain, 2, chain.GetBlockByNumber(4).Transactions(), journal); err == nil {
+		t.Fatal("rollback succeeded with an unusable journal")
+	}
+	if head := chain.CurrentBlock().Number.Uint64(); head != 4 {
+		t.Fatalf("chain rewound despite the failure: head %d, want 4", head)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Tests that the zkscam developer mode seals voted blocks on its own, reading
+// the stakes in-process without any HTTP-RPC endpoint.
+func TestDevZkscamSealing(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("IPC endpoint in the datadir")
+	}
+	t.Parallel()
+	ipc := filepath.Join(t.TempDir(), "geth.ipc")
+	geth := runGeth(t,
+		"--dev.zkscam", "--dev.period", "1", "--ipcpath", ipc,
+		"--port", "0", "--authrpc.port", "0", "--maxpeers", "0", "--nodiscover")
+	defer geth.Kill()
+
+	waitForEndpoint(t, ipc, 10*time.Second)
+	client, err := ethclient.Dial(ipc)
+	if err != nil {
+		t.Fatalf("failed to dial node: %v", err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	for {
+		number, err := client.BlockNumber(ctx)
+		if err != nil {
+			t.Fatalf("failed to retrieve head: %v", err)
+		}
+		if number >= 2 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			t.Fatalf("no blocks sealed, head at %d", number)
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+	header, err := client.HeaderByNumber(ctx, big.NewInt(1))
+	if err != nil {
+		t.Fatalf("failed to retrieve block 1: %v", err)
+	}
+	if len(header.MinerAddresses) == 0 || header.Votes == nil || header.Votes.Sign() == 0 {
+		t.Fatalf("block 1 not voted on: miners %v, votes %v", header.MinerAddresses, header.Votes)
+	}
+}
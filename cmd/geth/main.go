@@ -68,6 +68,10 @@ var (
 		utils.SmartCardDaemonPathFlag,
 		utils.OverrideCancun,
 		utils.OverrideVerkle,
+		utils.OverrideZkscamBLS,
+		utils.OverrideZkscamVoteData,
+		utils.OverrideZkscamVotePayload,
+		utils.OverrideZkscamVoteDigest,
 		utils.EnablePersonal,
 		utils.TxPoolLocalsFlag,
 		utils.TxPoolNoLocalsFlag,
@@ -121,9 +125,13 @@ var (
 		utils.MinerGasLimitFlag,
 		utils.MinerGasPriceFlag,
 		utils.MinerEtherbaseFlag,
+		utils.MinerKeyFileFlag,
+		utils.MinerBLSKeyDirFlag,
+		utils.MinerBLSPasswordFlag,
 		utils.MinerExtraDataFlag,
 		utils.MinerRecommitIntervalFlag,
 		utils.MinerNewPayloadTimeout,
+		utils.MinerTxCutoffFlag,
 		utils.NATFlag,
 		utils.NoDiscoverFlag,
 		utils.DiscoveryV4Flag,
@@ -136,10 +144,13 @@ var (
 		utils.DeveloperFlag,
 		utils.DeveloperGasLimitFlag,
 		utils.DeveloperPeriodFlag,
+		utils.DeveloperZkscamFlag,
+		utils.DeveloperZkscamVotersFlag,
 		utils.VMEnableDebugFlag,
 		utils.NetworkIdFlag,
 		utils.EthStatsURLFlag,
 		utils.NoCompactionFlag,
+		utils.ConsensusAuditLogFlag,
 		utils.GpoBlocksFlag,
 		utils.GpoPercentileFlag,
 		utils.GpoMaxGasPriceFlag,
@@ -176,6 +187,7 @@ var (
 		utils.RPCGlobalGasCapFlag,
 		utils.RPCGlobalEVMTimeoutFlag,
 		utils.RPCGlobalTxFeeCapFlag,
+		utils.RPCVotePayloadFlag,
 		utils.AllowUnprotectedTxs,
 		utils.BatchRequestLimit,
 		utils.BatchResponseMaxSize,
@@ -218,6 +230,10 @@ func init() {
 		// See accountcmd.go:
 		accountCommand,
 		walletCommand,
+		// See votecmd.go:
+		voteHistoryCommand,
+		// See zkscamcmd.go:
+		zkscamCommand,
 		// See consolecmd.go:
 		consoleCommand,
 		attachCommand,
@@ -295,6 +311,9 @@ func prepare(ctx *cli.Context) {
 	case ctx.IsSet(utils.HoleskyFlag.Name):
 		log.Info("Starting Geth on Holesky testnet...")
 
+	case ctx.IsSet(utils.ZkscamFlag.Name):
+		log.Info("Starting Geth on ZKScam network...")
+
 	case ctx.IsSet(utils.DeveloperFlag.Name):
 		log.Info("Starting Geth in ephemeral dev mode...")
 		log.Warn(`You are running Geth in --dev mode. Please note the following:
@@ -313,6 +332,20 @@ func prepare(ctx *cli.Context) {
      to 0, and discovery is disabled.
 `)
 
+	case ctx.IsSet(utils.DeveloperZkscamFlag.Name):
+		log.Info("Starting Geth in ephemeral vote-based dev mode...")
+		log.Warn(`You are running Geth in --dev.zkscam mode. Please note the following:
+
+  1. This mode is only intended for development against the vote-based clique engine,
+     without assumptions on security or persistence.
+  2. The developer account is the only authorized signer. Its stake and the stakes of the
+     simulated voters are pre-allocated in the genesis token contract.
+  3. Blocks are sealed through the regular vote aggregation and verification path. With
+     --dev.period 0 the client only seals blocks if transactions are pending.
+  4. Networking is disabled; there is no listen-address, the maximum number of peers is set
+     to 0, and discovery is disabled.
+`)
+
 	case !ctx.IsSet(utils.NetworkIdFlag.Name):
 		log.Info("Starting Geth on Ethereum mainnet...")
 	}
@@ -322,7 +355,9 @@ func prepare(ctx *cli.Context) {
 		if !ctx.IsSet(utils.HoleskyFlag.Name) &&
 			!ctx.IsSet(utils.SepoliaFlag.Name) &&
 			!ctx.IsSet(utils.GoerliFlag.Name) &&
-			!ctx.IsSet(utils.DeveloperFlag.Name) {
+			!ctx.IsSet(utils.ZkscamFlag.Name) &&
+			!ctx.IsSet(utils.DeveloperFlag.Name) &&
+			!ctx.IsSet(utils.DeveloperZkscamFlag.Name) {
 			// Nope, we're really on mainnet. Bump that cache up!
 			log.Info("Bumping default cache on mainnet", "provided", ctx.Int(utils.CacheFlag.Name), "updated", 4096)
 			ctx.Set(utils.CacheFlag.Name, strconv.Itoa(4096))
@@ -431,7 +466,7 @@ func startNode(ctx *cli.Context, stack *node.Node, backend ethapi.Backend, isCon
 	}
 
 	// Start auxiliary services if enabled
-	if ctx.Bool(utils.MiningEnabledFlag.Name) {
+	if ctx.Bool(utils.MiningEnabledFlag.Name) || ctx.Bool(utils.DeveloperZkscamFlag.Name) {
 		// Mining only makes sense if a full Ethereum node is running
 		if ctx.String(utils.SyncModeFlag.Name) == "light" {
 			utils.Fatalf("Light clients do not support mining")
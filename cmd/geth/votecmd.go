@@ -0,0 +1,116 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/consensus/clique"
+	"github.com/urfave/cli/v2"
+)
+
+var voteHistoryCommand = &cli.Command{
+	Name:  "votehistory",
+	Usage: "Manage the record of votes signed by local miners",
+	Description: `
+The vote history records every vote signed by the miners of this node, so that
+a restarted miner never signs a different block at a height it already voted
+at. When migrating a miner to new hardware, export the history on the old node
+and import it on the new one before mining is started.`,
+	Subcommands: []*cli.Command{
+		{
+			Name:      "export",
+			Usage:     "Export the vote history into a file",
+			ArgsUsage: "<filename>",
+			Action:    exportVoteHistory,
+			Flags:     []cli.Flag{utils.DataDirFlag},
+			Description: `
+    geth votehistory export <filename>
+
+Writes all recorded votes into <filename> in JSON format.`,
+		},
+		{
+			Name:      "import",
+			Usage:     "Import a vote history from a file",
+			ArgsUsage: "<filename>",
+			Action:    importVoteHistory,
+			Flags:     []cli.Flag{utils.DataDirFlag},
+			Description: `
+    geth votehistory import <filename>
+
+Merges the votes exported by another node into the local history. The import
+is aborted if a vote conflicts with one recorded locally.`,
+		},
+	},
+}
+
+// openVoteHistory opens the vote history database of the node.
+func openVoteHistory(ctx *cli.Context, readonly bool) (*clique.VoteHistory, func()) {
+	stack, _ := makeConfigNode(ctx)
+	db, err := stack.OpenDatabase(clique.VoteHistoryDB, 16, 16, "", readonly)
+	if err != nil {
+		utils.Fatalf("Failed to open vote history: %v", err)
+	}
+	return clique.NewVoteHistory(db), func() {
+		db.Close()
+		stack.Close()
+	}
+}
+
+func exportVoteHistory(ctx *cli.Context) error {
+	if ctx.Args().Len() != 1 {
+		utils.Fatalf("This command requires an argument.")
+	}
+	history, closeFn := openVoteHistory(ctx, true)
+	defer closeFn()
+
+	out, err := os.OpenFile(ctx.Args().First(), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		utils.Fatalf("Failed to create export file: %v", err)
+	}
+	defer out.Close()
+
+	n, err := history.Export(out)
+	if err != nil {
+		utils.Fatalf("Export error: %v", err)
+	}
+	fmt.Printf("Exported %d votes\n", n)
+	return nil
+}
+
+func importVoteHistory(ctx *cli.Context) error {
+	if ctx.Args().Len() != 1 {
+		utils.Fatalf("This command requires an argument.")
+	}
+	in, err := os.Open(ctx.Args().First())
+	if err != nil {
+		utils.Fatalf("Failed to open import file: %v", err)
+	}
+	defer in.Close()
+
+	history, closeFn := openVoteHistory(ctx, false)
+	defer closeFn()
+
+	n, err := history.Import(in)
+	if err != nil {
+		utils.Fatalf("Import error after %d votes: %v", n, err)
+	}
+	fmt.Printf("Imported %d votes\n", n)
+	return nil
+}
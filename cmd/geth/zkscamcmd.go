@@ -0,0 +1,309 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/downloader"
+	"github.com/ethereum/go-ethereum/internal/flags"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/params"
+	single "github.com/ethereum/go-ethereum/singleton"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	zkscamKeyFileFlag = &cli.PathFlag{
+		Name:      "keyfile",
+		Usage:     "Legacy plaintext miner key file (hex key and address lines) to import into the keystore",
+		TakesFile: true,
+	}
+	zkscamForceFlag = &cli.BoolFlag{
+		Name:  "force",
+		Usage: "Wipe an existing chain and overwrite the node config",
+	}
+)
+
+var zkscamCommand = &cli.Command{
+	Name:  "zkscam",
+	Usage: "Bootstrap a node of the ZKScam network",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "init",
+			Usage:  "Initialize the datadir, miner keys and node config",
+			Action: zkscamInit,
+			Flags: flags.Merge([]cli.Flag{
+				zkscamKeyFileFlag,
+				zkscamForceFlag,
+				utils.KeyStoreDirFlag,
+				utils.MinerBLSKeyDirFlag,
+				utils.MinerEtherbaseFlag,
+				utils.PasswordFileFlag,
+				utils.LightKDFFlag,
+				utils.CachePreimagesFlag,
+			}, utils.DatabaseFlags),
+			Description: `
+    geth zkscam init [--keyfile <file>] [--force]
+
+Prepares the datadir to run a ZKScam miner:
+
+ - writes the embedded ZKScam genesis into the chain database,
+ - imports the miner key from --keyfile into the keystore, or uses the account
+   given by --miner.etherbase, or the only keystore account, or generates one,
+ - generates the BLS voting key of the miner and authorizes it with the miner
+   account; the key is registered on-chain with the first vote,
+ - writes <DATADIR>/config.toml, to be used with 'geth --config'.
+
+The command can be run repeatedly, steps already done are skipped. A chain from
+another genesis is never wiped unless --force is given, which also overwrites
+the node config. The vote history of the miners is always kept.
+
+When a --password file is given, its first line unlocks the miner account and
+the second line encrypts the BLS key. The BLS password is then also written to
+<DATADIR>/blspassword, which the node config unlocks the BLS key with.`,
+		},
+	},
+}
+
+const (
+	// zkscamConfigFile is the name of the node config written into the datadir.
+	zkscamConfigFile = "config.toml"
+
+	// zkscamBLSPasswordFile is the name of the BLS key password file written
+	// into the datadir if the passwords are given as a file.
+	zkscamBLSPasswordFile = "blspassword"
+)
+
+func zkscamInit(ctx *cli.Context) error {
+	stack, cfg := makeConfigNode(ctx)
+	defer stack.Close()
+
+	net := zkscamMainnet()
+	initZkscamChain(ctx, stack, net)
+
+	miner := zkscamMinerAccount(ctx, stack)
+	fmt.Printf("Miner address:              %s\n", miner.Address.Hex())
+
+	dir, _, _ := blsKeyDir(ctx)
+	if _, err := os.Stat(single.BLSKeyFile(dir, miner.Address)); err == nil {
+		fmt.Printf("Using the stored BLS key:   %s\n", single.BLSKeyFile(dir, miner.Address))
+	} else {
+		storeBLSKey(ctx, single.GenerateBLSKey(miner.Address))
+	}
+
+	path := filepath.Join(stack.DataDir(), zkscamConfigFile)
+	if _, err := os.Stat(path); err == nil && !ctx.Bool(zkscamForceFlag.Name) {
+		fmt.Printf("Keeping the node config:    %s\n", path)
+	} else {
+		var blsPasswordFile string
+		if passwords := utils.MakePasswordList(ctx); len(passwords) > 0 {
+			blsPasswordFile = filepath.Join(stack.DataDir(), zkscamBLSPasswordFile)
+			if err := os.WriteFile(blsPasswordFile, []byte(utils.GetPassPhraseWithList("", false, 1, passwords)), 0600); err != nil {
+				utils.Fatalf("Failed to write the BLS password file: %v", err)
+			}
+			fmt.Printf("Wrote the BLS password:     %s\n", blsPasswordFile)
+		}
+		writeZkscamConfig(path, cfg, net, miner.Address, blsPasswordFile)
+		fmt.Printf("Wrote the node config:      %s\n", path)
+	}
+	var written gethConfig
+	if err := loadConfig(path, &written); err != nil {
+		utils.Fatalf("Failed to load the node config: %v", err)
+	}
+	passwordFile := ctx.Path(utils.PasswordFileFlag.Name)
+	if passwordFile != "" {
+		if abs, err := filepath.Abs(passwordFile); err == nil {
+			passwordFile = abs
+		}
+	}
+	fmt.Printf("\nStart mining with: %s\n", zkscamMineCommand(path, &written, passwordFile))
+	return nil
+}
+
+// zkscamMineCommand returns the command line starting to mine with the given
+// node config. The miner account is unlocked with the given password file, or
+// interactively if none is given. Configs without a BLS password file get a
+// placeholder for it, as the BLS key can't be unlocked interactively.
+func zkscamMineCommand(path string, cfg *gethConfig, passwordFile string) string {
+	cmd := fmt.Sprintf("geth --config %s --%s %s", path, utils.UnlockedAccountFlag.Name, cfg.Eth.Miner.Etherbase.Hex())
+	if passwordFile != "" {
+		cmd += fmt.Sprintf(" --%s %s", utils.PasswordFileFlag.Name, passwordFile)
+	}
+	if cfg.Eth.Miner.BLSPasswordFile == "" {
+		cmd += fmt.Sprintf(" --%s <BLS password file>", utils.MinerBLSPasswordFlag.Name)
+	}
+	return cmd + " --" + utils.MiningEnabledFlag.Name
+}
+
+// zkscamNetwork is the chain specification of a ZKScam network.
+type zkscamNetwork struct {
+	genesis   *core.Genesis
+	hash      common.Hash
+	networkID uint64
+	bootnodes []string
+}
+
+// zkscamMainnet returns the chain specification of the ZKScam network.
+func zkscamMainnet() *zkscamNetwork {
+	return &zkscamNetwork{core.ZkscamGenesisBlock(), params.ZkscamGenesisHash, params.ZkscamNetworkID, params.ZkscamBootnodes}
+}
+
+// initZkscamChain writes the ZKScam genesis into the chain databases. Databases
+// already holding the ZKScam chain are left alone, databases of another chain
+// are only wiped if forced to.
+func initZkscamChain(ctx *cli.Context, stack *node.Node, net *zkscamNetwork) {
+	genesis := net.genesis
+	ancient := ctx.String(utils.AncientFlag.Name)
+
+	for _, name := range []string{"chaindata", "lightchaindata"} {
+		chaindb, err := stack.OpenDatabaseWithFreezer(name, 0, 0, ancient, "", false)
+		if err != nil {
+			utils.Fatalf("Failed to open database: %v", err)
+		}
+		if stored := rawdb.ReadCanonicalHash(chaindb, 0); stored != (common.Hash{}) {
+			chaindb.Close()
+			if !ctx.Bool(zkscamForceFlag.Name) {
+				if stored == net.hash {
+					log.Info("Chain already initialized", "database", name, "hash", stored)
+					continue
+				}
+				utils.Fatalf("Database %s holds another chain (genesis %x), use --force to wipe it", name, stored)
+			}
+			removeZkscamChain(stack, name, ancient)
+
+			if chaindb, err = stack.OpenDatabaseWithFreezer(name, 0, 0, ancient, "", false); err != nil {
+				utils.Fatalf("Failed to open database: %v", err)
+			}
+		}
+		triedb := utils.MakeTrieDatabase(ctx, chaindb, ctx.Bool(utils.CachePreimagesFlag.Name), false, genesis.IsVerkle())
+		_, hash, err := core.SetupGenesisBlock(chaindb, triedb, genesis)
+		triedb.Close()
+		chaindb.Close()
+		if err != nil {
+			utils.Fatalf("Failed to write genesis block: %v", err)
+		}
+		log.Info("Successfully wrote genesis state", "database", name, "hash", hash)
+	}
+}
+
+// removeZkscamChain deletes a chain database, including its ancient store if it
+// is kept outside of the database directory.
+func removeZkscamChain(stack *node.Node, name string, ancient string) {
+	paths := []string{stack.ResolvePath(name)}
+	if name == "chaindata" && ancient != "" {
+		if !filepath.IsAbs(ancient) {
+			ancient = stack.ResolvePath(ancient)
+		}
+		paths = append(paths, ancient)
+	}
+	for _, path := range paths {
+		log.Info("Removing chain database", "path", path)
+		if err := os.RemoveAll(path); err != nil {
+			utils.Fatalf("Failed to remove %s: %v", path, err)
+		}
+	}
+}
+
+// zkscamMinerAccount returns the keystore account of the miner, importing or
+// generating it if needed.
+func zkscamMinerAccount(ctx *cli.Context, stack *node.Node) accounts.Account {
+	backends := stack.AccountManager().Backends(keystore.KeyStoreType)
+	if len(backends) == 0 {
+		utils.Fatalf("Keystore is not available")
+	}
+	ks := backends[0].(*keystore.KeyStore)
+	passwords := utils.MakePasswordList(ctx)
+
+	if file := ctx.Path(zkscamKeyFileFlag.Name); file != "" {
+		key, err := single.ReadKeyFile(file)
+		if err != nil {
+			utils.Fatalf("Failed to load the miner key file: %v", err)
+		}
+		if addr := crypto.PubkeyToAddress(key.PublicKey); ks.HasAddress(addr) {
+			log.Info("Miner key already in the keystore", "address", addr)
+			return accounts.Account{Address: addr}
+		}
+		password := utils.GetPassPhraseWithList("Your miner key is locked with a password. Please give a password. Do not forget this password.", true, 0, passwords)
+		account, err := ks.ImportECDSA(key, password)
+		if err != nil {
+			utils.Fatalf("Failed to import the miner key: %v", err)
+		}
+		fmt.Printf("Imported the miner key:     %s\n", account.URL.Path)
+		fmt.Printf("The key file %s is no longer needed, consider deleting it\n", file)
+		return account
+	}
+	if ctx.IsSet(utils.MinerEtherbaseFlag.Name) {
+		account, err := utils.MakeAddress(ks, ctx.String(utils.MinerEtherbaseFlag.Name))
+		if err != nil {
+			utils.Fatalf("Invalid miner account: %v", err)
+		}
+		if !ks.HasAddress(account.Address) {
+			utils.Fatalf("Miner account %s is not in the keystore", account.Address.Hex())
+		}
+		return account
+	}
+	switch existing := ks.Accounts(); len(existing) {
+	case 0:
+		password := utils.GetPassPhraseWithList("Your new miner account is locked with a password. Please give a password. Do not forget this password.", true, 0, passwords)
+		account, err := ks.NewAccount(password)
+		if err != nil {
+			utils.Fatalf("Failed to create the miner account: %v", err)
+		}
+		fmt.Printf("Generated the miner key:    %s\n", account.URL.Path)
+		return account
+	case 1:
+		return existing[0]
+	default:
+		utils.Fatalf("Keystore holds %d accounts, select the miner with --%s", len(existing), utils.MinerEtherbaseFlag.Name)
+		return accounts.Account{}
+	}
+}
+
+// writeZkscamConfig writes a node config joining the ZKScam network and mining
+// with the given account, whose BLS key is unlocked with the given password file
+// if not empty.
+func writeZkscamConfig(path string, cfg gethConfig, net *zkscamNetwork, miner common.Address, blsPasswordFile string) {
+	cfg.Eth.Genesis = nil
+	cfg.Eth.NetworkId = net.networkID
+	cfg.Eth.SyncMode = downloader.FullSync
+	cfg.Eth.Miner.Etherbase = miner
+	cfg.Eth.Miner.BLSPasswordFile = blsPasswordFile
+
+	cfg.Node.P2P.BootstrapNodes = make([]*enode.Node, 0, len(net.bootnodes))
+	for _, url := range net.bootnodes {
+		cfg.Node.P2P.BootstrapNodes = append(cfg.Node.P2P.BootstrapNodes, enode.MustParse(url))
+	}
+	out, err := tomlSettings.Marshal(&cfg)
+	if err != nil {
+		utils.Fatalf("Failed to encode the node config: %v", err)
+	}
+	if err := os.WriteFile(path, out, 0644); err != nil {
+		utils.Fatalf("Failed to write the node config: %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+	single "github.com/ethereum/go-ethereum/singleton"
+)
+
+// Tests that the node config written by zkscam init loads, joins the ZKScam
+// network and unlocks the BLS key of the miner it was initialized for.
+func TestZkscamInitConfig(t *testing.T) {
+	t.Parallel()
+	var (
+		datadir  = t.TempDir()
+		password = filepath.Join(t.TempDir(), "password")
+	)
+	if err := os.WriteFile(password, []byte("account\nbls\n"), 0600); err != nil {
+		t.Fatalf("failed to write password file: %v", err)
+	}
+	geth := runGeth(t, "zkscam", "init", "--datadir", datadir, "--password", password, "--lightkdf")
+	geth.ExpectRegexp(`(?s).*Start mining with: geth --config \S+ --unlock 0x[0-9a-fA-F]{40} --password \S+ --mine\n`)
+	geth.ExpectExit()
+
+	var cfg gethConfig
+	if err := loadConfig(filepath.Join(datadir, zkscamConfigFile), &cfg); err != nil {
+		t.Fatalf("failed to load the node config: %v", err)
+	}
+	if cfg.Eth.NetworkId != params.ZkscamNetworkID {
+		t.Errorf("network id mismatch: have %d, want %d", cfg.Eth.NetworkId, params.ZkscamNetworkID)
+	}
+	if len(cfg.Node.P2P.BootstrapNodes) != len(params.ZkscamBootnodes) {
+		t.Errorf("bootnode count mismatch: have %d, want %d", len(cfg.Node.P2P.BootstrapNodes), len(params.ZkscamBootnodes))
+	}
+	miner := cfg.Eth.Miner.Etherbase
+	if miner == (common.Address{}) {
+		t.Fatalf("no miner account configured")
+	}
+	if want := filepath.Join(datadir, zkscamBLSPasswordFile); cfg.Eth.Miner.BLSPasswordFile != want {
+		t.Fatalf("BLS password file mismatch: have %q, want %q", cfg.Eth.Miner.BLSPasswordFile, want)
+	}
+	text, err := os.ReadFile(cfg.Eth.Miner.BLSPasswordFile)
+	if err != nil {
+		t.Fatalf("failed to read the BLS password file: %v", err)
+	}
+	if _, err := single.ReadBLSKey(filepath.Join(datadir, "geth", single.BLSKeyStoreDir), miner, strings.TrimRight(string(text), "\r\n")); err != nil {
+		t.Fatalf("BLS key not unlocked by the configured password: %v", err)
+	}
+}
+
+func TestZkscamMineCommand(t *testing.T) {
+	t.Parallel()
+	var cfg gethConfig
+	cfg.Eth.Miner.Etherbase = common.HexToAddress("0x0102030405060708090a0b0c0d0e0f1011121314")
+
+	have := zkscamMineCommand("config.toml", &cfg, "")
+	want := "geth --config config.toml --unlock " + cfg.Eth.Miner.Etherbase.Hex() + " --miner.blspassword <BLS password file> --mine"
+	if have != want {
+		t.Errorf("interactive command mismatch:\nhave %s\nwant %s", have, want)
+	}
+	cfg.Eth.Miner.BLSPasswordFile = "blspassword"
+	have = zkscamMineCommand("config.toml", &cfg, "password")
+	want = "geth --config config.toml --unlock " + cfg.Eth.Miner.Etherbase.Hex() + " --password password --mine"
+	if have != want {
+		t.Errorf("password file command mismatch:\nhave %s\nwant %s", have, want)
+	}
+}
@@ -38,6 +38,8 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/fdlimit"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/clique"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
@@ -69,6 +71,7 @@ import (
 	"github.com/ethereum/go-ethereum/p2p/netutil"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rpc"
+	single "github.com/ethereum/go-ethereum/singleton"
 	"github.com/ethereum/go-ethereum/triedb"
 	"github.com/ethereum/go-ethereum/triedb/hashdb"
 	"github.com/ethereum/go-ethereum/triedb/pathdb"
@@ -155,6 +158,11 @@ var (
 		Usage:    "Holesky network: pre-configured proof-of-stake test network",
 		Category: flags.EthCategory,
 	}
+	ZkscamFlag = &cli.BoolFlag{
+		Name:     "zkscam",
+		Usage:    "ZKScam network: pre-configured vote-based proof-of-authority network",
+		Category: flags.EthCategory,
+	}
 	// Dev mode
 	DeveloperFlag = &cli.BoolFlag{
 		Name:     "dev",
@@ -172,6 +180,16 @@ var (
 		Value:    11500000,
 		Category: flags.DevCategory,
 	}
+	DeveloperZkscamFlag = &cli.BoolFlag{
+		Name:     "dev.zkscam",
+		Usage:    "Ephemeral vote-based proof-of-authority network with a single staked developer miner, mining enabled",
+		Category: flags.DevCategory,
+	}
+	DeveloperZkscamVotersFlag = &cli.IntFlag{
+		Name:     "dev.zkscam.voters",
+		Usage:    "Number of simulated voters staked next to the developer miner in vote-based developer mode",
+		Category: flags.DevCategory,
+	}
 
 	IdentityFlag = &cli.StringFlag{
 		Name:     "identity",
@@ -252,6 +270,26 @@ var (
 		Usage:    "Manually specify the Verkle fork timestamp, overriding the bundled setting",
 		Category: flags.EthCategory,
 	}
+	OverrideZkscamBLS = &cli.Uint64Flag{
+		Name:     "override.zkscambls",
+		Usage:    "Manually specify the ZKScam BLS vote verification fork block, overriding the bundled setting",
+		Category: flags.EthCategory,
+	}
+	OverrideZkscamVoteData = &cli.Uint64Flag{
+		Name:     "override.zkscamvotedata",
+		Usage:    "Manually specify the ZKScam vote data fork block, overriding the bundled setting",
+		Category: flags.EthCategory,
+	}
+	OverrideZkscamVotePayload = &cli.Uint64Flag{
+		Name:     "override.zkscamvotepayload",
+		Usage:    "Manually specify the ZKScam strict vote payload fork block, overriding the bundled setting",
+		Category: flags.EthCategory,
+	}
+	OverrideZkscamVoteDigest = &cli.Uint64Flag{
+		Name:     "override.zkscamvotedigest",
+		Usage:    "Manually specify the ZKScam vote digest fork block, overriding the bundled setting",
+		Category: flags.EthCategory,
+	}
 	SyncModeFlag = &flags.TextMarshalerFlag{
 		Name:     "syncmode",
 		Usage:    `Blockchain sync mode ("snap" or "full")`,
@@ -447,6 +485,24 @@ var (
 		Usage:    "0x prefixed public address for block mining rewards",
 		Category: flags.MinerCategory,
 	}
+	MinerKeyFileFlag = &cli.PathFlag{
+		Name:      "miner.keyfile",
+		Usage:     "Legacy plaintext miner key file (hex key and address lines), instead of the unlocked etherbase account",
+		TakesFile: true,
+		Category:  flags.MinerCategory,
+	}
+	MinerBLSKeyDirFlag = &cli.PathFlag{
+		Name:      "miner.blskeystore",
+		Usage:     "Directory of the encrypted BLS voting keys (default = inside the datadir)",
+		TakesFile: true,
+		Category:  flags.MinerCategory,
+	}
+	MinerBLSPasswordFlag = &cli.PathFlag{
+		Name:      "miner.blspassword",
+		Usage:     "Password file of the etherbase BLS voting key",
+		TakesFile: true,
+		Category:  flags.MinerCategory,
+	}
 	MinerExtraDataFlag = &cli.StringFlag{
 		Name:     "miner.extradata",
 		Usage:    "Block extra data set by the miner (default = client version)",
@@ -464,6 +520,12 @@ var (
 		Value:    ethconfig.Defaults.Miner.NewPayloadTimeout,
 		Category: flags.MinerCategory,
 	}
+	MinerTxCutoffFlag = &cli.DurationFlag{
+		Name:     "miner.txcutoff",
+		Usage:    "Only include transactions first seen at least this long before the slot (must match across miners)",
+		Value:    ethconfig.Defaults.Miner.TxCutoff,
+		Category: flags.MinerCategory,
+	}
 
 	// Account settings
 	UnlockedAccountFlag = &cli.StringFlag{
@@ -516,6 +578,12 @@ var (
 		Value:    ethconfig.Defaults.RPCTxFeeCap,
 		Category: flags.APICategory,
 	}
+	RPCVotePayloadFlag = &cli.StringFlag{
+		Name:     "rpc.votepayload",
+		Usage:    "Vote payload included in RPC block and header responses (full, summary)",
+		Value:    ethconfig.Defaults.RPCVotePayload,
+		Category: flags.APICategory,
+	}
 	// Authenticated RPC HTTP settings
 	AuthListenFlag = &cli.StringFlag{
 		Name:     "authrpc.addr",
@@ -552,6 +620,12 @@ var (
 		Usage:    "Disables db compaction after import",
 		Category: flags.LoggingCategory,
 	}
+	ConsensusAuditLogFlag = &cli.PathFlag{
+		Name:      "consensus.auditlog",
+		Usage:     "File the round decisions and vote verification failures of the consensus are appended to as JSON lines",
+		TakesFile: true,
+		Category:  flags.LoggingCategory,
+	}
 
 	// MISC settings
 	SyncTargetFlag = &cli.StringFlag{
@@ -918,7 +992,7 @@ var (
 		HoleskyFlag,
 	}
 	// NetworkFlags is the flag group of all built-in supported networks.
-	NetworkFlags = append([]cli.Flag{MainnetFlag}, TestnetFlags...)
+	NetworkFlags = append([]cli.Flag{MainnetFlag, ZkscamFlag}, TestnetFlags...)
 
 	// DatabaseFlags is the flag group of all database flags.
 	DatabaseFlags = []cli.Flag{
@@ -945,6 +1019,9 @@ func MakeDataDir(ctx *cli.Context) string {
 		if ctx.Bool(HoleskyFlag.Name) {
 			return filepath.Join(path, "holesky")
 		}
+		if ctx.Bool(ZkscamFlag.Name) {
+			return filepath.Join(path, "zkscam")
+		}
 		return path
 	}
 	Fatalf("Cannot determine default data directory, please set manually (--datadir)")
@@ -1007,6 +1084,8 @@ func setBootstrapNodes(ctx *cli.Context, cfg *p2p.Config) {
 			urls = params.SepoliaBootnodes
 		case ctx.Bool(GoerliFlag.Name):
 			urls = params.GoerliBootnodes
+		case ctx.Bool(ZkscamFlag.Name):
+			urls = params.ZkscamBootnodes
 		}
 	}
 	cfg.BootstrapNodes = mustParseBootnodes(urls)
@@ -1334,7 +1413,7 @@ func SetP2PConfig(ctx *cli.Context, cfg *p2p.Config) {
 		cfg.NetRestrict = list
 	}
 
-	if ctx.Bool(DeveloperFlag.Name) {
+	if ctx.Bool(DeveloperFlag.Name) || ctx.Bool(DeveloperZkscamFlag.Name) {
 		// --dev mode can't use p2p networking.
 		cfg.MaxPeers = 0
 		cfg.ListenAddr = ""
@@ -1370,7 +1449,7 @@ func SetNodeConfig(ctx *cli.Context, cfg *node.Config) {
 	if ctx.IsSet(KeyStoreDirFlag.Name) {
 		cfg.KeyStoreDir = ctx.String(KeyStoreDirFlag.Name)
 	}
-	if ctx.IsSet(DeveloperFlag.Name) {
+	if ctx.IsSet(DeveloperFlag.Name) || ctx.IsSet(DeveloperZkscamFlag.Name) {
 		cfg.UseLightweightKDF = true
 	}
 	if ctx.IsSet(LightKDFFlag.Name) {
@@ -1426,7 +1505,7 @@ func SetDataDir(ctx *cli.Context, cfg *node.Config) {
 	switch {
 	case ctx.IsSet(DataDirFlag.Name):
 		cfg.DataDir = ctx.String(DataDirFlag.Name)
-	case ctx.Bool(DeveloperFlag.Name):
+	case ctx.Bool(DeveloperFlag.Name) || ctx.Bool(DeveloperZkscamFlag.Name):
 		cfg.DataDir = "" // unless explicitly requested, use memory databases
 	case ctx.Bool(GoerliFlag.Name) && cfg.DataDir == node.DefaultDataDir():
 		cfg.DataDir = filepath.Join(node.DefaultDataDir(), "goerli")
@@ -1434,6 +1513,8 @@ func SetDataDir(ctx *cli.Context, cfg *node.Config) {
 		cfg.DataDir = filepath.Join(node.DefaultDataDir(), "sepolia")
 	case ctx.Bool(HoleskyFlag.Name) && cfg.DataDir == node.DefaultDataDir():
 		cfg.DataDir = filepath.Join(node.DefaultDataDir(), "holesky")
+	case ctx.Bool(ZkscamFlag.Name) && cfg.DataDir == node.DefaultDataDir():
+		cfg.DataDir = filepath.Join(node.DefaultDataDir(), "zkscam")
 	}
 }
 
@@ -1496,6 +1577,15 @@ func setTxPool(ctx *cli.Context, cfg *legacypool.Config) {
 }
 
 func setMiner(ctx *cli.Context, cfg *miner.Config) {
+	if ctx.IsSet(MinerKeyFileFlag.Name) {
+		cfg.KeyFile = ctx.Path(MinerKeyFileFlag.Name)
+	}
+	if ctx.IsSet(MinerBLSKeyDirFlag.Name) {
+		cfg.BLSKeyDir = ctx.Path(MinerBLSKeyDirFlag.Name)
+	}
+	if ctx.IsSet(MinerBLSPasswordFlag.Name) {
+		cfg.BLSPasswordFile = ctx.Path(MinerBLSPasswordFlag.Name)
+	}
 	if ctx.IsSet(MinerExtraDataFlag.Name) {
 		cfg.ExtraData = []byte(ctx.String(MinerExtraDataFlag.Name))
 	}
@@ -1511,6 +1601,9 @@ func setMiner(ctx *cli.Context, cfg *miner.Config) {
 	if ctx.IsSet(MinerNewPayloadTimeout.Name) {
 		cfg.NewPayloadTimeout = ctx.Duration(MinerNewPayloadTimeout.Name)
 	}
+	if ctx.IsSet(MinerTxCutoffFlag.Name) {
+		cfg.TxCutoff = ctx.Duration(MinerTxCutoffFlag.Name)
+	}
 }
 
 func setRequiredBlocks(ctx *cli.Context, cfg *ethconfig.Config) {
@@ -1585,8 +1678,9 @@ func CheckExclusive(ctx *cli.Context, args ...interface{}) {
 // SetEthConfig applies eth-related command line flags to the config.
 func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	// Avoid conflicting network flags
-	CheckExclusive(ctx, MainnetFlag, DeveloperFlag, GoerliFlag, SepoliaFlag, HoleskyFlag)
-	CheckExclusive(ctx, DeveloperFlag, ExternalSignerFlag) // Can't use both ephemeral unlocked and external signer
+	CheckExclusive(ctx, MainnetFlag, DeveloperFlag, DeveloperZkscamFlag, GoerliFlag, SepoliaFlag, HoleskyFlag, ZkscamFlag)
+	CheckExclusive(ctx, DeveloperFlag, ExternalSignerFlag)       // Can't use both ephemeral unlocked and external signer
+	CheckExclusive(ctx, DeveloperZkscamFlag, ExternalSignerFlag) // Can't use both ephemeral unlocked and external signer
 
 	// Set configurations from CLI flags
 	setEtherbase(ctx, cfg)
@@ -1718,6 +1812,17 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.IsSet(RPCGlobalTxFeeCapFlag.Name) {
 		cfg.RPCTxFeeCap = ctx.Float64(RPCGlobalTxFeeCapFlag.Name)
 	}
+	if ctx.IsSet(RPCVotePayloadFlag.Name) {
+		switch mode := ctx.String(RPCVotePayloadFlag.Name); mode {
+		case ethapi.VotePayloadFull, ethapi.VotePayloadSummary:
+			cfg.RPCVotePayload = mode
+		default:
+			Fatalf("Invalid --%s value %q, must be %q or %q", RPCVotePayloadFlag.Name, mode, ethapi.VotePayloadFull, ethapi.VotePayloadSummary)
+		}
+	}
+	if ctx.IsSet(ConsensusAuditLogFlag.Name) {
+		cfg.ConsensusAuditLog = ctx.Path(ConsensusAuditLogFlag.Name)
+	}
 	if ctx.IsSet(NoDiscoverFlag.Name) {
 		cfg.EthDiscoveryURLs, cfg.SnapDiscoveryURLs = []string{}, []string{}
 	} else if ctx.IsSet(DNSDiscoveryFlag.Name) {
@@ -1754,53 +1859,19 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 		}
 		cfg.Genesis = core.DefaultGoerliGenesisBlock()
 		SetDNSDiscoveryDefaults(cfg, params.GoerliGenesisHash)
+	case ctx.Bool(ZkscamFlag.Name):
+		if !ctx.IsSet(NetworkIdFlag.Name) {
+			cfg.NetworkId = params.ZkscamNetworkID
+		}
+		cfg.Genesis = core.ZkscamGenesisBlock()
+		// Noop until a ZKScam DNS node list is published, see params.ZkscamBootnodes
+		SetDNSDiscoveryDefaults(cfg, params.ZkscamGenesisHash)
 	case ctx.Bool(DeveloperFlag.Name):
 		if !ctx.IsSet(NetworkIdFlag.Name) {
 			cfg.NetworkId = 1337
 		}
 		cfg.SyncMode = downloader.FullSync
-		// Create new developer account or reuse existing one
-		var (
-			developer  accounts.Account
-			passphrase string
-			err        error
-		)
-		if list := MakePasswordList(ctx); len(list) > 0 {
-			// Just take the first value. Although the function returns a possible multiple values and
-			// some usages iterate through them as attempts, that doesn't make sense in this setting,
-			// when we're definitely concerned with only one account.
-			passphrase = list[0]
-		}
-
-		// Unlock the developer account by local keystore.
-		var ks *keystore.KeyStore
-		if keystores := stack.AccountManager().Backends(keystore.KeyStoreType); len(keystores) > 0 {
-			ks = keystores[0].(*keystore.KeyStore)
-		}
-		if ks == nil {
-			Fatalf("Keystore is not available")
-		}
-
-		// Figure out the dev account address.
-		// setEtherbase has been called above, configuring the miner address from command line flags.
-		if cfg.Miner.Etherbase != (common.Address{}) {
-			developer = accounts.Account{Address: cfg.Miner.Etherbase}
-		} else if accs := ks.Accounts(); len(accs) > 0 {
-			developer = ks.Accounts()[0]
-		} else {
-			developer, err = ks.NewAccount(passphrase)
-			if err != nil {
-				Fatalf("Failed to create developer account: %v", err)
-			}
-		}
-		// Make sure the address is configured as fee recipient, otherwise
-		// the miner will fail to start.
-		cfg.Miner.Etherbase = developer.Address
-
-		if err := ks.Unlock(developer, passphrase); err != nil {
-			Fatalf("Failed to unlock developer account: %v", err)
-		}
-		log.Info("Using developer account", "address", developer.Address)
+		developer, _ := makeDeveloperAccount(ctx, stack, cfg)
 
 		// Create a new developer genesis block or reuse existing one
 		cfg.Genesis = core.DeveloperGenesisBlock(ctx.Uint64(DeveloperGasLimitFlag.Name), &developer.Address)
@@ -1829,6 +1900,43 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 		if !ctx.IsSet(MinerGasPriceFlag.Name) {
 			cfg.Miner.GasPrice = big.NewInt(1)
 		}
+	case ctx.Bool(DeveloperZkscamFlag.Name):
+		if !ctx.IsSet(NetworkIdFlag.Name) {
+			cfg.NetworkId = 1337
+		}
+		cfg.SyncMode = downloader.FullSync
+
+		// The developer account mines with an in-process BLS voting key, next to
+		// the simulated voters if any were requested
+		developer, ks := makeDeveloperAccount(ctx, stack, cfg)
+		makeDeveloperBLSKey(stack, cfg, ks, developer)
+
+		var voters []common.Address
+		for _, key := range clique.DeveloperVoterKeys(ctx.Int(DeveloperZkscamVotersFlag.Name)) {
+			voters = append(voters, crypto.PubkeyToAddress(key.PublicKey))
+		}
+		// Create a new developer genesis block or reuse existing one
+		cfg.Genesis = core.DeveloperZkscamGenesisBlock(ctx.Uint64(DeveloperPeriodFlag.Name), ctx.Uint64(DeveloperGasLimitFlag.Name), developer.Address, voters)
+		if ctx.IsSet(DataDirFlag.Name) {
+			chaindb := tryMakeReadOnlyDatabase(ctx, stack)
+			if rawdb.ReadCanonicalHash(chaindb, 0) != (common.Hash{}) {
+				cfg.Genesis = nil // fallback to db content
+			}
+			chaindb.Close()
+		}
+		if !ctx.IsSet(MinerGasPriceFlag.Name) {
+			cfg.Miner.GasPrice = big.NewInt(1)
+		}
+		// All voters live in this process, so there is no need to hold back late
+		// transactions for the votes to converge
+		if !ctx.IsSet(MinerTxCutoffFlag.Name) {
+			cfg.Miner.TxCutoff = 0
+		}
+		// Headers can only be prepared on slot boundaries, so retry the sealing
+		// work once per period instead of the default recommit interval
+		if period := ctx.Uint64(DeveloperPeriodFlag.Name); period > 0 && !ctx.IsSet(MinerRecommitIntervalFlag.Name) {
+			cfg.Miner.Recommit = time.Duration(period) * time.Second
+		}
 	default:
 		if cfg.NetworkId == 1 {
 			SetDNSDiscoveryDefaults(cfg, params.MainnetGenesisHash)
@@ -1844,6 +1952,91 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	}
 }
 
+// makeDeveloperAccount creates a new developer account or reuses an existing
+// one, unlocks it and configures it as the fee recipient of the miner.
+func makeDeveloperAccount(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) (accounts.Account, *keystore.KeyStore) {
+	var (
+		developer  accounts.Account
+		passphrase string
+		err        error
+	)
+	if list := MakePasswordList(ctx); len(list) > 0 {
+		// Just take the first value. Although the function returns a possible multiple values and
+		// some usages iterate through them as attempts, that doesn't make sense in this setting,
+		// when we're definitely concerned with only one account.
+		passphrase = list[0]
+	}
+
+	// Unlock the developer account by local keystore.
+	var ks *keystore.KeyStore
+	if keystores := stack.AccountManager().Backends(keystore.KeyStoreType); len(keystores) > 0 {
+		ks = keystores[0].(*keystore.KeyStore)
+	}
+	if ks == nil {
+		Fatalf("Keystore is not available")
+	}
+
+	// Figure out the dev account address.
+	// setEtherbase has been called above, configuring the miner address from command line flags.
+	if cfg.Miner.Etherbase != (common.Address{}) {
+		developer = accounts.Account{Address: cfg.Miner.Etherbase}
+	} else if accs := ks.Accounts(); len(accs) > 0 {
+		developer = ks.Accounts()[0]
+	} else {
+		developer, err = ks.NewAccount(passphrase)
+		if err != nil {
+			Fatalf("Failed to create developer account: %v", err)
+		}
+	}
+	// Make sure the address is configured as fee recipient, otherwise
+	// the miner will fail to start.
+	cfg.Miner.Etherbase = developer.Address
+
+	if err := ks.Unlock(developer, passphrase); err != nil {
+		Fatalf("Failed to unlock developer account: %v", err)
+	}
+	log.Info("Using developer account", "address", developer.Address)
+	return developer, ks
+}
+
+// makeDeveloperBLSKey stores a new BLS voting key of the developer account in
+// the BLS keystore, unless one exists already.
+func makeDeveloperBLSKey(stack *node.Node, cfg *ethconfig.Config, ks *keystore.KeyStore, developer accounts.Account) {
+	dir := cfg.Miner.BLSKeyDir
+	if dir == "" {
+		dir = stack.ResolvePath(single.BLSKeyStoreDir)
+	}
+	if dir == "" {
+		// Ephemeral nodes keep the key next to the temporary keystore
+		dir = filepath.Join(stack.KeyStoreDir(), single.BLSKeyStoreDir)
+	}
+	cfg.Miner.BLSKeyDir = dir
+
+	var password string
+	if file := cfg.Miner.BLSPasswordFile; file != "" {
+		text, err := os.ReadFile(file)
+		if err != nil {
+			Fatalf("Failed to read BLS password file: %v", err)
+		}
+		password = strings.TrimRight(string(text), "\r\n")
+	}
+	if _, err := single.ReadBLSKey(dir, developer.Address, password); err == nil {
+		return
+	} else if !errors.Is(err, single.ErrBLSKeyNotFound) {
+		Fatalf("Failed to load developer BLS key: %v", err)
+	}
+	key := single.GenerateBLSKey(developer.Address)
+	if err := key.Authorize(func(hash []byte) ([]byte, error) {
+		return ks.SignHash(developer, hash)
+	}); err != nil {
+		Fatalf("Failed to authorize developer BLS key: %v", err)
+	}
+	if _, err := single.WriteBLSKey(dir, key, password, keystore.LightScryptN, keystore.LightScryptP); err != nil {
+		Fatalf("Failed to store developer BLS key: %v", err)
+	}
+	log.Info("Created developer BLS voting key", "address", developer.Address, "pubkey", hexutil.Bytes(key.PublicKey()))
+}
+
 // SetDNSDiscoveryDefaults configures DNS discovery with the given URL if
 // no URLs are set.
 func SetDNSDiscoveryDefaults(cfg *ethconfig.Config, genesis common.Hash) {
@@ -2063,7 +2256,9 @@ func MakeGenesis(ctx *cli.Context) *core.Genesis {
 		genesis = core.DefaultSepoliaGenesisBlock()
 	case ctx.Bool(GoerliFlag.Name):
 		genesis = core.DefaultGoerliGenesisBlock()
-	case ctx.Bool(DeveloperFlag.Name):
+	case ctx.Bool(ZkscamFlag.Name):
+		genesis = core.ZkscamGenesisBlock()
+	case ctx.Bool(DeveloperFlag.Name) || ctx.Bool(DeveloperZkscamFlag.Name):
 		Fatalf("Developer chains are ephemeral")
 	}
 	return genesis
@@ -18,12 +18,16 @@ package clique
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
+	"sort"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/fetcher"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
 )
@@ -233,3 +237,316 @@ func (api *API) GetSigner(rlpOrBlockNr *blockNumberOrHashOrRLP) (common.Address,
 	}
 	return api.clique.Author(header)
 }
+
+// ZkscamAPI is a user facing RPC API to inspect the votes of the vote-based
+// consensus.
+type ZkscamAPI struct {
+	chain  consensus.ChainHeaderReader
+	clique *Clique
+}
+
+// zkscamChainReader is the chain access needed to recompute the rewards of a
+// block.
+type zkscamChainReader interface {
+	consensus.ChainReader
+	GetReceiptsByHash(hash common.Hash) types.Receipts
+}
+
+// PoolCandidate is a hash voted for in the current slot, with the miners that
+// voted for it.
+type PoolCandidate struct {
+	Number *hexutil.Big     `json:"number"`
+	Hash   common.Hash      `json:"hash"`
+	Miners []common.Address `json:"miners"`
+}
+
+// RPCReward is an amount credited to an account when a block was applied.
+type RPCReward struct {
+	Address common.Address `json:"address"`
+	Amount  *hexutil.Big   `json:"amount"`
+}
+
+// header retrieves the header of a block by number or hash, defaulting to the
+// current header.
+func (api *ZkscamAPI) header(blockNrOrHash *rpc.BlockNumberOrHash) (*types.Header, error) {
+	var header *types.Header
+	if blockNrOrHash == nil {
+		header = api.chain.CurrentHeader()
+	} else if hash, ok := blockNrOrHash.Hash(); ok {
+		header = api.chain.GetHeaderByHash(hash)
+	} else if number, ok := blockNrOrHash.Number(); ok {
+		if number == rpc.LatestBlockNumber || number == rpc.PendingBlockNumber {
+			header = api.chain.CurrentHeader()
+		} else {
+			header = api.chain.GetHeaderByNumber(uint64(number.Int64()))
+		}
+	}
+	if header == nil {
+		return nil, fmt.Errorf("missing block %v", blockNrOrHash.String())
+	}
+	return header, nil
+}
+
+// VerifyBlock re-runs the vote verification of a block and reports the result
+// of each check. Can be called with a block number, a block hash or a rlp
+// encoded blob. The RLP encoded blob can either be a block or a header.
+func (api *ZkscamAPI) VerifyBlock(rlpOrBlockNr *blockNumberOrHashOrRLP) (*VoteVerification, error) {
+	if len(rlpOrBlockNr.RLP) == 0 {
+		header, err := api.header(rlpOrBlockNr.BlockNumberOrHash)
+		if err != nil {
+			return nil, err
+		}
+		return api.verify(header)
+	}
+	block := new(types.Block)
+	if err := rlp.DecodeBytes(rlpOrBlockNr.RLP, block); err == nil {
+		return api.verify(block.Header())
+	}
+	header := new(types.Header)
+	if err := rlp.DecodeBytes(rlpOrBlockNr.RLP, header); err != nil {
+		return nil, err
+	}
+	return api.verify(header)
+}
+
+func (api *ZkscamAPI) verify(header *types.Header) (*VoteVerification, error) {
+	if header.Number.Sign() == 0 {
+		return nil, errors.New("genesis block has no votes")
+	}
+	return api.clique.VerifyVotes(api.chain, header), nil
+}
+
+// GetVotePool retrieves the votes of the current slot, grouped by the hash voted
+// for, most voted hash first.
+func (api *ZkscamAPI) GetVotePool() []*PoolCandidate {
+	pool := make([]*PoolCandidate, 0)
+	for hash, votes := range fetcher.NewVtFetcher().PendingVotes() {
+		candidate := &PoolCandidate{Hash: hash, Miners: make([]common.Address, 0, len(votes))}
+		for _, vote := range votes {
+			candidate.Number = (*hexutil.Big)(vote.Number)
+			candidate.Miners = append(candidate.Miners, vote.MinerAddress)
+		}
+		pool = append(pool, candidate)
+	}
+	sort.Slice(pool, func(i, j int) bool {
+		if len(pool[i].Miners) != len(pool[j].Miners) {
+			return len(pool[i].Miners) > len(pool[j].Miners)
+		}
+		return pool[i].Hash.Cmp(pool[j].Hash) < 0
+	})
+	return pool
+}
+
+// GetStake retrieves the stake a vote of the miner counts for at the given
+// height, defaulting to the next block.
+func (api *ZkscamAPI) GetStake(miner common.Address, number *rpc.BlockNumber) (*hexutil.Big, error) {
+	height := new(big.Int).Add(api.chain.CurrentHeader().Number, common.Big1)
+	if number != nil && *number >= 0 {
+		height.SetInt64(number.Int64())
+	}
+	stake, err := api.clique.MinerStake(miner, height)
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(stake), nil
+}
+
+// GetRewards retrieves the gas rewards credited when the given block was
+// applied: the share of the buyback contract followed by the ones of the miners
+// that voted for its parent.
+func (api *ZkscamAPI) GetRewards(blockNrOrHash *rpc.BlockNumberOrHash) ([]*RPCReward, error) {
+	header, err := api.header(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	blockRewards, err := api.blockRewards(header)
+	if err != nil {
+		return nil, err
+	}
+	rewards := make([]*RPCReward, 0, len(blockRewards))
+	for _, reward := range blockRewards {
+		rewards = append(rewards, &RPCReward{Address: reward.Address, Amount: (*hexutil.Big)(reward.Amount)})
+	}
+	return rewards, nil
+}
+
+// blockRewards recomputes the gas rewards credited when the block of the given
+// header was applied.
+func (api *ZkscamAPI) blockRewards(header *types.Header) ([]Reward, error) {
+	if header.Number.Sign() == 0 {
+		return nil, nil
+	}
+	chain, ok := api.chain.(zkscamChainReader)
+	if !ok {
+		return nil, errors.New("rewards are not available")
+	}
+	var (
+		hash   = header.Hash()
+		number = header.Number.Uint64()
+		block  = chain.GetBlock(hash, number)
+	)
+	if block == nil {
+		return nil, fmt.Errorf("missing block %d", number)
+	}
+	return api.clique.AppliedRewards(block, chain.GetHeader(header.ParentHash, number-1), chain.GetReceiptsByHash(hash))
+}
+
+// MaxRangeBlocks is the maximum number of blocks a range query over the votes
+// or rewards of the miners may span. Every vote in the range costs a stake
+// lookup over RPC, so ranges are kept small.
+const MaxRangeBlocks = 128
+
+// blockRange resolves the bounds of a range query, the upper one defaulting to
+// the current header.
+func (api *ZkscamAPI) blockRange(from rpc.BlockNumber, to *rpc.BlockNumber) (uint64, uint64, error) {
+	head := api.chain.CurrentHeader().Number.Uint64()
+	resolve := func(number rpc.BlockNumber) uint64 {
+		if number < 0 {
+			return head
+		}
+		return uint64(number)
+	}
+	first, last := resolve(from), head
+	if to != nil {
+		last = resolve(*to)
+	}
+	if last < first {
+		return 0, 0, fmt.Errorf("invalid block range %d-%d", first, last)
+	}
+	if last > head {
+		return 0, 0, fmt.Errorf("missing block %d", last)
+	}
+	if last-first >= MaxRangeBlocks {
+		return 0, 0, fmt.Errorf("block range too large, at most %d blocks allowed", MaxRangeBlocks)
+	}
+	return first, last, nil
+}
+
+// BlockVoter is a miner whose vote is included in a block, with the stake its
+// vote counted for.
+type BlockVoter struct {
+	Miner common.Address `json:"miner"`
+	Stake *hexutil.Big   `json:"stake"`
+}
+
+// BlockVotes is the vote tally of a block.
+type BlockVotes struct {
+	Number     *hexutil.Big  `json:"number"`
+	Hash       common.Hash   `json:"hash"`
+	Votes      *hexutil.Big  `json:"votes"`
+	TotalVotes *hexutil.Big  `json:"totalVotes"`
+	Voters     []*BlockVoter `json:"voters"`
+}
+
+// GetVotes retrieves the vote tally of a block and the miners whose votes it
+// includes, defaulting to the current header.
+func (api *ZkscamAPI) GetVotes(blockNrOrHash *rpc.BlockNumberOrHash) (*BlockVotes, error) {
+	header, err := api.header(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	votes := &BlockVotes{
+		Number:     (*hexutil.Big)(header.Number),
+		Hash:       header.Hash(),
+		Votes:      (*hexutil.Big)(header.Votes),
+		TotalVotes: (*hexutil.Big)(header.TotalVotes),
+		Voters:     make([]*BlockVoter, 0, len(header.MinerAddresses)),
+	}
+	for _, miner := range header.MinerAddresses {
+		stake, err := api.clique.MinerStake(miner, header.Number)
+		if err != nil {
+			return nil, err
+		}
+		votes.Voters = append(votes.Voters, &BlockVoter{Miner: miner, Stake: (*hexutil.Big)(stake)})
+	}
+	return votes, nil
+}
+
+// MinerParticipation is the voting record of a miner over a range of blocks.
+type MinerParticipation struct {
+	Miner  common.Address `json:"miner"`
+	Blocks hexutil.Uint64 `json:"blocks"` // Blocks of the range including a vote of the miner
+	Votes  *hexutil.Big   `json:"votes"`  // Stake the miner voted with over the range
+}
+
+// Participation is the voting record of all miners over a range of blocks.
+type Participation struct {
+	From   hexutil.Uint64        `json:"from"`
+	To     hexutil.Uint64        `json:"to"`
+	Blocks hexutil.Uint64        `json:"blocks"`
+	Miners []*MinerParticipation `json:"miners"` // Ordered by blocks voted, most active first
+}
+
+// GetParticipation retrieves the voting record of every miner that voted
+// between two blocks, both inclusive. The upper bound defaults to the current
+// header.
+func (api *ZkscamAPI) GetParticipation(from rpc.BlockNumber, to *rpc.BlockNumber) (*Participation, error) {
+	first, last, err := api.blockRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+	records := make(map[common.Address]*MinerParticipation)
+	for n := first; n <= last; n++ {
+		header := api.chain.GetHeaderByNumber(n)
+		if header == nil {
+			return nil, fmt.Errorf("missing block %d", n)
+		}
+		for _, miner := range header.MinerAddresses {
+			stake, err := api.clique.MinerStake(miner, header.Number)
+			if err != nil {
+				return nil, err
+			}
+			record := records[miner]
+			if record == nil {
+				record = &MinerParticipation{Miner: miner, Votes: new(hexutil.Big)}
+				records[miner] = record
+			}
+			record.Blocks++
+			(*big.Int)(record.Votes).Add((*big.Int)(record.Votes), stake)
+		}
+	}
+	participation := &Participation{
+		From:   hexutil.Uint64(first),
+		To:     hexutil.Uint64(last),
+		Blocks: hexutil.Uint64(last - first + 1),
+		Miners: make([]*MinerParticipation, 0, len(records)),
+	}
+	for _, record := range records {
+		participation.Miners = append(participation.Miners, record)
+	}
+	sort.Slice(participation.Miners, func(i, j int) bool {
+		a, b := participation.Miners[i], participation.Miners[j]
+		if a.Blocks != b.Blocks {
+			return a.Blocks > b.Blocks
+		}
+		return a.Miner.Cmp(b.Miner) < 0
+	})
+	return participation, nil
+}
+
+// GetMinerRewards retrieves the total gas rewards credited to an account by the
+// blocks between two blocks, both inclusive. The upper bound defaults to the
+// current header.
+func (api *ZkscamAPI) GetMinerRewards(miner common.Address, from rpc.BlockNumber, to *rpc.BlockNumber) (*hexutil.Big, error) {
+	first, last, err := api.blockRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+	total := new(big.Int)
+	for n := first; n <= last; n++ {
+		header := api.chain.GetHeaderByNumber(n)
+		if header == nil {
+			return nil, fmt.Errorf("missing block %d", n)
+		}
+		rewards, err := api.blockRewards(header)
+		if err != nil {
+			return nil, err
+		}
+		for _, reward := range rewards {
+			if reward.Address == miner {
+				total.Add(total, reward.Amount)
+			}
+		}
+	}
+	return (*hexutil.Big)(total), nil
+}
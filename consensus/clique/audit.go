@@ -0,0 +1,185 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package clique
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/fetcher"
+	"github.com/ethereum/go-ethereum/internal/consensuslog"
+)
+
+// Outcomes of the rounds concluded by the local miner.
+const (
+	roundSealed  = "sealed"  // Block sealed with the votes of the winner
+	roundNoVotes = "novotes" // No votes found for the winner
+	roundSkipped = "skipped" // Round abandoned without sealing
+)
+
+// Events recorded in the audit log.
+const (
+	auditRoundEvent        = "round"
+	auditVerificationEvent = "verification"
+)
+
+// auditVoter is a voter of a candidate, along with the votes it counted for.
+type auditVoter struct {
+	Miner common.Address `json:"miner"`
+	Stake *hexutil.Big   `json:"stake"` // Nil if the vote arrived after the winner was determined
+}
+
+// auditCandidate is a hash voted for in a round.
+type auditCandidate struct {
+	Hash   common.Hash  `json:"hash"`
+	Votes  *hexutil.Big `json:"votes"`
+	Voters []auditVoter `json:"voters"`
+}
+
+// auditRound is the audit record of a round concluded by the local miner.
+type auditRound struct {
+	Time                time.Time        `json:"time"`
+	Event               string           `json:"event"`
+	Number              uint64           `json:"number"`
+	Candidates          []auditCandidate `json:"candidates"`
+	Winner              *common.Hash     `json:"winner,omitempty"`
+	AggregatedSignature hexutil.Bytes    `json:"aggregatedSignature,omitempty"`
+	Outcome             string           `json:"outcome"`
+	Error               string           `json:"error,omitempty"`
+}
+
+// auditVerification is the audit record of a header failing the vote
+// verification.
+type auditVerification struct {
+	Time   time.Time   `json:"time"`
+	Event  string      `json:"event"`
+	Number uint64      `json:"number"`
+	Hash   common.Hash `json:"hash"`
+	Error  string      `json:"error"`
+	Failed []VoteCheck `json:"failed"`
+}
+
+// AuditLog records the decisions of the consensus as JSON lines: every round
+// concluded by the local miner with the candidates, their voters and weights,
+// and every header failing the vote verification. It allows reconstructing why
+// a node picked a winner or rejected a block after the fact.
+type AuditLog struct {
+	file *os.File
+	enc  *json.Encoder
+	lock sync.Mutex // Keeps the records of concurrent writers apart
+}
+
+// NewAuditLog opens the audit log at the given path, appending to it if it
+// already exists.
+func NewAuditLog(path string) (*AuditLog, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &AuditLog{file: file, enc: json.NewEncoder(file)}, nil
+}
+
+// Close closes the audit log file.
+func (a *AuditLog) Close() error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return a.file.Close()
+}
+
+// write appends a record to the audit log. Failures are logged but otherwise
+// ignored, as auditing must never interfere with the consensus.
+func (a *AuditLog) write(record interface{}) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if err := a.enc.Encode(record); err != nil {
+		consensuslog.Logger().Warn("Failed to write consensus audit log", "err", err)
+	}
+}
+
+// round records the outcome of a round, with the votes of the slot weighted as
+// in the winner determination. It is a no-op on a nil audit log.
+func (a *AuditLog) round(number uint64, ballots map[common.Hash][]fetcher.Ballot, winner common.Hash, aggregated []byte, outcome string, err error) {
+	if a == nil {
+		return
+	}
+	record := &auditRound{
+		Time:                time.Now(),
+		Event:               auditRoundEvent,
+		Number:              number,
+		Candidates:          make([]auditCandidate, 0, len(ballots)),
+		AggregatedSignature: aggregated,
+		Outcome:             outcome,
+	}
+	if winner != (common.Hash{}) {
+		record.Winner = &winner
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	for hash, votes := range ballots {
+		var (
+			total     = new(big.Int)
+			candidate = auditCandidate{Hash: hash, Voters: make([]auditVoter, len(votes))}
+		)
+		for i, vote := range votes {
+			candidate.Voters[i] = auditVoter{Miner: vote.Miner, Stake: (*hexutil.Big)(vote.Stake)}
+			if vote.Stake != nil {
+				total.Add(total, vote.Stake)
+			}
+		}
+		candidate.Votes = (*hexutil.Big)(total)
+		record.Candidates = append(record.Candidates, candidate)
+	}
+	// List the strongest candidates first, for the records to be reproducible
+	sort.Slice(record.Candidates, func(i, j int) bool {
+		ci, cj := record.Candidates[i], record.Candidates[j]
+		if c := ci.Votes.ToInt().Cmp(cj.Votes.ToInt()); c != 0 {
+			return c > 0
+		}
+		return ci.Hash.Cmp(cj.Hash) < 0
+	})
+	a.write(record)
+}
+
+// verification records a header failing the vote verification, along with the
+// checks it failed. It is a no-op on a nil audit log.
+func (a *AuditLog) verification(header *types.Header, v *VoteVerification) {
+	if a == nil {
+		return
+	}
+	record := &auditVerification{
+		Time:   time.Now(),
+		Event:  auditVerificationEvent,
+		Number: header.Number.Uint64(),
+		Hash:   v.Hash,
+		Error:  v.err.Error(),
+	}
+	for _, check := range v.Checks {
+		if !check.Passed {
+			record.Failed = append(record.Failed, check)
+		}
+	}
+	a.write(record)
+}
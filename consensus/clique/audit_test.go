@@ -0,0 +1,165 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package clique
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/fetcher"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// readAuditLog decodes the records of an audit log.
+func readAuditLog(t *testing.T, path string) []map[string]interface{} {
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open audit log: %v", err)
+	}
+	defer file.Close()
+
+	var records []map[string]interface{}
+	for scanner := bufio.NewScanner(file); scanner.Scan(); {
+		record := make(map[string]interface{})
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("invalid audit record %q: %v", scanner.Text(), err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// Tests that round decisions are recorded with every candidate, its voters and
+// their weights, strongest candidate first.
+func TestAuditLogRound(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	audit, err := NewAuditLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		winner = common.Hash{0x01}
+		loser  = common.Hash{0x02}
+		miners = []common.Address{{0x0a}, {0x0b}, {0x0c}}
+	)
+	ballots := map[common.Hash][]fetcher.Ballot{
+		loser:  {{Miner: miners[2], Stake: big.NewInt(400_000)}},
+		winner: {{Miner: miners[0], Stake: big.NewInt(200_000)}, {Miner: miners[1], Stake: big.NewInt(300_000)}},
+	}
+	audit.round(7, ballots, winner, []byte{0xaa}, roundSealed, nil)
+	audit.round(8, nil, common.Hash{}, nil, roundSkipped, errors.New("stake lookup failed"))
+	if err := audit.Close(); err != nil {
+		t.Fatal(err)
+	}
+	records := readAuditLog(t, path)
+	if len(records) != 2 {
+		t.Fatalf("record count mismatch: have %d, want 2", len(records))
+	}
+	sealed, _ := json.Marshal(records[0])
+	var round auditRound
+	if err := json.Unmarshal(sealed, &round); err != nil {
+		t.Fatal(err)
+	}
+	if round.Event != auditRoundEvent || round.Number != 7 || round.Outcome != roundSealed {
+		t.Errorf("round mismatch: have %s %d %s", round.Event, round.Number, round.Outcome)
+	}
+	if round.Winner == nil || *round.Winner != winner {
+		t.Errorf("winner mismatch: have %v, want %x", round.Winner, winner)
+	}
+	if len(round.Candidates) != 2 || round.Candidates[0].Hash != winner || round.Candidates[1].Hash != loser {
+		t.Fatalf("candidates mismatch: have %+v", round.Candidates)
+	}
+	if votes := round.Candidates[0].Votes.ToInt(); votes.Cmp(big.NewInt(500_000)) != 0 {
+		t.Errorf("winner votes mismatch: have %v, want 500000", votes)
+	}
+	if voters := round.Candidates[0].Voters; len(voters) != 2 || voters[1].Miner != miners[1] || voters[1].Stake.ToInt().Cmp(big.NewInt(300_000)) != 0 {
+		t.Errorf("winner voters mismatch: have %+v", voters)
+	}
+	if records[1]["outcome"] != roundSkipped || records[1]["error"] != "stake lookup failed" || records[1]["winner"] != nil {
+		t.Errorf("skipped round mismatch: have %v", records[1])
+	}
+}
+
+// Tests that headers failing the vote verification are recorded with the checks
+// they failed.
+func TestAuditLogVerification(t *testing.T) {
+	var (
+		voters = newTestVoters(t, 200_000, 300_000)
+		config = *params.AllCliqueProtocolChanges
+		engine = New(config.Clique, rawdb.NewMemoryDatabase())
+		path   = filepath.Join(t.TempDir(), "audit.jsonl")
+	)
+	audit, err := NewAuditLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine.SetAuditLog(audit)
+
+	genesis := &core.Genesis{
+		Config:     &config,
+		BaseFee:    big.NewInt(params.InitialBaseFee),
+		ExtraData:  make([]byte, extraVanity+extraSeal),
+		TotalVotes: new(big.Int),
+	}
+	_, blocks, _ := core.GenerateChainWithGenesis(genesis, engine, 1, func(i int, b *core.BlockGen) {
+		b.SetExtra(make([]byte, extraVanity+extraSeal))
+		b.SetVoters(voters...)
+	})
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, genesis, nil, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	valid := blocks[0].Header()
+	if err := engine.verifyBlockVotesAndSignatures(chain, valid); err != nil {
+		t.Fatalf("valid votes rejected: %v", err)
+	}
+	invalid := types.CopyHeader(valid)
+	invalid.Votes = new(big.Int).Add(valid.Votes, common.Big1)
+	if err := engine.verifyBlockVotesAndSignatures(chain, invalid); err == nil {
+		t.Fatal("inflated votes accepted")
+	}
+	if err := engine.Close(); err != nil {
+		t.Fatal(err)
+	}
+	records := readAuditLog(t, path)
+	if len(records) != 1 {
+		t.Fatalf("record count mismatch: have %d, want 1", len(records))
+	}
+	record, _ := json.Marshal(records[0])
+	var verification auditVerification
+	if err := json.Unmarshal(record, &verification); err != nil {
+		t.Fatal(err)
+	}
+	if verification.Event != auditVerificationEvent || verification.Hash != invalid.Hash() || verification.Error == "" {
+		t.Errorf("verification mismatch: have %+v", verification)
+	}
+	if len(verification.Failed) != 1 || verification.Failed[0].Name != checkVotes {
+		t.Errorf("failed checks mismatch: have %+v", verification.Failed)
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/ethereum/go-ethereum/contracts"
 	"github.com/ethereum/go-ethereum/eth/fetcher"
 	eth2 "github.com/ethereum/go-ethereum/eth/protocols/eth"
+	"github.com/ethereum/go-ethereum/internal/consensuslog"
 	single "github.com/ethereum/go-ethereum/singleton"
 	"github.com/holiman/uint256"
 	"io"
@@ -43,7 +44,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
-	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -75,6 +76,16 @@ var (
 	diffNoTurn = big.NewInt(1) // Block difficulty for out-of-turn signatures
 )
 
+var (
+	roundWinnerMeter  = metrics.NewRegisteredMeter("consensus/clique/round/winner", nil)
+	roundNoVotesMeter = metrics.NewRegisteredMeter("consensus/clique/round/novotes", nil)
+	roundSkippedMeter = metrics.NewRegisteredMeter("consensus/clique/round/skipped", nil)
+
+	// roundMarginHistogram tracks the lead of the winning block over the runner-up
+	// in basis points of the votes cast in the round.
+	roundMarginHistogram = metrics.NewRegisteredHistogram("consensus/clique/round/margin", nil, metrics.NewExpDecaySample(1028, 0.015))
+)
+
 // Various error messages to mark blocks invalid. These should be private to
 // prevent engine specific errors from being referenced in the remainder of the
 // codebase, inherently breaking if the engine is swapped out. Please put common
@@ -86,6 +97,14 @@ var (
 
 	errBalanceNotEnough = errors.New("stoke balance not enough")
 	errMinerVotesIsNil  = errors.New("miner votes is nil")
+
+	// errMissingMinerKey is returned when sealing is attempted before a miner key
+	// or signer was configured via Authorize or the legacy key file.
+	errMissingMinerKey = errors.New("miner signing key not configured")
+
+	// errZeroPeriod is returned when preparing a block of a 0-period chain outside
+	// of the developer mode, the only one sealing blocks on demand.
+	errZeroPeriod = errors.New("zero block period outside developer mode")
 	// errInvalidCheckpointBeneficiary is returned if a checkpoint/epoch transition
 	// block has a beneficiary set to non-zeroes.
 	errInvalidCheckpointBeneficiary = errors.New("beneficiary in checkpoint block non-zero")
@@ -208,6 +227,13 @@ type Clique struct {
 	signFn SignerFn       // Signer function to authorize hashes with
 	lock   sync.RWMutex   // Protects the signer and proposals fields
 
+	votes *VoteHistory // Votes signed by the local miner, nil if not persisted
+	audit *AuditLog    // Record of the round decisions and verification failures, nil if disabled
+
+	devMode      bool              // Verify locally sealed blocks like the ones of peers
+	devVoters    []*simulatedVoter // Simulated voters voting next to the local miner
+	devSealEmpty bool              // Seal empty blocks on 0-period chains, which are sealed on demand
+
 	// The fields below are for testing only
 	fakeDiff    bool // Skip difficulty verifications
 	erc20       *contracts.ERC20
@@ -217,11 +243,6 @@ type Clique struct {
 // New creates a Clique proof-of-authority consensus engine with the initial
 // signers set to the ones provided by the user.
 func New(config *params.CliqueConfig, db ethdb.Database) *Clique {
-	// 调用 New 方法获取私钥和地址
-	_, _, err := single.New()
-	if err != nil {
-		fmt.Println("Failed to initialize: %v", err)
-	}
 	// Set any missing consensus parameters to their defaults
 	conf := *config
 	if conf.Epoch == 0 {
@@ -342,117 +363,75 @@ func (c *Clique) verifyHeader(chain consensus.ChainHeaderReader, header *types.H
 		return fmt.Errorf("invalid gasLimit: have %v, max %v", header.GasLimit, params.MaxGasLimit)
 	}
 
-	// 验证是否支持特定的硬分叉
-	if chain.Config().IsShanghai(header.Number, header.Time) {
-		return errors.New("clique does not support shanghai fork")
-	}
-	if header.WithdrawalsHash != nil {
-		return fmt.Errorf("invalid withdrawalsHash: have %x, expected nil", header.WithdrawalsHash)
-	}
-	if chain.Config().IsCancun(header.Number, header.Time) {
-		return errors.New("clique does not support cancun fork")
+	// 验证 Shanghai 和 Cancun 硬分叉的字段
+	if err := verifyForkFields(chain.Config(), header); err != nil {
+		return err
 	}
 
+	// 创世区块没有投票
+	if number == 0 {
+		return nil
+	}
 	// 验证区块的签名和投票信息
 	return c.verifyBlockVotesAndSignatures(chain, header)
 }
 
-func (c *Clique) verifyBlockVotesAndSignatures(chain consensus.ChainHeaderReader, header *types.Header) error {
-	// 收集签名和公钥
-	//fmt.Println("current Num", header.Number.String())
-	var pubKeys [][]byte
-	var minBalanceThreshold = big.NewInt(100000)
-	var votesCount = big.NewInt(0) // 当前区块的总票数
-	for i, minerAddress := range header.MinerAddresses {
-		// 1. 验证之前10个区块的ERC20余额是否满足要求
-		balanceLast, err := c.erc20.BalanceOfAt(minerAddress, new(big.Int).Sub(header.Number, big.NewInt(miner_waiting_block)))
-		if err != nil {
-			return fmt.Errorf("error retrieving ERC20 balance for miner %s: %v", minerAddress.Hex(), err)
+// verifyForkFields verifies the header fields introduced by the Shanghai and
+// Cancun forks. Clique activates the opcodes of the forks but neither
+// withdrawals nor blobs, so the fields must be absent before the forks and
+// either absent or zero after them.
+func verifyForkFields(config *params.ChainConfig, header *types.Header) error {
+	if !config.IsShanghai(header.Number, header.Time) {
+		if header.WithdrawalsHash != nil {
+			return fmt.Errorf("invalid withdrawalsHash before fork: have %x, expected nil", *header.WithdrawalsHash)
 		}
-		//fmt.Println("balanceLast ：", balanceLast.String())
-		balance, err := c.erc20.BalanceOfAt(minerAddress, new(big.Int).Sub(header.Number, big.NewInt(miner_waiting_block)))
-		if err != nil {
-			return fmt.Errorf("error retrieving ERC20 balance for miner %s: %v", minerAddress.Hex(), err)
-		}
-		//fmt.Println("balance ：", balance.String())
-		result := balanceLast.Cmp(balance)
-
-		// 根据比较结果执行操作
-		if result == 1 {
-			fmt.Println("balanceLast 大于 balance 说明有资金转出，不用等待，但要加上")
-			balance = balanceLast
+	} else if header.WithdrawalsHash != nil && *header.WithdrawalsHash != types.EmptyWithdrawalsHash {
+		return fmt.Errorf("invalid withdrawalsHash: have %x, expected nil or %x", *header.WithdrawalsHash, types.EmptyWithdrawalsHash)
+	}
+	if !config.IsCancun(header.Number, header.Time) {
+		switch {
+		case header.BlobGasUsed != nil:
+			return fmt.Errorf("invalid blobGasUsed before fork: have %d, expected nil", *header.BlobGasUsed)
+		case header.ExcessBlobGas != nil:
+			return fmt.Errorf("invalid excessBlobGas before fork: have %d, expected nil", *header.ExcessBlobGas)
+		case header.ParentBeaconRoot != nil:
+			return fmt.Errorf("invalid parentBeaconRoot before fork: have %x, expected nil", *header.ParentBeaconRoot)
 		}
-		if balance.Cmp(minBalanceThreshold) < 0 {
-			fmt.Printf("Miner %s ERC20 balance: %s\n", minerAddress.Hex(), balance.String()) // 打印余额
-			return fmt.Errorf("miner %s does not meet the minimum balance threshold", minerAddress.Hex())
-		}
-
-		// 2. 从签名和原像恢复公钥
-		sigPublicKey, err := crypto.SigToPub(header.ZkscamHash.Bytes(), header.Signatures[i])
-		if err != nil {
-			return fmt.Errorf("error recovering public key for miner %s: %v", minerAddress.Hex(), err)
-		}
-
-		// 3. 检查从签名中恢复的地址是否匹配
-		recoveredAddr := crypto.PubkeyToAddress(*sigPublicKey)
-		if recoveredAddr != minerAddress {
-			return fmt.Errorf("invalid signature: recovered address %s does not match miner address %s", recoveredAddr.Hex(), minerAddress.Hex())
-		}
-
-		// 4. 验证 BLS 公钥和授权签名
-		pass_sigBLSKey, err := single.VerifyAnyLengthMessageSignatureWithAddress(header.BLSPublicKeys[i], header.AuthBLSSignatures[i], minerAddress)
-		if err != nil {
-			return fmt.Errorf("error verifying BLS key signature for miner %s: %v", minerAddress.Hex(), err)
-		}
-		if !pass_sigBLSKey {
-			return fmt.Errorf("invalid BLS key signature for miner %s", minerAddress.Hex())
-		}
-
-		// 5. 增加票数计数
-		votesCount = votesCount.Add(votesCount, balance)
-
-		pubKeys = append(pubKeys, header.BLSPublicKeys[i])
+		return nil
 	}
-
-	// 6. 验证当前区块票数是否匹配
-	if header.Votes != nil {
-		if header.Votes.Cmp(votesCount) != 0 {
-			fmt.Printf("total votes: header has %d total votes, expected %d total votes\n", header.Votes, votesCount)
-
-			return fmt.Errorf("votes count mismatch: header has %d votes, but calculated %d votes", header.Votes, votesCount)
-		}
-	} else {
-		return fmt.Errorf("votes nil")
+	if header.BlobGasUsed != nil && *header.BlobGasUsed != 0 {
+		return fmt.Errorf("invalid blobGasUsed: have %d, expected nil or 0", *header.BlobGasUsed)
 	}
-
-	// 7. 验证 `TotalVotes` 是否正确
-	// 验证时优先从缓存中读取
-	parentHeader := c.headerCache.Get(header.ParentHash)
-	if parentHeader == nil {
-		// 如果缓存中没有，再从链中获取
-		parentHeader = chain.GetHeader(header.ParentHash, header.Number.Uint64()-1)
-		if parentHeader == nil {
-			return fmt.Errorf("unable to retrieve parent header for block %d", header.Number.Uint64()-1)
-		}
+	if header.ExcessBlobGas != nil && *header.ExcessBlobGas != 0 {
+		return fmt.Errorf("invalid excessBlobGas: have %d, expected nil or 0", *header.ExcessBlobGas)
 	}
-	//parentHeader := chain.GetHeader(header.ParentHash, header.Number.Uint64()-1)
-	expectedTotalVotes := new(big.Int).Set(votesCount) // 当前区块的票数
-	if parentHeader != nil {
-		expectedTotalVotes = expectedTotalVotes.Add(expectedTotalVotes, parentHeader.TotalVotes)
+	if header.ParentBeaconRoot != nil && *header.ParentBeaconRoot != (common.Hash{}) {
+		return fmt.Errorf("invalid parentBeaconRoot: have %x, expected nil or zero", *header.ParentBeaconRoot)
 	}
-
-	if header.TotalVotes.Cmp(expectedTotalVotes) != 0 {
-		fmt.Printf("total votes mismatch: header has %d total votes, but expected %d total votes", header.TotalVotes, expectedTotalVotes)
-		return fmt.Errorf("total votes mismatch: header has %d total votes, but expected %d total votes", header.TotalVotes, expectedTotalVotes)
+	// Optional fields can only be left out at the end of the header, otherwise
+	// it has no RLP encoding
+	if header.WithdrawalsHash == nil && (header.BlobGasUsed != nil || header.ExcessBlobGas != nil || header.ParentBeaconRoot != nil) {
+		return errors.New("missing withdrawalsHash before the cancun fields")
 	}
-
-	// 8. 调用 single 包中的 BLS 聚合签名验证函数
-	isValid, err := single.BLSAggregateVerify(header.ZkscamHash.Bytes(), header.AggregatedSignature, pubKeys)
-	if err != nil || !isValid {
-		return fmt.Errorf("aggregated signature verification failed: %v", err)
+	if (header.BlobGasUsed == nil) != (header.ExcessBlobGas == nil) {
+		return errors.New("blobGasUsed and excessBlobGas must be both present or absent")
+	}
+	if header.ExcessBlobGas == nil && header.ParentBeaconRoot != nil {
+		return errors.New("missing blob gas fields before parentBeaconRoot")
 	}
-	c.headerCache.Set(header.Hash(), header)
-	fmt.Println("Aggregated signature verification succeeded!")
+	return nil
+}
+
+func (c *Clique) verifyBlockVotesAndSignatures(chain consensus.ChainHeaderReader, header *types.Header) error {
+	v := c.verifyVotes(chain, header, false)
+	if !v.Valid {
+		c.lock.RLock()
+		c.audit.verification(header, v)
+		c.lock.RUnlock()
+		return v.err
+	}
+	c.headerCache.Set(v.Hash, header)
+	consensuslog.Logger().Trace("Verified block votes", "number", header.Number, "hash", v.Hash, "votes", header.Votes)
 	return nil
 }
 
@@ -555,6 +534,21 @@ func (c *Clique) Prepare(chain consensus.ChainHeaderReader, header *types.Header
 	// 获取当前系统时间
 	currentTime := uint64(time.Now().Unix())
 
+	// 开发者模式下 0 周期链按需封印，时间戳只需不早于父区块
+	if c.config.Period == 0 {
+		c.lock.RLock()
+		devMode := c.devMode
+		c.lock.RUnlock()
+		if !devMode {
+			return errZeroPeriod
+		}
+		header.Time = parent.Time
+		if currentTime > header.Time {
+			header.Time = currentTime
+		}
+		return nil
+	}
+
 	// 计算N，使得 currentTime > parent.Time + N * c.config.Period 并且 currentTime < parent.Time + (N+1) * c.config.Period
 	if currentTime <= parent.Time {
 		return fmt.Errorf("current system time is earlier than parent block time")
@@ -570,8 +564,51 @@ func (c *Clique) Prepare(chain consensus.ChainHeaderReader, header *types.Header
 	return nil
 }
 
-// DistributeMinerGasReward 在这里，我们计算总的 gas 费用，并将其按照矿工的质押比例分配。
-func (c *Clique) DistributeMinerGasReward(header *types.Header, state *state.StateDB, txs []*types.Transaction, receipts []*types.Receipt) {
+// buybackAddress is the buyback contract receiving 20% of the gas fees.
+var buybackAddress = common.HexToAddress("0x1234567890abcdef1234567890abcdef12345678")
+
+// Reward is an amount credited to an account when a block is finalized.
+type Reward struct {
+	Address common.Address
+	Amount  *big.Int
+}
+
+// BlockRewards returns the gas rewards credited when finalizing a block with the
+// given transactions on top of parent: the share of the buyback contract
+// followed by the ones of the miners that voted for the parent.
+func (c *Clique) BlockRewards(parent *types.Header, txs []*types.Transaction, receipts []*types.Receipt) []Reward {
+	return c.gasRewards(parent, txs, receipts)
+}
+
+// AppliedRewards returns the gas rewards credited when the given block, stored
+// with its receipts, was applied on top of parent. Genesis credits nothing, all
+// other blocks need their parent and receipts, even if they are empty.
+func (c *Clique) AppliedRewards(block *types.Block, parent *types.Header, receipts types.Receipts) ([]Reward, error) {
+	number := block.NumberU64()
+	if number == 0 {
+		return nil, nil
+	}
+	if parent == nil {
+		return nil, fmt.Errorf("missing block %d", number-1)
+	}
+	if len(receipts) != len(block.Transactions()) {
+		return nil, fmt.Errorf("missing receipts of block %d", number)
+	}
+	return c.BlockRewards(parent, block.Transactions(), receipts), nil
+}
+
+// gasRewards returns the gas rewards of a block on top of header, reading the
+// stakes of its voters from the staking token.
+func (c *Clique) gasRewards(header *types.Header, txs []*types.Transaction, receipts []*types.Receipt) []Reward {
+	return GasRewards(header, txs, receipts, func(miner common.Address) (*big.Int, error) {
+		return c.erc20.BalanceOfAt(miner, new(big.Int).Sub(header.Number, big.NewInt(miner_waiting_block)))
+	})
+}
+
+// GasRewards 在这里，我们计算总的 gas 费用，并将其按照矿工的质押比例分配。
+// The stake of every voter of header is returned by stakeFn, voters whose stake
+// cannot be retrieved or is below the minimum are not rewarded.
+func GasRewards(header *types.Header, txs []*types.Transaction, receipts []*types.Receipt, stakeFn func(common.Address) (*big.Int, error)) []Reward {
 	// 计算需要分配的总 gas 费用
 	totalFees := new(big.Int)
 
@@ -606,7 +643,7 @@ func (c *Clique) DistributeMinerGasReward(header *types.Header, state *state.Sta
 
 	if totalFees.Sign() == 0 {
 		// 如果当前区块没有产生 gas 费用，无法分配费用
-		return
+		return nil
 	}
 
 	// **将 totalFees 分为 80% 和 20%**
@@ -616,68 +653,63 @@ func (c *Clique) DistributeMinerGasReward(header *types.Header, state *state.Sta
 	twentyPercentFees := new(big.Int).Sub(totalFees, eightyPercentFees) // 剩余的 20%
 
 	// **将 20% 的费用分配给回购合约地址**
-	hardcodedAddress := common.HexToAddress("0x1234567890abcdef1234567890abcdef12345678")
-	twentyPercentFeesUint256, overflow := uint256.FromBig(twentyPercentFees)
-	if overflow {
-		log.Error("twentyPercentFees 超出 uint256 范围")
-	} else {
-		state.AddBalance(hardcodedAddress, twentyPercentFeesUint256)
-	}
-
-	// 获取矿工的地址和质押
-	minerAddresses := header.MinerAddresses
-	totalStake := new(big.Int)
-
-	// 存储矿工质押的映射
-	minerStakes := make(map[common.Address]*big.Int)
-
-	for _, minerAddress := range minerAddresses {
-		stake, err := c.erc20.BalanceOfAt(minerAddress, new(big.Int).Sub(header.Number, big.NewInt(miner_waiting_block)))
+	rewards := []Reward{{Address: buybackAddress, Amount: twentyPercentFees}}
+
+	// 获取矿工的质押, 重复的矿工只获得一次奖励, 但其质押会重复计入总质押
+	var (
+		miners      []common.Address
+		minerStakes = make(map[common.Address]*big.Int)
+		totalStake  = new(big.Int)
+	)
+	for _, minerAddress := range header.MinerAddresses {
+		stake, err := stakeFn(minerAddress)
 		if err != nil {
-			log.Error("无法获取矿工质押", "miner", minerAddress.Hex(), "error", err)
+			consensuslog.Logger().Error("Failed to retrieve miner stake", "miner", minerAddress.Hex(), "error", err)
 			continue
 		}
 		// 如果矿工质押为零，跳过
-		if stake.Cmp(big.NewInt(100000)) < 0 {
+		if stake.Cmp(minBalanceThreshold) < 0 {
 			continue
 		}
+		if _, ok := minerStakes[minerAddress]; !ok {
+			miners = append(miners, minerAddress)
+		}
 		minerStakes[minerAddress] = stake
 		totalStake.Add(totalStake, stake)
 	}
 
 	if totalStake.Sign() == 0 {
 		// 如果没有矿工质押，无法分配费用
-		log.Error("矿工总质押为零，无法分配费用")
-		return
+		consensuslog.Logger().Error("No miner stake to distribute the fees by")
+		return rewards
 	}
 
 	// 按照矿工质押比例分配 80% 的总费用
-	distributedFees := new(big.Int)
-	for minerAddress, stake := range minerStakes {
+	for _, minerAddress := range miners {
 		// 计算矿工应得份额：minerShare = eightyPercentFees * stake / totalStake
-		minerShare := new(big.Int).Mul(eightyPercentFees, stake)
+		minerShare := new(big.Int).Mul(eightyPercentFees, minerStakes[minerAddress])
 		minerShare.Div(minerShare, totalStake)
 
+		rewards = append(rewards, Reward{Address: minerAddress, Amount: minerShare})
+	}
+	return rewards
+}
+
+// DistributeMinerGasReward 将 gas 费用按照矿工的质押比例分配, 20% 分配给回购合约。
+func (c *Clique) DistributeMinerGasReward(header *types.Header, state *state.StateDB, txs []*types.Transaction, receipts []*types.Receipt) {
+	for _, reward := range c.gasRewards(header, txs, receipts) {
 		// **仅当矿工地址为 single.GetETHAddress() 时，记录其奖励**
-		if minerAddress == single.GetETHAddress() {
-			// 将 Wei 转换为 Ether
-			//minerShareFloat := new(big.Float).SetInt(minerShare)
-			//minerShareEther := new(big.Float).Quo(minerShareFloat, big.NewFloat(1e18))
-			log.Info("当前节点获得的奖励", "miner", minerAddress.Hex(), "reward (Wei)", minerShare.String(), "Wei")
+		if reward.Address == single.GetETHAddress() {
+			consensuslog.Logger().Info("Rewarded local miner", "miner", reward.Address.Hex(), "reward", reward.Amount)
 		}
-
-		// 更新状态：将 minerShare 添加到矿工的余额中
-		// 在调用 state.AddBalance 之前，将 minerShare 从 *big.Int 转换为 *uint256.Int
-		minerShareUint256, overflow := uint256.FromBig(minerShare)
+		// 在调用 state.AddBalance 之前，将奖励从 *big.Int 转换为 *uint256.Int
+		amount, overflow := uint256.FromBig(reward.Amount)
 		if overflow {
-			log.Error("minerShare 超出 uint256 范围", "miner", minerAddress.Hex())
+			consensuslog.Logger().Error("Reward overflows uint256", "address", reward.Address.Hex())
 			continue
 		}
-		state.AddBalance(minerAddress, minerShareUint256)
-
-		distributedFees.Add(distributedFees, minerShare)
+		state.AddBalance(reward.Address, amount)
 	}
-
 }
 
 // Finalize implements consensus.Engine. There is no post-transaction
@@ -698,7 +730,7 @@ func (c *Clique) FinalizeAndAssemble(chain consensus.ChainHeaderReader, header *
 	state *state.StateDB, txs []*types.Transaction, uncles []*types.Header,
 	receipts []*types.Receipt, withdrawals []*types.Withdrawal) (*types.Block, error) {
 	if len(withdrawals) > 0 {
-		return nil, errors.New("clique 不支持 withdrawals")
+		return nil, errors.New("clique does not support withdrawals")
 	}
 	// 完成区块
 	c.Finalize(chain, header, state, txs, uncles, receipts, withdrawals)
@@ -706,18 +738,109 @@ func (c *Clique) FinalizeAndAssemble(chain consensus.ChainHeaderReader, header *
 	// 分配最终的状态根到 header。
 	header.Root = state.IntermediateRoot(chain.Config().IsEIP158(header.Number))
 
+	// Shanghai 和 Cancun 之后的字段使用零值，使所有矿工构建相同的区块
+	if chain.Config().IsCancun(header.Number, header.Time) {
+		header.BlobGasUsed, header.ExcessBlobGas = new(uint64), new(uint64)
+	}
+	if chain.Config().IsShanghai(header.Number, header.Time) {
+		return types.NewBlockWithWithdrawals(header, txs, nil, receipts, []*types.Withdrawal{}, trie.NewStackTrie(nil)), nil
+	}
 	// 组装并返回用于封印的最终区块。
 	return types.NewBlock(header, txs, nil, receipts, trie.NewStackTrie(nil)), nil
 }
 
 // Authorize injects a private key into the consensus engine to mint new blocks
-// with.
-func (c *Clique) Authorize(signer common.Address, signFn SignerFn) {
+// with. The sign function is used for every consensus signature of the miner:
+// votes are requested via the accounts.MimetypeZkscamVote mimetype, or via
+// accounts.MimetypeZkscamLegacyVote before the vote digest fork, BLS key
+// authorizations via accounts.MimetypeZkscamBLSAuth.
+// A nil sign function keeps using the key loaded from the legacy miner key file.
+func (c *Clique) Authorize(signer common.Address, signFn SignerFn) error {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
 	c.signer = signer
 	c.signFn = signFn
+
+	if signFn == nil {
+		if _, addr, err := single.New(); err != nil {
+			return err
+		} else if addr != signer {
+			return fmt.Errorf("miner key file belongs to %s, not %s", addr.Hex(), signer.Hex())
+		}
+		return nil
+	}
+	account := accounts.Account{Address: signer}
+	single.SetSigner(signer, func(pub []byte) ([]byte, error) {
+		return signFn(account, accounts.MimetypeZkscamBLSAuth, pub)
+	}, func(number uint64, hash common.Hash, legacy bool) ([]byte, error) {
+		if legacy {
+			return signFn(account, accounts.MimetypeZkscamLegacyVote, accounts.ZkscamVoteData(number, hash))
+		}
+		return signFn(account, accounts.MimetypeZkscamVote, accounts.ZkscamVoteData(number, hash))
+	})
+	return nil
+}
+
+// SetVoteHistory configures the persistent record of signed votes, which keeps
+// the local miner from signing conflicting votes across restarts.
+func (c *Clique) SetVoteHistory(votes *VoteHistory) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.votes = votes
+}
+
+// SetAuditLog configures the audit log the round decisions of the local miner
+// and the vote verification failures of headers are recorded to. The engine
+// closes it when closed.
+func (c *Clique) SetAuditLog(audit *AuditLog) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.audit = audit
+}
+
+// EnableDevMode configures the engine for the single node developer mode: the
+// given simulated voters vote next to the local miner, and sealed blocks are
+// verified like the ones of peers before being released.
+func (c *Clique) EnableDevMode(voters []*ecdsa.PrivateKey) error {
+	simulated := make([]*simulatedVoter, 0, len(voters))
+	for _, key := range voters {
+		voter, err := newSimulatedVoter(key)
+		if err != nil {
+			return err
+		}
+		simulated = append(simulated, voter)
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.devMode, c.devVoters = true, simulated
+	return nil
+}
+
+// SetDevVoterOnline sets whether the simulated voter with the given address
+// votes on the blocks sealed in developer mode, simulating missing voters.
+func (c *Clique) SetDevVoterOnline(voter common.Address, online bool) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, simulated := range c.devVoters {
+		if simulated.bls.Address == voter {
+			simulated.offline = !online
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown simulated voter %s", voter.Hex())
+}
+
+// SetDevSealEmpty sets whether blocks without transactions are sealed on a
+// 0-period chain. The miner keeps them paused, as it would otherwise seal empty
+// blocks in a loop, but chains sealing on demand need them to move forward.
+func (c *Clique) SetDevSealEmpty(seal bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.devSealEmpty = seal
 }
 
 // Seal implements consensus.Engine, attempting to create a sealed block using
@@ -733,12 +856,14 @@ func (c *Clique) Seal(chain consensus.ChainHeaderReader, block *types.Block, res
 
 	// 不支持对创世区块进行封印
 	number := header.Number.Uint64()
-	fmt.Printf("%d", number)
 	if number == 0 {
 		return errUnknownBlock
 	}
 	// 确保自身有打包权利
 	minerAdd := single.GetETHAddress()
+	if minerAdd == (common.Address{}) {
+		return errMissingMinerKey
+	}
 	minerVote, _ := c.erc20.BalanceOfAt(minerAdd, new(big.Int).Sub(header.Number, big.NewInt(miner_waiting_block)))
 	if minerVote == nil {
 		return errMinerVotesIsNil
@@ -746,37 +871,60 @@ func (c *Clique) Seal(chain consensus.ChainHeaderReader, block *types.Block, res
 	if minerVote.Cmp(big.NewInt(100000)) < 0 {
 		return errBalanceNotEnough
 	}
+	c.lock.RLock()
+	devMode, devSealEmpty, audit := c.devMode, c.devSealEmpty, c.audit
+	var devVoters []*simulatedVoter
+	for _, voter := range c.devVoters {
+		if !voter.offline {
+			devVoters = append(devVoters, voter)
+		}
+	}
+	c.lock.RUnlock()
+
 	// 如果是 0 周期链，拒绝封印空区块（没有奖励，但会导致封印操作不断进行）
-	if c.config.Period == 0 && len(block.Transactions()) == 0 {
+	if c.config.Period == 0 && len(block.Transactions()) == 0 && !devSealEmpty {
 		return errors.New("sealing paused while waiting for transactions")
 	}
 
 	// 使用 VtFetcher 实例获取得胜区块的哈希值
 	voteFetcher := fetcher.NewVtFetcher()
-	key, err := single.GetPrivateKey()
+	vote, err := c.vote(chain.Config(), block, minerAdd)
 	if err != nil {
-		log.Info(" get private err: %v", err)
-	}
-	var zkScamHash = block.ZkScamHash()
-	signature, err := sign(zkScamHash, key, single.GetETHAddress())
-	if err != nil {
-		log.Info("err sign(block.ZkScamHash(), key, single.GetETHAddress()): %v", err)
+		return err
 	}
-	vote := eth2.Vote{
-		Number:           block.Number(),
-		MinerAddress:     single.GetETHAddress(),
-		BlockHash:        zkScamHash,
-		Signature:        signature,
-		BLSPublicKey:     single.GetBLSKeyBytes(),
-		AuthBLSSignature: single.SignAnyLengthMessage(single.GetBLSKeyBytes()),
-		BLSSignature:     single.BLSSign(zkScamHash),
+	voteFetcher.AddVote(vote, minerVote)
+
+	// 开发者模式下，在线的模拟矿工与本地矿工投票给同一个区块
+	for _, voter := range devVoters {
+		simulated, err := voter.vote(chain.Config(), vote.Number, vote.BlockHash)
+		if err != nil {
+			consensuslog.Logger().Error("Failed to sign simulated vote", "miner", voter.bls.Address, "err", err)
+			continue
+		}
+		voteFetcher.ReceiveVotes(eth2.Votes{Votes: []eth2.Vote{*simulated}})
 	}
-	voteFetcher.AddVote((*eth2.Vote)(&vote))
 
 	// 等待合适的时间进行签名
 	delay := time.Unix(int64(header.Time), 0).Sub(time.Now()) // nolint: gosimple
-	log.Info("Waiting for slot to sign and propagate", "delay", common.PrettyDuration(delay))
-
+	consensuslog.Logger().Info("Waiting for slot to sign and propagate", "delay", common.PrettyDuration(delay))
+
+	// conclude accounts for the outcome of the round, before the votes of the
+	// slot are cleared. Rounds not sealing a block publish a failed result, the
+	// sealed block is published by the caller.
+	conclude := func(outcome string, winner common.Hash, aggregated []byte, err error) {
+		switch outcome {
+		case roundSealed:
+			roundWinnerMeter.Mark(1)
+		case roundNoVotes:
+			roundNoVotesMeter.Mark(1)
+		default:
+			roundSkippedMeter.Mark(1)
+		}
+		audit.round(number, voteFetcher.Ballots(), winner, aggregated, outcome, err)
+		if outcome != roundSealed {
+			voteFetcher.PostRoundResult(header.Number, nil)
+		}
+	}
 	go func() {
 		select {
 		case <-stop:
@@ -785,7 +933,8 @@ func (c *Clique) Seal(chain consensus.ChainHeaderReader, block *types.Block, res
 			// 获取获胜区块的哈希值
 			winningBlockHash, err := voteFetcher.DetermineWinner()
 			if err != nil {
-				log.Error("Failed to determine winner", "error", err)
+				consensuslog.Logger().Error("Failed to determine winner", "error", err)
+				conclude(roundSkipped, common.Hash{}, nil, err)
 				results <- nil
 				return
 			}
@@ -798,7 +947,16 @@ func (c *Clique) Seal(chain consensus.ChainHeaderReader, block *types.Block, res
 			var votesCount *big.Int = big.NewInt(0) // 当前区块的总票数
 			votes, exists := voteFetcher.GetVotesForBlock(winningBlockHash)
 			if !exists {
-				log.Error("No votes found for block hash", "hash", winningBlockHash.Hex())
+				consensuslog.Logger().Error("No votes found for block hash", "hash", winningBlockHash.Hex())
+				conclude(roundNoVotes, winningBlockHash, nil, nil)
+				results <- nil
+				return
+			}
+			// Blocks with more voters are rejected by every verifier
+			if len(votes) > MaxVoteMiners {
+				err := fmt.Errorf("too many miners voted: have %d, max %d", len(votes), MaxVoteMiners)
+				consensuslog.Logger().Error("Failed to collect votes", "hash", winningBlockHash.Hex(), "err", err)
+				conclude(roundSkipped, winningBlockHash, nil, err)
 				results <- nil
 				return
 			}
@@ -817,24 +975,27 @@ func (c *Clique) Seal(chain consensus.ChainHeaderReader, block *types.Block, res
 				} else {
 					balanceLast, err := c.erc20.BalanceOfAt(minerAddress, new(big.Int).Sub(header.Number, big.NewInt(miner_waiting_block)))
 					if err != nil {
-						fmt.Errorf("error retrieving ERC20 balance for miner %s: %v", minerAddress.Hex(), err)
+						consensuslog.Logger().Error("Failed to retrieve ERC20 balance", "miner", minerAddress, "err", err)
+						conclude(roundSkipped, winningBlockHash, nil, err)
 						return
 					}
-					fmt.Println("balanceLast ：", balanceLast.String())
 					balance, err := c.erc20.BalanceOfAt(minerAddress, new(big.Int).Sub(header.Number, big.NewInt(miner_waiting_block)))
 					if err != nil {
-						fmt.Errorf("error retrieving ERC20 balance for miner %s: %v", minerAddress.Hex(), err)
+						consensuslog.Logger().Error("Failed to retrieve ERC20 balance", "miner", minerAddress, "err", err)
+						conclude(roundSkipped, winningBlockHash, nil, err)
 						return
 					}
-					fmt.Println("balance ：", balance.String())
 					result := balanceLast.Cmp(balance)
 
 					// 根据比较结果执行操作
 					if result == 1 {
-						fmt.Println("balanceLast 大于 balance 说明有资金转出，不用等待，但要加上")
+						// 有资金转出，不用等待，但要加上
+						consensuslog.Logger().Debug("Stake of local miner decreased, counting the previous one", "previous", balanceLast, "stake", balance)
 						balance = balanceLast
 					} else if result == -1 {
-						fmt.Println("balanceLast 小于 balance 说明有资金转入，等待一个区块")
+						// 有资金转入，等待一个区块
+						consensuslog.Logger().Debug("Stake of local miner increased, waiting a block", "previous", balanceLast, "stake", balance)
+						conclude(roundSkipped, winningBlockHash, nil, errors.New("stake of local miner increased"))
 						return
 					}
 					votesCount = votesCount.Add(votesCount, balance)
@@ -851,7 +1012,8 @@ func (c *Clique) Seal(chain consensus.ChainHeaderReader, block *types.Block, res
 			// 获取聚合签名
 			aggregatedSignature, err := voteFetcher.AggregateSignaturesForBlock(winningBlockHash)
 			if err != nil {
-				log.Error("Failed to aggregate signatures", "error", err)
+				consensuslog.Logger().Error("Failed to aggregate signatures", "error", err)
+				conclude(roundSkipped, winningBlockHash, nil, err)
 				results <- nil
 				return
 			}
@@ -865,14 +1027,31 @@ func (c *Clique) Seal(chain consensus.ChainHeaderReader, block *types.Block, res
 			header.AggregatedSignature = aggregatedSignature
 			header.Votes = votesCount      // 当前区块的票数
 			header.TotalVotes = totalVotes // 累计历史总票数
+
+			// 开发者模式下没有其他节点验证区块，在发布前由本地验证
+			if devMode {
+				if err := c.verifyBlockVotesAndSignatures(chain, header); err != nil {
+					consensuslog.Logger().Error("Sealed block failed vote verification", "number", number, "err", err)
+					conclude(roundSkipped, winningBlockHash, aggregatedSignature, err)
+					voteFetcher.ClearVotes()
+					results <- nil
+					return
+				}
+			}
 			// 在区块写入数据库之前将其缓存
 
+			sealed := block.WithSeal(header)
+			conclude(roundSealed, winningBlockHash, aggregatedSignature, nil)
+			if margin, cast := voteFetcher.Margin(); cast.Sign() > 0 {
+				roundMarginHistogram.Update(new(big.Int).Div(new(big.Int).Mul(margin, big.NewInt(10000)), cast).Int64())
+			}
+			voteFetcher.PostRoundResult(header.Number, sealed)
 			voteFetcher.ClearVotes()
 
 			select {
-			case results <- block.WithSeal(header):
+			case results <- sealed:
 			default:
-				log.Warn("Sealing result is not read by miner", "sealhash", SealHash(header))
+				consensuslog.Logger().Warn("Sealing result is not read by miner", "sealhash", SealHash(header))
 			}
 		}
 	}()
@@ -880,16 +1059,69 @@ func (c *Clique) Seal(chain consensus.ChainHeaderReader, block *types.Block, res
 	return nil
 }
 
-// sign 签名函数
-func sign(hash common.Hash, privKey *ecdsa.PrivateKey, ethAddress common.Address) ([]byte, error) {
-	// 签名哈希
-	sig, err := crypto.Sign(hash.Bytes(), privKey)
+// vote returns the local miner's vote for the block. If the miner already voted
+// at this height, e.g. before a restart, the recorded vote is returned instead
+// of signing a conflicting one. New votes are recorded before they are used,
+// votes missing any of their BLS fields are neither recorded nor returned.
+func (c *Clique) vote(config *params.ChainConfig, block *types.Block, minerAdd common.Address) (*eth2.Vote, error) {
+	c.lock.RLock()
+	votes := c.votes
+	c.lock.RUnlock()
+
+	number, zkScamHash := block.NumberU64(), block.ZkScamHash()
+	if votes != nil {
+		prev, err := votes.Get(minerAdd, number)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read vote history: %v", err)
+		}
+		if prev != nil {
+			if prev.BlockHash != zkScamHash {
+				consensuslog.Logger().Warn("Refusing conflicting vote, re-broadcasting signed one", "number", number, "signed", prev.BlockHash, "refused", zkScamHash)
+			}
+			return prev, nil
+		}
+	}
+	signature, err := sign(config, block.Number(), zkScamHash, minerAdd)
+	if err != nil {
+		return nil, err
+	}
+	vote := &eth2.Vote{
+		Number:           block.Number(),
+		MinerAddress:     minerAdd,
+		BlockHash:        zkScamHash,
+		Signature:        signature,
+		BLSPublicKey:     single.GetBLSKeyBytes(),
+		AuthBLSSignature: single.BLSAuthorization(),
+		BLSSignature:     single.BLSSign(zkScamHash),
+	}
+	// Every verifier rejects votes without their BLS fields, so don't record or
+	// broadcast one, a later attempt at this height may still succeed
+	switch {
+	case vote.BLSPublicKey == nil:
+		return nil, single.ErrNoBLSKey
+	case vote.AuthBLSSignature == nil:
+		return nil, errors.New("failed to authorize BLS key")
+	case vote.BLSSignature == nil:
+		return nil, errors.New("failed to BLS sign vote")
+	}
+	if votes != nil {
+		if err := votes.Put(vote); err != nil {
+			return nil, fmt.Errorf("failed to record vote: %v", err)
+		}
+	}
+	return vote, nil
+}
+
+// sign 签名函数，通过矿工私钥或 Authorize 注入的签名器对投票摘要签名
+func sign(config *params.ChainConfig, number *big.Int, hash common.Hash, ethAddress common.Address) ([]byte, error) {
+	// 签名该高度的投票哈希，投票摘要分叉之前只签名原始哈希
+	sig, err := single.SignVote(number.Uint64(), hash, !config.IsZkscamVoteDigest(number))
 	if err != nil {
 		return nil, fmt.Errorf("signing failed: %v", err)
 	}
 
 	// 从签名恢复出公钥
-	publicKey, err := crypto.SigToPub(hash.Bytes(), sig)
+	publicKey, err := crypto.SigToPub(accounts.ZkscamVoteDigest(config, number, hash), sig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to recover public key from signature: %v", err)
 	}
@@ -925,9 +1157,17 @@ func (c *Clique) SealHash(header *types.Header) common.Hash {
 	return SealHash(header)
 }
 
-// Close implements consensus.Engine. It's a noop for clique as there are no background threads.
+// Close implements consensus.Engine, closing the round audit log if one is open.
 func (c *Clique) Close() error {
-	return nil
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.audit == nil {
+		return nil
+	}
+	err := c.audit.Close()
+	c.audit = nil
+	return err
 }
 
 // APIs implements consensus.Engine, returning the user facing RPC API to allow
@@ -936,6 +1176,9 @@ func (c *Clique) APIs(chain consensus.ChainHeaderReader) []rpc.API {
 	return []rpc.API{{
 		Namespace: "clique",
 		Service:   &API{chain: chain, clique: c},
+	}, {
+		Namespace: "zkscam",
+		Service:   &ZkscamAPI{chain: chain, clique: c},
 	}}
 }
 
@@ -978,20 +1221,20 @@ func encodeSigHeader(w io.Writer, header *types.Header) {
 		header.MixDigest,
 		header.Nonce,
 	}
-	if header.BaseFee != nil {
-		enc = append(enc, header.BaseFee)
-	}
-	if header.WithdrawalsHash != nil {
-		panic("unexpected withdrawal hash value in clique")
-	}
-	if header.ExcessBlobGas != nil {
-		panic("unexpected excess blob gas value in clique")
-	}
-	if header.BlobGasUsed != nil {
-		panic("unexpected blob gas used value in clique")
-	}
-	if header.ParentBeaconRoot != nil {
-		panic("unexpected parent beacon root value in clique")
+	// The optional fields are encoded like in the header RLP: up to the last one
+	// present, the absent ones before it as empty strings.
+	optional := []interface{}{header.BaseFee, header.WithdrawalsHash, header.BlobGasUsed, header.ExcessBlobGas, header.ParentBeaconRoot}
+	switch {
+	case header.ParentBeaconRoot != nil:
+		enc = append(enc, optional...)
+	case header.ExcessBlobGas != nil:
+		enc = append(enc, optional[:4]...)
+	case header.BlobGasUsed != nil:
+		enc = append(enc, optional[:3]...)
+	case header.WithdrawalsHash != nil:
+		enc = append(enc, optional[:2]...)
+	case header.BaseFee != nil:
+		enc = append(enc, optional[:1]...)
 	}
 	if err := rlp.Encode(w, enc); err != nil {
 		panic("can't encode: " + err.Error())
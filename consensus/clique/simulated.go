@@ -0,0 +1,124 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package clique
+
+import (
+	"crypto/ecdsa"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	eth2 "github.com/ethereum/go-ethereum/eth/protocols/eth"
+	"github.com/ethereum/go-ethereum/params"
+	single "github.com/ethereum/go-ethereum/singleton"
+)
+
+// developerVoterSeed is the public seed the keys of the simulated voters are
+// derived from.
+var developerVoterSeed = []byte("zkscam developer voter")
+
+// DeveloperVoterKeys returns the keys of the first n simulated voters of the
+// developer mode. They are derived from a public seed, so that restarts keep the
+// voters staked in the genesis, and must never hold any value.
+func DeveloperVoterKeys(n int) []*ecdsa.PrivateKey {
+	keys := make([]*ecdsa.PrivateKey, n)
+	for i := range keys {
+		var index [8]byte
+		binary.BigEndian.PutUint64(index[:], uint64(i))
+		key, err := crypto.ToECDSA(crypto.Keccak256(developerVoterSeed, index[:]))
+		if err != nil {
+			panic(err) // Astronomically unlikely, the seed is fixed anyway
+		}
+		keys[i] = key
+	}
+	return keys
+}
+
+// simulatedVoter is an in-process miner voting for the same blocks as the local
+// one in developer mode.
+type simulatedVoter struct {
+	key     *ecdsa.PrivateKey
+	bls     *single.BLSKey
+	offline bool // Whether the voter abstains, protected by the engine lock
+}
+
+// newSimulatedVoter creates a simulated voter with a fresh BLS key authorized by
+// the given account key.
+func newSimulatedVoter(key *ecdsa.PrivateKey) (*simulatedVoter, error) {
+	blsKey := single.GenerateBLSKey(crypto.PubkeyToAddress(key.PublicKey))
+	if err := blsKey.Authorize(func(hash []byte) ([]byte, error) {
+		return crypto.Sign(hash, key)
+	}); err != nil {
+		return nil, err
+	}
+	return &simulatedVoter{key: key, bls: blsKey}, nil
+}
+
+// vote returns the vote of the simulated voter for the given block hash.
+func (v *simulatedVoter) vote(config *params.ChainConfig, number *big.Int, hash common.Hash) (*eth2.Vote, error) {
+	signature, err := crypto.Sign(accounts.ZkscamVoteDigest(config, number, hash), v.key)
+	if err != nil {
+		return nil, err
+	}
+	blsSignature, err := v.bls.Sign(hash.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return &eth2.Vote{
+		Number:           new(big.Int).Set(number),
+		MinerAddress:     v.bls.Address,
+		BlockHash:        hash,
+		Signature:        signature,
+		BLSPublicKey:     v.bls.PublicKey(),
+		AuthBLSSignature: v.bls.Authorization,
+		BLSSignature:     blsSignature,
+	}, nil
+}
+
+// AuthorizeKey authorizes the engine to seal and vote with the given private
+// key, together with a fresh BLS key authorized by it. It is meant for chains
+// whose miner keys are generated in process, e.g. the simulated backend.
+func (c *Clique) AuthorizeKey(key *ecdsa.PrivateKey) error {
+	signFn := func(account accounts.Account, mimeType string, data []byte) ([]byte, error) {
+		if mimeType == accounts.MimetypeZkscamVote || mimeType == accounts.MimetypeZkscamLegacyVote {
+			number, hash, err := accounts.ParseZkscamVoteData(data)
+			if err != nil {
+				return nil, err
+			}
+			if mimeType == accounts.MimetypeZkscamLegacyVote {
+				return crypto.Sign(hash[:], key)
+			}
+			return crypto.Sign(accounts.ZkscamVoteHash(number, hash), key)
+		}
+		if mimeType == accounts.MimetypeZkscamBLSAuth {
+			return crypto.Sign(accounts.ZkscamBLSAuthHash(data), key)
+		}
+		return nil, fmt.Errorf("unsupported mimetype %s", mimeType)
+	}
+	voter, err := newSimulatedVoter(key)
+	if err != nil {
+		return err
+	}
+	if err := c.Authorize(voter.bls.Address, signFn); err != nil {
+		return err
+	}
+	single.SetBLSKey(voter.bls)
+	return nil
+}
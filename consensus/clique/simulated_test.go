@@ -0,0 +1,73 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package clique
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	single "github.com/ethereum/go-ethereum/singleton"
+)
+
+// Tests that the simulated voters keep their accounts across restarts.
+func TestDeveloperVoterKeys(t *testing.T) {
+	first, second := DeveloperVoterKeys(3), DeveloperVoterKeys(2)
+	seen := make(map[common.Address]bool)
+	for i, key := range first {
+		addr := crypto.PubkeyToAddress(key.PublicKey)
+		if seen[addr] {
+			t.Fatalf("voter %d: duplicate address %v", i, addr)
+		}
+		seen[addr] = true
+		if i < len(second) && crypto.PubkeyToAddress(second[i].PublicKey) != addr {
+			t.Fatalf("voter %d: address mismatch across derivations", i)
+		}
+	}
+}
+
+// Tests that the votes of simulated voters pass the checks of peer votes.
+func TestSimulatedVote(t *testing.T) {
+	voter, err := newSimulatedVoter(DeveloperVoterKeys(1)[0])
+	if err != nil {
+		t.Fatalf("failed to create voter: %v", err)
+	}
+	hash := common.HexToHash("0x3d5bc5dbd46de2d6a0d9c4e5e4cf0f5ebfb8b7b11e5e0ec0b3e3f5b6b5a3c2e1")
+	vote, err := voter.vote(params.AllCliqueProtocolChanges, big.NewInt(7), hash)
+	if err != nil {
+		t.Fatalf("failed to vote: %v", err)
+	}
+	if vote.Number.Uint64() != 7 || vote.BlockHash != hash {
+		t.Fatalf("vote mismatch: have %d/%x, want 7/%x", vote.Number, vote.BlockHash, hash)
+	}
+	pub, err := crypto.SigToPub(accounts.ZkscamVoteHash(7, hash), vote.Signature)
+	if err != nil {
+		t.Fatalf("failed to recover signer: %v", err)
+	}
+	if signer := crypto.PubkeyToAddress(*pub); signer != vote.MinerAddress {
+		t.Fatalf("signer mismatch: have %v, want %v", signer, vote.MinerAddress)
+	}
+	if ok, err := single.VerifyAnyLengthMessageSignatureWithAddress(vote.BLSPublicKey, vote.AuthBLSSignature, vote.MinerAddress); !ok {
+		t.Fatalf("invalid BLS authorization: %v", err)
+	}
+	if ok, err := single.BLSAggregateVerify(hash.Bytes(), vote.BLSSignature, [][]byte{vote.BLSPublicKey}); !ok {
+		t.Fatalf("invalid BLS signature: %v", err)
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package clique
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	single "github.com/ethereum/go-ethereum/singleton"
+)
+
+// minBalanceThreshold is the minimum stake a miner needs for its vote to count.
+var minBalanceThreshold = big.NewInt(100000)
+
+// MinStake returns the minimum stake a miner needs for its vote to count.
+func MinStake() *big.Int {
+	return new(big.Int).Set(minBalanceThreshold)
+}
+
+// MaxVoteMiners is the maximum number of miners that may vote on a block. It
+// bounds the stake lookups and signature checks done for a single header.
+const MaxVoteMiners = 1024
+
+// Names of the checks of the vote verification.
+const (
+	checkPayload             = "payload"             // Vote fields are present for every miner
+	checkStake               = "stake"               // Miner holds the minimum stake at the lookback block
+	checkSignature           = "signature"           // Miner signed the zkscam hash
+	checkBLSAuthorization    = "blsAuthorization"    // Miner authorized its BLS key
+	checkVotes               = "votes"               // Votes equal the stake of the miners
+	checkTotalVotes          = "totalVotes"          // Total votes extend the ones of the parent
+	checkAggregatedSignature = "aggregatedSignature" // BLS signature aggregates the miners' ones
+)
+
+// VoteCheck is the outcome of a single check of the vote verification.
+type VoteCheck struct {
+	Name   string          `json:"name"`
+	Miner  *common.Address `json:"miner,omitempty"`
+	Passed bool            `json:"passed"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// VoteVerification is the outcome of verifying the votes of a block, listing
+// every check that was run.
+type VoteVerification struct {
+	Number hexutil.Uint64 `json:"number"`
+	Hash   common.Hash    `json:"hash"`
+	Valid  bool           `json:"valid"`
+	Checks []VoteCheck    `json:"checks"`
+
+	err  error // First failed check, as reported to consensus
+	full bool  // Whether to run the remaining checks after a failure
+}
+
+// record adds the outcome of a check, returning whether the verification goes
+// on with the next one.
+func (v *VoteVerification) record(name string, miner *common.Address, err error) bool {
+	check := VoteCheck{Name: name, Miner: miner, Passed: err == nil}
+	if err != nil {
+		check.Error = err.Error()
+		if v.err == nil {
+			v.err = err
+		}
+	}
+	v.Checks = append(v.Checks, check)
+	return err == nil || v.full
+}
+
+// VerifyVotes re-runs the vote verification of a header, reporting the result
+// of each check instead of stopping at the first failure.
+func (c *Clique) VerifyVotes(chain consensus.ChainHeaderReader, header *types.Header) *VoteVerification {
+	return c.verifyVotes(chain, header, true)
+}
+
+// verifyVotes verifies the votes of a header. With full set, every check is run
+// and reported, otherwise the verification stops at the first failed check, as
+// consensus only needs to know whether the votes are valid.
+func (c *Clique) verifyVotes(chain consensus.ChainHeaderReader, header *types.Header, full bool) *VoteVerification {
+	v := &VoteVerification{Number: hexutil.Uint64(header.Number.Uint64()), Hash: header.Hash(), full: full}
+
+	// 0. 检查每个矿工都有签名、BLS 公钥和授权签名
+	verifyPayload := verifyLegacyVotePayload
+	if chain.Config().IsZkscamVotePayload(header.Number) {
+		verifyPayload = verifyVotePayload
+	}
+	// The remaining checks index the vote fields, so a malformed payload always
+	// ends the verification
+	if err := verifyPayload(header); !v.record(checkPayload, nil, err) || err != nil {
+		return v
+	}
+	var (
+		pubKeys    [][]byte
+		votesCount = big.NewInt(0) // 当前区块的总票数
+	)
+	for i := range header.MinerAddresses {
+		minerAddress := &header.MinerAddresses[i]
+
+		// 1. 验证之前10个区块的ERC20余额是否满足要求
+		balance, err := c.MinerStake(*minerAddress, header.Number)
+		if err == nil && balance.Cmp(minBalanceThreshold) < 0 {
+			err = fmt.Errorf("miner %s does not meet the minimum balance threshold: stake %v", minerAddress.Hex(), balance)
+		}
+		if !v.record(checkStake, minerAddress, err) {
+			return v
+		}
+
+		// 2. 从签名和原像恢复公钥, 3. 检查从签名中恢复的地址是否匹配
+		sigPublicKey, err := crypto.SigToPub(accounts.ZkscamVoteDigest(chain.Config(), header.Number, header.ZkscamHash), header.Signatures[i])
+		if err != nil {
+			err = fmt.Errorf("error recovering public key for miner %s: %v", minerAddress.Hex(), err)
+		} else if recoveredAddr := crypto.PubkeyToAddress(*sigPublicKey); recoveredAddr != *minerAddress {
+			err = fmt.Errorf("invalid signature: recovered address %s does not match miner address %s", recoveredAddr.Hex(), minerAddress.Hex())
+		}
+		if !v.record(checkSignature, minerAddress, err) {
+			return v
+		}
+
+		// 4. 验证 BLS 公钥和授权签名
+		passSigBLSKey, err := single.VerifyAnyLengthMessageSignatureWithAddress(header.BLSPublicKeys[i], header.AuthBLSSignatures[i], *minerAddress)
+		if err != nil {
+			err = fmt.Errorf("error verifying BLS key signature for miner %s: %v", minerAddress.Hex(), err)
+		} else if !passSigBLSKey {
+			err = fmt.Errorf("invalid BLS key signature for miner %s", minerAddress.Hex())
+		}
+		if !v.record(checkBLSAuthorization, minerAddress, err) {
+			return v
+		}
+
+		// 5. 增加票数计数
+		if balance != nil {
+			votesCount.Add(votesCount, balance)
+		}
+		pubKeys = append(pubKeys, header.BLSPublicKeys[i])
+	}
+
+	// 6. 验证当前区块票数是否匹配
+	var err error
+	if header.Votes == nil {
+		err = fmt.Errorf("votes nil")
+	} else if header.Votes.Cmp(votesCount) != 0 {
+		err = fmt.Errorf("votes count mismatch: header has %d votes, but calculated %d votes", header.Votes, votesCount)
+	}
+	if !v.record(checkVotes, nil, err) {
+		return v
+	}
+
+	// 7. 验证 `TotalVotes` 是否正确, 优先从缓存中读取父区块
+	parentHeader := c.headerCache.Get(header.ParentHash)
+	if parentHeader == nil {
+		parentHeader = chain.GetHeader(header.ParentHash, header.Number.Uint64()-1)
+	}
+	err = nil
+	switch {
+	case parentHeader == nil:
+		err = fmt.Errorf("unable to retrieve parent header for block %d", header.Number.Uint64()-1)
+	case header.TotalVotes == nil || parentHeader.TotalVotes == nil:
+		err = fmt.Errorf("total votes nil")
+	default:
+		expectedTotalVotes := new(big.Int).Add(votesCount, parentHeader.TotalVotes)
+		if header.TotalVotes.Cmp(expectedTotalVotes) != 0 {
+			err = fmt.Errorf("total votes mismatch: header has %d total votes, but expected %d total votes", header.TotalVotes, expectedTotalVotes)
+		}
+	}
+	if !v.record(checkTotalVotes, nil, err) {
+		return v
+	}
+
+	// 8. 调用 single 包中的 BLS 聚合签名验证函数
+	isValid, err := single.BLSAggregateVerify(header.ZkscamHash.Bytes(), header.AggregatedSignature, pubKeys)
+	if err != nil || !isValid {
+		err = fmt.Errorf("aggregated signature verification failed: %v", err)
+	}
+	v.record(checkAggregatedSignature, nil, err)
+
+	v.Valid = v.err == nil
+	return v
+}
+
+// verifyLegacyVotePayload checks that a bounded number of miners voted on a
+// header, each with a signature, a BLS key and its authorization, as done before
+// the strict vote payload fork. Surplus entries are ignored.
+func verifyLegacyVotePayload(header *types.Header) error {
+	miners := len(header.MinerAddresses)
+	if miners > MaxVoteMiners {
+		return fmt.Errorf("too many miners voted: have %d, max %d", miners, MaxVoteMiners)
+	}
+	if len(header.Signatures) < miners || len(header.BLSPublicKeys) < miners || len(header.AuthBLSSignatures) < miners {
+		return fmt.Errorf("incomplete vote payload: %d miners, %d signatures, %d BLS keys, %d BLS authorizations",
+			miners, len(header.Signatures), len(header.BLSPublicKeys), len(header.AuthBLSSignatures))
+	}
+	return nil
+}
+
+// verifyVotePayload checks the structure of the vote fields of a header before
+// any of them is interpreted, from the strict vote payload fork on: a bounded
+// number of miners voting once each with a signature, a BLS key and its
+// authorization, and the vote tallies being present.
+func verifyVotePayload(header *types.Header) error {
+	miners := len(header.MinerAddresses)
+	if miners == 0 {
+		return errors.New("no miners voted")
+	}
+	if miners > MaxVoteMiners {
+		return fmt.Errorf("too many miners voted: have %d, max %d", miners, MaxVoteMiners)
+	}
+	if len(header.Signatures) != miners || len(header.BLSPublicKeys) != miners || len(header.AuthBLSSignatures) != miners {
+		return fmt.Errorf("malformed vote payload: %d miners, %d signatures, %d BLS keys, %d BLS authorizations",
+			miners, len(header.Signatures), len(header.BLSPublicKeys), len(header.AuthBLSSignatures))
+	}
+	seen := make(map[common.Address]struct{}, miners)
+	for i, miner := range header.MinerAddresses {
+		if _, ok := seen[miner]; ok {
+			return fmt.Errorf("miner %s voted more than once", miner.Hex())
+		}
+		seen[miner] = struct{}{}
+
+		if len(header.Signatures[i]) != crypto.SignatureLength {
+			return fmt.Errorf("invalid signature length %d for miner %s", len(header.Signatures[i]), miner.Hex())
+		}
+		if len(header.AuthBLSSignatures[i]) != crypto.SignatureLength {
+			return fmt.Errorf("invalid BLS authorization length %d for miner %s", len(header.AuthBLSSignatures[i]), miner.Hex())
+		}
+		if len(header.BLSPublicKeys[i]) == 0 {
+			return fmt.Errorf("missing BLS key for miner %s", miner.Hex())
+		}
+	}
+	if len(header.AggregatedSignature) == 0 {
+		return errors.New("missing aggregated signature")
+	}
+	if header.Votes == nil || header.TotalVotes == nil {
+		return errors.New("missing vote tallies")
+	}
+	return nil
+}
+
+// MinerStake returns the stake of a miner counting for its vote at the given
+// height, which is its balance at the lookback block.
+func (c *Clique) MinerStake(miner common.Address, number *big.Int) (*big.Int, error) {
+	balance, err := c.erc20.BalanceOfAt(miner, new(big.Int).Sub(number, big.NewInt(miner_waiting_block)))
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving ERC20 balance for miner %s: %v", miner.Hex(), err)
+	}
+	return balance, nil
+}
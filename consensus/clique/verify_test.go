@@ -0,0 +1,564 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package clique
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/contracts"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// stakeService serves the stake token balances read by the engine.
+type stakeService struct {
+	stakes map[common.Address]*big.Int
+}
+
+// Call answers balanceOf calls of the stake token, whatever the block.
+func (s *stakeService) Call(args map[string]interface{}, number string) (hexutil.Bytes, error) {
+	input, _ := args["input"].(string)
+	data, err := hexutil.Decode(input)
+	if err != nil || len(data) != 36 {
+		return nil, errors.New("not a balanceOf call")
+	}
+	stake := s.stakes[common.BytesToAddress(data[4:])]
+	if stake == nil {
+		stake = new(big.Int)
+	}
+	return common.LeftPadBytes(stake.Bytes(), 32), nil
+}
+
+// newTestVoters creates voters with the given stakes, served to the engine.
+func newTestVoters(t *testing.T, stakes ...int64) []*core.Voter {
+	service := &stakeService{stakes: make(map[common.Address]*big.Int)}
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", service); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(server.Stop)
+	contracts.UseClient(rpc.DialInProc(server))
+
+	voters := make([]*core.Voter, len(stakes))
+	for i, stake := range stakes {
+		key, _ := crypto.GenerateKey()
+		voter, err := core.NewVoter(key, big.NewInt(stake))
+		if err != nil {
+			t.Fatalf("failed to create voter: %v", err)
+		}
+		service.stakes[voter.BLS.Address] = voter.Stake
+		voters[i] = voter
+	}
+	return voters
+}
+
+// Tests that blocks voted by the chain generator pass the vote verification and
+// that the fork choice prefers the fork carrying the most votes.
+func TestGeneratedVotes(t *testing.T) {
+	var (
+		voters = newTestVoters(t, 200_000, 300_000, 1_000_000)
+		config = *params.AllCliqueProtocolChanges
+		engine = New(config.Clique, rawdb.NewMemoryDatabase())
+	)
+	genesis := &core.Genesis{
+		Config:     &config,
+		BaseFee:    big.NewInt(params.InitialBaseFee),
+		ExtraData:  make([]byte, extraVanity+extraSeal),
+		TotalVotes: new(big.Int),
+	}
+	generate := func(blocks int, voters ...*core.Voter) []*types.Block {
+		_, chain, _ := core.GenerateChainWithGenesis(genesis, engine, blocks, func(i int, b *core.BlockGen) {
+			b.SetExtra(make([]byte, extraVanity+extraSeal))
+			b.SetVoters(voters...)
+		})
+		return chain
+	}
+	// The first two voters outvote the last one per block, but not per fork
+	main, fork := generate(3, voters[0], voters[1]), generate(2, voters[2])
+
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, genesis, nil, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(main); err != nil {
+		t.Fatalf("failed to insert voted chain: %v", err)
+	}
+	for _, block := range main {
+		if v := engine.VerifyVotes(chain, block.Header()); !v.Valid {
+			t.Fatalf("block %d: invalid votes: %v", block.NumberU64(), v.err)
+		}
+	}
+	if want := big.NewInt(3 * 500_000); main[2].Header().TotalVotes.Cmp(want) != 0 {
+		t.Fatalf("total votes mismatch: have %v, want %v", main[2].Header().TotalVotes, want)
+	}
+	forkChoice := core.NewForkChoice(chain, nil)
+	if reorg, err := forkChoice.ReorgNeeded(main[2].Header(), fork[1].Header()); err != nil || !reorg {
+		t.Fatalf("heavier fork not chosen: reorg %v, err %v", reorg, err)
+	}
+	if reorg, err := forkChoice.ReorgNeeded(fork[1].Header(), main[2].Header()); err != nil || reorg {
+		t.Fatalf("lighter fork chosen: reorg %v, err %v", reorg, err)
+	}
+	if _, err := chain.InsertChain(fork); err != nil {
+		t.Fatalf("failed to insert fork: %v", err)
+	}
+	if head := chain.CurrentBlock().Hash(); head != fork[1].Hash() {
+		t.Fatalf("head mismatch: have %x, want fork head %x", head, fork[1].Hash())
+	}
+}
+
+// Tests that headers with structurally malformed votes are rejected before any
+// of the votes is interpreted.
+func TestMalformedVotes(t *testing.T) {
+	var (
+		voters = newTestVoters(t, 200_000, 300_000)
+		config = *params.AllCliqueProtocolChanges
+		engine = New(config.Clique, rawdb.NewMemoryDatabase())
+	)
+	genesis := &core.Genesis{
+		Config:     &config,
+		BaseFee:    big.NewInt(params.InitialBaseFee),
+		ExtraData:  make([]byte, extraVanity+extraSeal),
+		TotalVotes: new(big.Int),
+	}
+	_, blocks, _ := core.GenerateChainWithGenesis(genesis, engine, 1, func(i int, b *core.BlockGen) {
+		b.SetExtra(make([]byte, extraVanity+extraSeal))
+		b.SetVoters(voters...)
+	})
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, genesis, nil, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if v := engine.VerifyVotes(chain, blocks[0].Header()); !v.Valid {
+		t.Fatalf("valid votes rejected: %v", v.err)
+	}
+	tests := map[string]func(h *types.Header){
+		"no miners": func(h *types.Header) {
+			h.MinerAddresses, h.Signatures, h.BLSPublicKeys, h.AuthBLSSignatures = nil, nil, nil, nil
+		},
+		"missing signature":     func(h *types.Header) { h.Signatures = h.Signatures[:1] },
+		"extra signature":       func(h *types.Header) { h.Signatures = append(h.Signatures, h.Signatures[0]) },
+		"missing BLS key":       func(h *types.Header) { h.BLSPublicKeys = h.BLSPublicKeys[:1] },
+		"missing authorization": func(h *types.Header) { h.AuthBLSSignatures = h.AuthBLSSignatures[:1] },
+		"short signature":       func(h *types.Header) { h.Signatures = [][]byte{h.Signatures[0], h.Signatures[1][:64]} },
+		"empty BLS key":         func(h *types.Header) { h.BLSPublicKeys = [][]byte{h.BLSPublicKeys[0], nil} },
+		"duplicate miner": func(h *types.Header) {
+			h.MinerAddresses = []common.Address{h.MinerAddresses[0], h.MinerAddresses[0]}
+		},
+		"too many miners": func(h *types.Header) {
+			for len(h.MinerAddresses) <= MaxVoteMiners {
+				h.MinerAddresses = append(h.MinerAddresses, common.BigToAddress(big.NewInt(int64(len(h.MinerAddresses)))))
+				h.Signatures = append(h.Signatures, h.Signatures[0])
+				h.BLSPublicKeys = append(h.BLSPublicKeys, h.BLSPublicKeys[0])
+				h.AuthBLSSignatures = append(h.AuthBLSSignatures, h.AuthBLSSignatures[0])
+			}
+		},
+		"no aggregated signature": func(h *types.Header) { h.AggregatedSignature = nil },
+		"no votes":                func(h *types.Header) { h.Votes = nil },
+		"no total votes":          func(h *types.Header) { h.TotalVotes = nil },
+	}
+	for name, mutate := range tests {
+		header := types.CopyHeader(blocks[0].Header())
+		mutate(header)
+
+		v := engine.VerifyVotes(chain, header)
+		if v.Valid {
+			t.Errorf("%s: malformed votes accepted", name)
+			continue
+		}
+		if check := v.Checks[0]; check.Name != checkPayload || check.Passed {
+			t.Errorf("%s: payload check passed, failing %v", name, v.err)
+		}
+	}
+}
+
+// Tests that the vote verification of consensus stops at the first failed
+// check, while the full verification reports every check.
+func TestVerifyVotesEarlyExit(t *testing.T) {
+	var (
+		voters = newTestVoters(t, 200_000, 300_000)
+		config = *params.AllCliqueProtocolChanges
+		engine = New(config.Clique, rawdb.NewMemoryDatabase())
+	)
+	genesis := &core.Genesis{
+		Config:     &config,
+		BaseFee:    big.NewInt(params.InitialBaseFee),
+		ExtraData:  make([]byte, extraVanity+extraSeal),
+		TotalVotes: new(big.Int),
+	}
+	_, blocks, _ := core.GenerateChainWithGenesis(genesis, engine, 1, func(i int, b *core.BlockGen) {
+		b.SetExtra(make([]byte, extraVanity+extraSeal))
+		b.SetVoters(voters...)
+	})
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, genesis, nil, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	// Swap the signatures of the miners, failing the signature check of both
+	header := types.CopyHeader(blocks[0].Header())
+	header.Signatures = [][]byte{header.Signatures[1], header.Signatures[0]}
+
+	full := engine.verifyVotes(chain, header, true)
+	if full.Valid {
+		t.Fatalf("swapped signatures accepted")
+	}
+	if have, want := len(full.Checks), 1+3*len(voters)+3; have != want {
+		t.Errorf("full verification check count mismatch: have %d, want %d", have, want)
+	}
+	early := engine.verifyVotes(chain, header, false)
+	if early.Valid {
+		t.Fatalf("swapped signatures accepted")
+	}
+	if have, want := len(early.Checks), 3; have != want {
+		t.Fatalf("early verification check count mismatch: have %d, want %d", have, want)
+	}
+	if last := early.Checks[len(early.Checks)-1]; last.Name != checkSignature || last.Passed {
+		t.Errorf("early verification stopped at %s (passed %v), want failed %s", last.Name, last.Passed, checkSignature)
+	}
+	if early.err.Error() != full.err.Error() {
+		t.Errorf("first failure mismatch: have %v, want %v", early.err, full.err)
+	}
+}
+
+// Tests that a clique chain past Shanghai and Cancun runs the opcodes of the
+// forks, and that it accepts the zero or absent forms of their header fields
+// only.
+func TestCancunBlocks(t *testing.T) {
+	var (
+		voters = newTestVoters(t, 200_000, 300_000)
+		key, _ = crypto.GenerateKey()
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		config = *params.AllCliqueProtocolChanges
+		engine = New(config.Clique, rawdb.NewMemoryDatabase())
+	)
+	config.ShanghaiTime, config.CancunTime = new(uint64), new(uint64)
+
+	genesis := &core.Genesis{
+		Config:     &config,
+		BaseFee:    big.NewInt(params.InitialBaseFee),
+		ExtraData:  make([]byte, extraVanity+extraSeal),
+		TotalVotes: new(big.Int),
+		Alloc:      types.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+	}
+	// Deploy a contract whose init code stores 0x2a in transient storage with
+	// PUSH0 and TSTORE, reads it back with TLOAD and MCOPYs it before returning
+	// it as the contract code.
+	initcode := common.FromHex("602a5f5d5f5c5f5260205f60205e6001603ff3")
+
+	// Deploy a second one storing the value of opcode 0x44, which remains
+	// DIFFICULTY as the chain is not merged.
+	difficulty := common.FromHex("445f5500")
+
+	_, blocks, receipts := core.GenerateChainWithGenesis(genesis, engine, 2, func(i int, b *core.BlockGen) {
+		b.SetExtra(make([]byte, extraVanity+extraSeal))
+		b.SetVoters(voters...)
+		if i == 0 {
+			tx := types.MustSignNewTx(key, types.LatestSigner(&config), &types.DynamicFeeTx{
+				ChainID:   config.ChainID,
+				Gas:       100_000,
+				GasFeeCap: b.BaseFee(),
+				Data:      initcode,
+			})
+			b.AddTx(tx)
+		}
+		if i == 1 {
+			tx := types.MustSignNewTx(key, types.LatestSigner(&config), &types.DynamicFeeTx{
+				ChainID:   config.ChainID,
+				Nonce:     1,
+				Gas:       100_000,
+				GasFeeCap: b.BaseFee(),
+				Data:      difficulty,
+			})
+			b.AddTx(tx)
+		}
+	})
+	for i := range receipts {
+		if status := receipts[i][0].Status; status != types.ReceiptStatusSuccessful {
+			t.Fatalf("contract creation %d failed", i)
+		}
+	}
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, genesis, nil, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	state, err := chain.State()
+	if err != nil {
+		t.Fatalf("failed to retrieve state: %v", err)
+	}
+	if code := state.GetCode(crypto.CreateAddress(addr, 0)); !bytes.Equal(code, []byte{0x2a}) {
+		t.Fatalf("contract code mismatch: have %x, want 2a", code)
+	}
+	want := common.BigToHash(blocks[1].Difficulty())
+	if have := state.GetState(crypto.CreateAddress(addr, 1), common.Hash{}); have != want || want == (common.Hash{}) {
+		t.Fatalf("difficulty mismatch: have %x, want %x", have, want)
+	}
+
+	// Check the forms of the fork fields accepted by the header verification
+	var (
+		one  = uint64(1)
+		root = common.Hash{0x1}
+	)
+	tests := []struct {
+		name   string
+		mutate func(h *types.Header)
+		valid  bool
+	}{
+		{"zero fields", func(h *types.Header) {}, true},
+		{"absent fields", func(h *types.Header) {
+			h.WithdrawalsHash, h.BlobGasUsed, h.ExcessBlobGas, h.ParentBeaconRoot = nil, nil, nil, nil
+		}, true},
+		{"absent beacon root", func(h *types.Header) { h.ParentBeaconRoot = nil }, true},
+		{"withdrawals", func(h *types.Header) { h.WithdrawalsHash = &root }, false},
+		{"blob gas used", func(h *types.Header) { h.BlobGasUsed = &one }, false},
+		{"excess blob gas", func(h *types.Header) { h.ExcessBlobGas = &one }, false},
+		{"beacon root", func(h *types.Header) { h.ParentBeaconRoot = &root }, false},
+		{"absent withdrawals hash", func(h *types.Header) { h.WithdrawalsHash = nil }, false},
+		{"absent blob gas used", func(h *types.Header) { h.BlobGasUsed = nil }, false},
+		{"absent blob gas fields", func(h *types.Header) { h.BlobGasUsed, h.ExcessBlobGas = nil, nil }, false},
+	}
+	for _, tt := range tests {
+		header := types.CopyHeader(blocks[0].Header())
+		tt.mutate(header)
+		if err := verifyForkFields(&config, header); (err == nil) != tt.valid {
+			t.Errorf("%s: verification mismatch: have %v, want valid %v", tt.name, err, tt.valid)
+		}
+	}
+	// Before the forks, the fields must be absent
+	config.ShanghaiTime, config.CancunTime = nil, nil
+	header := types.CopyHeader(blocks[0].Header())
+	if err := verifyForkFields(&config, header); err == nil {
+		t.Error("fork fields accepted before the forks")
+	}
+	header.WithdrawalsHash, header.BlobGasUsed, header.ExcessBlobGas, header.ParentBeaconRoot = nil, nil, nil, nil
+	if err := verifyForkFields(&config, header); err != nil {
+		t.Errorf("absent fork fields rejected before the forks: %v", err)
+	}
+}
+
+// Tests that a clique chain without Shanghai and Cancun timestamps runs neither
+// the opcodes of the forks nor prices blob gas.
+func TestUnscheduledCancunBlocks(t *testing.T) {
+	var (
+		voters = newTestVoters(t, 200_000, 300_000)
+		key, _ = crypto.GenerateKey()
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		config = *params.AllCliqueProtocolChanges
+		engine = New(config.Clique, rawdb.NewMemoryDatabase())
+	)
+	genesis := &core.Genesis{
+		Config:     &config,
+		BaseFee:    big.NewInt(params.InitialBaseFee),
+		ExtraData:  make([]byte, extraVanity+extraSeal),
+		TotalVotes: new(big.Int),
+		Alloc:      types.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+	}
+	// Deploy the init code of TestCancunBlocks, starting with a PUSH0
+	initcode := common.FromHex("602a5f5d5f5c5f5260205f60205e6001603ff3")
+
+	_, blocks, receipts := core.GenerateChainWithGenesis(genesis, engine, 1, func(i int, b *core.BlockGen) {
+		b.SetExtra(make([]byte, extraVanity+extraSeal))
+		b.SetVoters(voters...)
+		tx := types.MustSignNewTx(key, types.LatestSigner(&config), &types.DynamicFeeTx{
+			ChainID:   config.ChainID,
+			Gas:       100_000,
+			GasFeeCap: b.BaseFee(),
+			Data:      initcode,
+		})
+		b.AddTx(tx)
+	})
+	if status := receipts[0][0].Status; status != types.ReceiptStatusFailed {
+		t.Fatalf("contract creation succeeded before Shanghai")
+	}
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, genesis, nil, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	header := blocks[0].Header()
+	if rules := config.Rules(header.Number, false, header.Time); rules.IsShanghai || rules.IsCancun {
+		t.Errorf("unscheduled forks active: shanghai %v, cancun %v", rules.IsShanghai, rules.IsCancun)
+	}
+	if fee := core.NewEVMBlockContext(header, chain, nil).BlobBaseFee; fee != nil {
+		t.Errorf("blob gas priced before Cancun: %v", fee)
+	}
+}
+
+// Tests that the seal hash covers the fork fields and survives the RLP round
+// trip of the header.
+func TestSealHashForkFields(t *testing.T) {
+	zero := uint64(0)
+	header := &types.Header{
+		Number:     big.NewInt(1),
+		Difficulty: big.NewInt(1),
+		Extra:      make([]byte, extraVanity+extraSeal),
+		BaseFee:    big.NewInt(params.InitialBaseFee),
+	}
+	london := SealHash(header)
+
+	header.WithdrawalsHash = &types.EmptyWithdrawalsHash
+	shanghai := SealHash(header)
+
+	header.BlobGasUsed, header.ExcessBlobGas, header.ParentBeaconRoot = &zero, &zero, new(common.Hash)
+	cancun := SealHash(header)
+
+	if london == shanghai || shanghai == cancun || london == cancun {
+		t.Fatalf("seal hash ignores the fork fields: %x %x %x", london, shanghai, cancun)
+	}
+	blob, err := rlp.EncodeToBytes(header)
+	if err != nil {
+		t.Fatalf("failed to encode header: %v", err)
+	}
+	var decoded types.Header
+	if err := rlp.DecodeBytes(blob, &decoded); err != nil {
+		t.Fatalf("failed to decode header: %v", err)
+	}
+	if hash := SealHash(&decoded); hash != cancun {
+		t.Errorf("seal hash mismatch after round trip: have %x, want %x", hash, cancun)
+	}
+}
+
+// Tests that the strict vote payload checks only apply from their fork on,
+// earlier headers being checked as they were when sealed apart from the bound
+// on the number of miners.
+func TestVotePayloadFork(t *testing.T) {
+	var (
+		voters = newTestVoters(t, 200_000, 300_000)
+		config = *params.AllCliqueProtocolChanges
+		engine = New(config.Clique, rawdb.NewMemoryDatabase())
+	)
+	config.ZkscamVotePayloadBlock = big.NewInt(2)
+
+	genesis := &core.Genesis{
+		Config:     &config,
+		BaseFee:    big.NewInt(params.InitialBaseFee),
+		ExtraData:  make([]byte, extraVanity+extraSeal),
+		TotalVotes: new(big.Int),
+	}
+	_, blocks, _ := core.GenerateChainWithGenesis(genesis, engine, 2, func(i int, b *core.BlockGen) {
+		b.SetExtra(make([]byte, extraVanity+extraSeal))
+		b.SetVoters(voters...)
+	})
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, genesis, nil, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks[:1]); err != nil {
+		t.Fatalf("failed to insert block: %v", err)
+	}
+	// A surplus signature is ignored before the fork and rejected after it
+	extra := func(h *types.Header) *types.Header {
+		h = types.CopyHeader(h)
+		h.Signatures = append(h.Signatures, h.Signatures[0])
+		return h
+	}
+	if v := engine.VerifyVotes(chain, extra(blocks[0].Header())); !v.Valid {
+		t.Errorf("pre-fork surplus signature rejected: %v", v.err)
+	}
+	v := engine.VerifyVotes(chain, extra(blocks[1].Header()))
+	if v.Valid {
+		t.Fatalf("post-fork surplus signature accepted")
+	}
+	if check := v.Checks[0]; check.Name != checkPayload || check.Passed {
+		t.Errorf("post-fork payload check passed, failing %v", v.err)
+	}
+	// The number of miners is bounded before the fork too
+	crowded := types.CopyHeader(blocks[0].Header())
+	for len(crowded.MinerAddresses) <= MaxVoteMiners {
+		crowded.MinerAddresses = append(crowded.MinerAddresses, common.BigToAddress(big.NewInt(int64(len(crowded.MinerAddresses)))))
+	}
+	v = engine.VerifyVotes(chain, crowded)
+	if v.Valid {
+		t.Fatalf("pre-fork crowded votes accepted")
+	}
+	if len(v.Checks) != 1 || v.Checks[0].Name != checkPayload {
+		t.Errorf("pre-fork crowded votes not rejected by the payload check: %v", v.err)
+	}
+}
+
+// Tests that the rewards of an applied block are shared by the stakes of the
+// voters of its parent, and that incomplete blocks are refused.
+func TestAppliedRewards(t *testing.T) {
+	var (
+		voters = newTestVoters(t, 100_000, 300_000)
+		engine = New(params.AllCliqueProtocolChanges.Clique, rawdb.NewMemoryDatabase())
+		parent = &types.Header{
+			Number:         big.NewInt(20),
+			MinerAddresses: []common.Address{voters[0].BLS.Address, voters[1].BLS.Address},
+		}
+		tx       = types.NewTx(&types.LegacyTx{Gas: params.TxGas, GasPrice: big.NewInt(10)})
+		block    = types.NewBlockWithHeader(&types.Header{Number: big.NewInt(21)}).WithBody([]*types.Transaction{tx}, nil)
+		receipts = types.Receipts{{GasUsed: params.TxGas}}
+	)
+	rewards, err := engine.AppliedRewards(block, parent, receipts)
+	if err != nil {
+		t.Fatalf("failed to compute rewards: %v", err)
+	}
+	want := []Reward{
+		{Address: buybackAddress, Amount: big.NewInt(42_000)},
+		{Address: voters[0].BLS.Address, Amount: big.NewInt(42_000)},
+		{Address: voters[1].BLS.Address, Amount: big.NewInt(126_000)},
+	}
+	if len(rewards) != len(want) {
+		t.Fatalf("reward count mismatch: have %d, want %d", len(rewards), len(want))
+	}
+	for i := range want {
+		if rewards[i].Address != want[i].Address || rewards[i].Amount.Cmp(want[i].Amount) != 0 {
+			t.Errorf("reward %d mismatch: have %v %v, want %v %v", i, rewards[i].Address, rewards[i].Amount, want[i].Address, want[i].Amount)
+		}
+	}
+	// Empty blocks credit nothing, but still need their parent
+	empty := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(21)})
+	if rewards, err := engine.AppliedRewards(empty, parent, nil); err != nil || rewards != nil {
+		t.Errorf("empty block rewards mismatch: %v, %v", rewards, err)
+	}
+	if _, err := engine.AppliedRewards(empty, nil, nil); err == nil {
+		t.Errorf("rewards computed without parent")
+	}
+	if _, err := engine.AppliedRewards(block, parent, nil); err == nil {
+		t.Errorf("rewards computed without receipts")
+	}
+	if rewards, err := engine.AppliedRewards(types.NewBlockWithHeader(&types.Header{Number: common.Big0}), nil, nil); err != nil || rewards != nil {
+		t.Errorf("genesis rewards mismatch: %v, %v", rewards, err)
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package clique
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	eth2 "github.com/ethereum/go-ethereum/eth/protocols/eth"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// VoteHistoryDB is the name of the database, inside the node instance
+// directory, recording the votes signed by local miners.
+const VoteHistoryDB = "votehistory"
+
+// voteHistoryVersion is the version of the vote history interchange format.
+const voteHistoryVersion = 1
+
+// voteHistoryLimit is the number of heights below the highest vote of a miner
+// for which its votes are retained. Older votes are pruned, and new votes at
+// pruned heights are refused, as they could conflict with a forgotten one.
+const voteHistoryLimit = 1024
+
+var (
+	// voteHistoryPrefix + miner address + height (uint64 big endian) -> RLP vote
+	voteHistoryPrefix = []byte("zkscam-vote-")
+
+	// voteHistoryHeadPrefix + miner address -> highest voted height (uint64 big endian)
+	voteHistoryHeadPrefix = []byte("zkscam-votehead-")
+)
+
+var (
+	// ErrConflictingVote is returned if a vote is recorded for a height at which
+	// the miner already signed a different hash.
+	ErrConflictingVote = errors.New("conflicting vote")
+
+	// ErrPrunedVote is returned if a vote is recorded for a height whose votes
+	// were already pruned from the history.
+	ErrPrunedVote = errors.New("vote below pruned history")
+)
+
+// VoteHistory is the persistent record of the votes signed by local miners. It
+// makes sure a miner never signs two different hashes at the same height, even
+// across restarts, and allows re-broadcasting a vote that was already signed.
+type VoteHistory struct {
+	db   ethdb.KeyValueStore
+	lock sync.Mutex // Serializes the conflict check and the write of a vote
+}
+
+// voteHistoryFile is the interchange format for migrating the vote history of
+// a miner to new hardware.
+type voteHistoryFile struct {
+	Version int         `json:"version"`
+	Votes   []eth2.Vote `json:"votes"`
+}
+
+// NewVoteHistory creates a vote history on top of the given database.
+func NewVoteHistory(db ethdb.KeyValueStore) *VoteHistory {
+	return &VoteHistory{db: db}
+}
+
+func voteHistoryKey(miner common.Address, number uint64) []byte {
+	key := make([]byte, 0, len(voteHistoryPrefix)+common.AddressLength+8)
+	key = append(key, voteHistoryPrefix...)
+	key = append(key, miner.Bytes()...)
+	return binary.BigEndian.AppendUint64(key, number)
+}
+
+func voteHistoryHeadKey(miner common.Address) []byte {
+	return append(append([]byte{}, voteHistoryHeadPrefix...), miner.Bytes()...)
+}
+
+// head returns the highest height the miner voted at, and whether it voted at all.
+func (h *VoteHistory) head(miner common.Address) (uint64, bool, error) {
+	key := voteHistoryHeadKey(miner)
+	if has, err := h.db.Has(key); err != nil || !has {
+		return 0, false, err
+	}
+	blob, err := h.db.Get(key)
+	if err != nil {
+		return 0, false, err
+	}
+	if len(blob) != 8 {
+		return 0, false, fmt.Errorf("corrupt vote history head of %s", miner.Hex())
+	}
+	return binary.BigEndian.Uint64(blob), true, nil
+}
+
+// Get retrieves the vote the miner signed at the given height, or nil if the
+// miner did not vote at that height yet.
+func (h *VoteHistory) Get(miner common.Address, number uint64) (*eth2.Vote, error) {
+	key := voteHistoryKey(miner, number)
+	if has, err := h.db.Has(key); err != nil || !has {
+		return nil, err
+	}
+	blob, err := h.db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	vote := new(eth2.Vote)
+	if err := rlp.DecodeBytes(blob, vote); err != nil {
+		return nil, err
+	}
+	return vote, nil
+}
+
+// Put records a signed vote. Recording the same vote again is a noop, a vote
+// for a different hash at an already voted height is refused.
+func (h *VoteHistory) Put(vote *eth2.Vote) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	return h.put(vote)
+}
+
+func (h *VoteHistory) put(vote *eth2.Vote) error {
+	if vote.Number == nil || !vote.Number.IsUint64() {
+		return errors.New("invalid vote height")
+	}
+	number := vote.Number.Uint64()
+	prev, err := h.Get(vote.MinerAddress, number)
+	if err != nil {
+		return err
+	}
+	if prev != nil {
+		if prev.BlockHash != vote.BlockHash {
+			return fmt.Errorf("%w: miner %s signed %s at height %d", ErrConflictingVote, vote.MinerAddress.Hex(), prev.BlockHash.Hex(), number)
+		}
+		return nil
+	}
+	head, voted, err := h.head(vote.MinerAddress)
+	if err != nil {
+		return err
+	}
+	if voted && number+voteHistoryLimit <= head {
+		return fmt.Errorf("%w: miner %s voted up to height %d, refusing height %d", ErrPrunedVote, vote.MinerAddress.Hex(), head, number)
+	}
+	blob, err := rlp.EncodeToBytes(vote)
+	if err != nil {
+		return err
+	}
+	if err := h.db.Put(voteHistoryKey(vote.MinerAddress, number), blob); err != nil {
+		return err
+	}
+	if voted && number <= head {
+		return nil
+	}
+	if err := h.db.Put(voteHistoryHeadKey(vote.MinerAddress), binary.BigEndian.AppendUint64(nil, number)); err != nil {
+		return err
+	}
+	return h.prune(vote.MinerAddress, number)
+}
+
+// prune deletes the votes of the miner that fell out of the retained window
+// below the given head height.
+func (h *VoteHistory) prune(miner common.Address, head uint64) error {
+	if head < voteHistoryLimit {
+		return nil
+	}
+	var (
+		prefix = append(append([]byte{}, voteHistoryPrefix...), miner.Bytes()...)
+		limit  = voteHistoryKey(miner, head-voteHistoryLimit+1)
+		it     = h.db.NewIterator(prefix, nil)
+		batch  = h.db.NewBatch()
+	)
+	defer it.Release()
+
+	for it.Next() && bytes.Compare(it.Key(), limit) < 0 {
+		if err := batch.Delete(common.CopyBytes(it.Key())); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// Export writes the whole vote history in the JSON interchange format.
+func (h *VoteHistory) Export(w io.Writer) (int, error) {
+	it := h.db.NewIterator(voteHistoryPrefix, nil)
+	defer it.Release()
+
+	file := voteHistoryFile{Version: voteHistoryVersion, Votes: []eth2.Vote{}}
+	for it.Next() {
+		var vote eth2.Vote
+		if err := rlp.DecodeBytes(it.Value(), &vote); err != nil {
+			return 0, fmt.Errorf("corrupt vote %x: %v", it.Key(), err)
+		}
+		file.Votes = append(file.Votes, vote)
+	}
+	if err := it.Error(); err != nil {
+		return 0, err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return len(file.Votes), enc.Encode(file)
+}
+
+// Import merges a vote history in the JSON interchange format into the local
+// one. The import is aborted at the first vote conflicting with a local one,
+// votes imported up to that point are kept. Votes below the pruned part of the
+// local history are skipped, as the local history already refuses them.
+func (h *VoteHistory) Import(r io.Reader) (int, error) {
+	var file voteHistoryFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return 0, err
+	}
+	if file.Version != voteHistoryVersion {
+		return 0, fmt.Errorf("unsupported vote history version %d", file.Version)
+	}
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for i := range file.Votes {
+		if err := h.put(&file.Votes[i]); err != nil && !errors.Is(err, ErrPrunedVote) {
+			return i, err
+		}
+	}
+	return len(file.Votes), nil
+}
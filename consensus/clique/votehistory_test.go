@@ -0,0 +1,177 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package clique
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	eth2 "github.com/ethereum/go-ethereum/eth/protocols/eth"
+	"github.com/ethereum/go-ethereum/params"
+	single "github.com/ethereum/go-ethereum/singleton"
+)
+
+func testVote(miner common.Address, number int64, hash byte) *eth2.Vote {
+	return &eth2.Vote{
+		Number:       big.NewInt(number),
+		MinerAddress: miner,
+		BlockHash:    common.Hash{hash},
+		Signature:    []byte{hash, 1},
+		BLSSignature: []byte{hash, 2},
+	}
+}
+
+func TestVoteHistoryConflicts(t *testing.T) {
+	history := NewVoteHistory(rawdb.NewMemoryDatabase())
+	miner := common.Address{0x01}
+
+	if vote, err := history.Get(miner, 1); vote != nil || err != nil {
+		t.Fatalf("unexpected vote in empty history: %v, %v", vote, err)
+	}
+	if err := history.Put(testVote(miner, 1, 0xaa)); err != nil {
+		t.Fatalf("failed to record vote: %v", err)
+	}
+	if err := history.Put(testVote(miner, 1, 0xaa)); err != nil {
+		t.Fatalf("failed to re-record same vote: %v", err)
+	}
+	if err := history.Put(testVote(miner, 1, 0xbb)); !errors.Is(err, ErrConflictingVote) {
+		t.Fatalf("conflicting vote error mismatch: have %v, want %v", err, ErrConflictingVote)
+	}
+	// Other heights and other miners are independent
+	if err := history.Put(testVote(miner, 2, 0xbb)); err != nil {
+		t.Fatalf("failed to record vote at next height: %v", err)
+	}
+	if err := history.Put(testVote(common.Address{0x02}, 1, 0xbb)); err != nil {
+		t.Fatalf("failed to record vote of other miner: %v", err)
+	}
+	vote, err := history.Get(miner, 1)
+	if err != nil || vote == nil {
+		t.Fatalf("failed to read vote: %v", err)
+	}
+	if vote.BlockHash != (common.Hash{0xaa}) || !bytes.Equal(vote.BLSSignature, []byte{0xaa, 2}) {
+		t.Fatalf("recorded vote mismatch: %+v", vote)
+	}
+}
+
+func TestVoteHistoryExportImport(t *testing.T) {
+	src := NewVoteHistory(rawdb.NewMemoryDatabase())
+	miner := common.Address{0x01}
+	for i := int64(1); i <= 3; i++ {
+		if err := src.Put(testVote(miner, i, byte(i))); err != nil {
+			t.Fatalf("failed to record vote: %v", err)
+		}
+	}
+	var buf bytes.Buffer
+	if n, err := src.Export(&buf); err != nil || n != 3 {
+		t.Fatalf("export failed: %d votes, %v", n, err)
+	}
+	exported := buf.Bytes()
+
+	dst := NewVoteHistory(rawdb.NewMemoryDatabase())
+	if n, err := dst.Import(bytes.NewReader(exported)); err != nil || n != 3 {
+		t.Fatalf("import failed: %d votes, %v", n, err)
+	}
+	if vote, _ := dst.Get(miner, 2); vote == nil || vote.BlockHash != (common.Hash{2}) {
+		t.Fatalf("imported vote mismatch: %+v", vote)
+	}
+	// Importing into a history that voted differently must fail
+	conflicting := NewVoteHistory(rawdb.NewMemoryDatabase())
+	if err := conflicting.Put(testVote(miner, 2, 0xff)); err != nil {
+		t.Fatalf("failed to record vote: %v", err)
+	}
+	if _, err := conflicting.Import(bytes.NewReader(exported)); !errors.Is(err, ErrConflictingVote) {
+		t.Fatalf("conflicting import error mismatch: have %v, want %v", err, ErrConflictingVote)
+	}
+}
+
+func TestVoteHistoryPruning(t *testing.T) {
+	history := NewVoteHistory(rawdb.NewMemoryDatabase())
+	miner, other := common.Address{0x01}, common.Address{0x02}
+
+	if err := history.Put(testVote(other, 1, 0xaa)); err != nil {
+		t.Fatalf("failed to record vote: %v", err)
+	}
+	for i := int64(1); i <= voteHistoryLimit+10; i++ {
+		if err := history.Put(testVote(miner, i, byte(i))); err != nil {
+			t.Fatalf("failed to record vote %d: %v", i, err)
+		}
+	}
+	// Votes out of the retained window are pruned and can't be signed again
+	for i := uint64(1); i <= 10; i++ {
+		if vote, err := history.Get(miner, i); vote != nil || err != nil {
+			t.Fatalf("vote %d not pruned: %v, %v", i, vote, err)
+		}
+	}
+	if vote, _ := history.Get(miner, 11); vote == nil {
+		t.Fatalf("retained vote pruned")
+	}
+	if err := history.Put(testVote(miner, 10, 0xbb)); !errors.Is(err, ErrPrunedVote) {
+		t.Fatalf("pruned vote error mismatch: have %v, want %v", err, ErrPrunedVote)
+	}
+	// Filling gaps inside the window and other miners are not affected
+	if err := history.Put(testVote(miner, voteHistoryLimit+20, 0xbb)); err != nil {
+		t.Fatalf("failed to record vote: %v", err)
+	}
+	if err := history.Put(testVote(miner, voteHistoryLimit+15, 0xbb)); err != nil {
+		t.Fatalf("failed to record vote inside the window: %v", err)
+	}
+	if vote, _ := history.Get(other, 1); vote == nil {
+		t.Fatalf("vote of other miner pruned")
+	}
+	// The miner retains heights 21 to limit+10 and the two later votes, the
+	// other miner its single vote
+	var buf bytes.Buffer
+	if n, err := history.Export(&buf); err != nil || n != voteHistoryLimit-10+2+1 {
+		t.Fatalf("export failed: %d votes, %v", n, err)
+	}
+}
+
+// Tests that votes missing their BLS fields are neither returned nor recorded.
+func TestVoteWithoutBLSKey(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	single.SetPrivateKey(key)
+	single.SetBLSKey(nil)
+	defer single.SetBLSKey(nil)
+
+	history := NewVoteHistory(rawdb.NewMemoryDatabase())
+	c := New(&params.CliqueConfig{Period: 1, Epoch: 30000}, rawdb.NewMemoryDatabase())
+	c.SetVoteHistory(history)
+
+	block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(5)})
+	if _, err := c.vote(params.AllCliqueProtocolChanges, block, addr); !errors.Is(err, single.ErrNoBLSKey) {
+		t.Fatalf("missing BLS key error mismatch: have %v, want %v", err, single.ErrNoBLSKey)
+	}
+	if vote, err := history.Get(addr, 5); vote != nil || err != nil {
+		t.Fatalf("incomplete vote recorded: %v, %v", vote, err)
+	}
+	single.SetBLSKey(single.GenerateBLSKey(addr))
+	vote, err := c.vote(params.AllCliqueProtocolChanges, block, addr)
+	if err != nil {
+		t.Fatalf("failed to vote: %v", err)
+	}
+	if recorded, _ := history.Get(addr, 5); recorded == nil || !bytes.Equal(recorded.BLSSignature, vote.BLSSignature) {
+		t.Fatalf("recorded vote mismatch: %+v", recorded)
+	}
+}
@@ -5,60 +5,86 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/internal/consensuslog"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rpc"
-	"log"
 	"math/big"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // ERC20 represents a module for retrieving ERC20 balances
 type ERC20 struct {
-	Client *rpc.Client
+	// client serves the stake reads. It is swapped by UseClient while the
+	// consensus engine reads stakes, hence atomic.
+	client atomic.Pointer[rpc.Client]
 }
 
 // 固定的 ERC20 合约地址 0xc00fbbeb1cc277be7102f8af85bad2c0d0ac1856
 // var tokenAddress = common.HexToAddress("0xc00fbbeb1cc277be7102f8af85bad2c0d0ac1856")
 // YB
-var tokenAddress = common.HexToAddress("0x4b75210419009994c7f856f0b5c5b79750dbed22")
-
 // 硬编码的 RPC URL
 const rpcURL = "http://localhost:8545"
 
 //const rpcURL = "http://localhost:8546"
 
 var (
-	instance *ERC20
-	once     sync.Once
+	instance = new(ERC20)
+	dialOnce sync.Once
+	dialErr  error
+)
+
+var (
+	stakeLookupTimer       = metrics.NewRegisteredTimer("consensus/stake/lookup", nil)
+	stakeLookupErrorsMeter = metrics.NewRegisteredMeter("consensus/stake/lookup/errors", nil)
 )
 
-// NewERC20 creates or returns the single instance of ERC20
+// NewERC20 returns the single instance of ERC20, connecting it to the hardcoded
+// endpoint unless UseClient gave it a client already.
 func NewERC20() (*ERC20, error) {
-	var err error
-	once.Do(func() {
-		var client *rpc.Client
-		client, err = rpc.DialContext(context.Background(), rpcURL)
+	dialOnce.Do(func() {
+		if instance.client.Load() != nil {
+			return
+		}
+		client, err := rpc.DialContext(context.Background(), rpcURL)
 		if err != nil {
-			log.Printf("Failed to connect to RPC: %v", err)
+			consensuslog.Logger().Error("Failed to connect to stake RPC endpoint", "url", rpcURL, "err", err)
+			dialErr = err
 			return
 		}
-		instance = &ERC20{Client: client}
+		instance.client.CompareAndSwap(nil, client)
 	})
-	if err != nil {
-		return nil, err
+	if instance.client.Load() == nil {
+		return nil, dialErr
 	}
 	return instance, nil
 }
 
+// UseClient points the shared ERC20 instance at the given RPC client instead of
+// the hardcoded endpoint, letting in-process nodes such as the simulated backend
+// serve the stake reads of the consensus engine.
+func UseClient(client *rpc.Client) {
+	instance.client.Store(client)
+}
+
+// RPCClient returns the RPC client currently serving the stake reads.
+func (erc20 *ERC20) RPCClient() *rpc.Client {
+	return erc20.client.Load()
+}
+
 // BalanceOfCurrentAndMinus10 retrieves the balance of the ERC20 token for a specific address
 // at the latest block and the block 10 blocks prior to the latest block.
 // BalanceOfMinus10 retrieves the balance of the ERC20 token for a specific address
 // at the block 10 blocks prior to the latest block.
 func (erc20 *ERC20) BalanceOfMinus10(accountAddress common.Address) (*big.Int, error) {
-	client := ethclient.NewClient(erc20.Client)
+	client := ethclient.NewClient(erc20.RPCClient())
 
 	// 获取最新区块号
 	header, err := client.HeaderByNumber(context.Background(), nil)
 	if err != nil {
+		stakeLookupErrorsMeter.Mark(1)
 		return nil, err
 	}
 	latestBlock := header.Number
@@ -73,7 +99,7 @@ func (erc20 *ERC20) BalanceOfMinus10(accountAddress common.Address) (*big.Int, e
 	// 查询前第10个区块的余额
 	balanceMinus10, err := erc20.BalanceOfAt(accountAddress, minus10Block)
 	if err != nil {
-		log.Printf("Failed to get balance at block -10 (%s): %v", minus10Block.String(), err)
+		consensuslog.Logger().Debug("Failed to retrieve stake at lookback block", "account", accountAddress, "number", minus10Block, "err", err)
 		return nil, err
 	}
 
@@ -82,13 +108,13 @@ func (erc20 *ERC20) BalanceOfMinus10(accountAddress common.Address) (*big.Int, e
 
 // BalanceOf GetBalance retrieves the balance of the ERC20 token for a specific address
 func (erc20 *ERC20) BalanceOf(accountAddress common.Address) (*big.Int, error) {
-	client := ethclient.NewClient(erc20.Client)
+	client := ethclient.NewClient(erc20.RPCClient())
 
 	// ERC20 balanceOf function signature: 70a08231
 	data := append([]byte{0x70, 0xa0, 0x82, 0x31}, common.LeftPadBytes(accountAddress.Bytes(), 32)...)
 
 	callMsg := ethereum.CallMsg{
-		To:   &tokenAddress,
+		To:   &params.ZkscamTokenAddress,
 		Data: data,
 	}
 
@@ -110,20 +136,23 @@ func (erc20 *ERC20) BalanceOfAt(accountAddress common.Address, blockNumber *big.
 		blockNumber = big.NewInt(0)
 	}
 
-	client := ethclient.NewClient(erc20.Client)
+	defer stakeLookupTimer.UpdateSince(time.Now())
+
+	client := ethclient.NewClient(erc20.RPCClient())
 
 	// ERC20 balanceOf function signature: 70a08231
 	data := append([]byte{0x70, 0xa0, 0x82, 0x31}, common.LeftPadBytes(accountAddress.Bytes(), 32)...)
 
 	callMsg := ethereum.CallMsg{
-		To:   &tokenAddress,
+		To:   &params.ZkscamTokenAddress,
 		Data: data,
 	}
 
 	// Call the contract at the specific block number
 	result, err := client.CallContract(context.Background(), callMsg, blockNumber)
 	if err != nil {
-		log.Printf(blockNumber.String(), accountAddress.String(), err.Error())
+		stakeLookupErrorsMeter.Mark(1)
+		consensuslog.Logger().Debug("Failed to retrieve stake", "account", accountAddress, "number", blockNumber, "err", err)
 		return nil, err
 	}
 
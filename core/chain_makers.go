@@ -17,9 +17,11 @@
 package core
 
 import (
+	"crypto/ecdsa"
 	"fmt"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/misc"
@@ -29,8 +31,10 @@ import (
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/params"
+	single "github.com/ethereum/go-ethereum/singleton"
 	"github.com/ethereum/go-ethereum/triedb"
 	"github.com/holiman/uint256"
 )
@@ -49,6 +53,7 @@ type BlockGen struct {
 	receipts    []*types.Receipt
 	uncles      []*types.Header
 	withdrawals []*types.Withdrawal
+	voters      []*Voter
 
 	engine consensus.Engine
 }
@@ -104,6 +109,22 @@ func (b *BlockGen) SetParentBeaconRoot(root common.Hash) {
 	ProcessBeaconBlockRoot(root, vmenv, b.statedb)
 }
 
+// SetVoters makes the generated block carry the votes of the given voters,
+// signed the same way the vote-based clique engine seals blocks: every voter
+// signs the zkscam hash of the block and their BLS signatures are aggregated.
+// The votes of the block are the sum of the stakes of the voters, extending the
+// total votes of the parent. Generating siblings with different voters or
+// stakes builds competing forks of chosen weights.
+//
+// At least one voter is needed. For the engine to accept the block, the stakes
+// must match the ones of the voters at the lookback block.
+func (b *BlockGen) SetVoters(voters ...*Voter) {
+	if len(voters) == 0 {
+		panic("no voters")
+	}
+	b.voters = voters
+}
+
 // addTx adds a transaction to the generated block. If no coinbase has
 // been set, the block's coinbase is set to the zero address.
 //
@@ -115,8 +136,13 @@ func (b *BlockGen) addTx(bc *BlockChain, vmConfig vm.Config, tx *types.Transacti
 	if b.gasPool == nil {
 		b.SetCoinbase(common.Address{})
 	}
+	// Without a chain, the generated blocks serve the chain context
+	var chain ChainContext = b.cm
+	if bc != nil {
+		chain = bc
+	}
 	b.statedb.SetTxContext(tx.Hash(), len(b.txs))
-	receipt, err := ApplyTransaction(b.cm.config, bc, &b.header.Coinbase, b.gasPool, b.statedb, b.header, tx, &b.header.GasUsed, vmConfig)
+	receipt, err := ApplyTransaction(b.cm.config, chain, &b.header.Coinbase, b.gasPool, b.statedb, b.header, tx, &b.header.GasUsed, vmConfig)
 	if err != nil {
 		panic(err)
 	}
@@ -291,6 +317,67 @@ func (b *BlockGen) OffsetTime(seconds int64) {
 	b.header.Difficulty = b.engine.CalcDifficulty(b.cm, b.header.Time, b.parent.Header())
 }
 
+// Voter is a miner voting on the blocks generated by GenerateChain, see
+// BlockGen.SetVoters.
+type Voter struct {
+	Key   *ecdsa.PrivateKey // Consensus key signing the votes
+	BLS   *single.BLSKey    // BLS voting key, authorized by the consensus key
+	Stake *big.Int          // Stake the vote is weighted with
+}
+
+// NewVoter creates a voter with the given consensus key and stake, voting with
+// a fresh BLS key authorized by the consensus key.
+func NewVoter(key *ecdsa.PrivateKey, stake *big.Int) (*Voter, error) {
+	blsKey := single.GenerateBLSKey(crypto.PubkeyToAddress(key.PublicKey))
+	if err := blsKey.Authorize(func(hash []byte) ([]byte, error) {
+		return crypto.Sign(hash, key)
+	}); err != nil {
+		return nil, err
+	}
+	return &Voter{Key: key, BLS: blsKey, Stake: stake}, nil
+}
+
+// vote returns the block sealed with the votes of the voters of the generated
+// block.
+func (b *BlockGen) vote(block *types.Block) (*types.Block, error) {
+	var (
+		header  = block.Header()
+		hash    = block.ZkScamHash()
+		blsSigs [][]byte
+	)
+	header.ZkscamHash = hash
+	header.MinerAddresses, header.Signatures = nil, nil
+	header.BLSPublicKeys, header.AuthBLSSignatures = nil, nil
+	header.Votes = new(big.Int)
+
+	for _, voter := range b.voters {
+		sig, err := crypto.Sign(accounts.ZkscamVoteDigest(b.cm.config, header.Number, hash), voter.Key)
+		if err != nil {
+			return nil, err
+		}
+		blsSig, err := voter.BLS.Sign(hash[:])
+		if err != nil {
+			return nil, err
+		}
+		header.MinerAddresses = append(header.MinerAddresses, voter.BLS.Address)
+		header.Signatures = append(header.Signatures, sig)
+		header.BLSPublicKeys = append(header.BLSPublicKeys, voter.BLS.PublicKey())
+		header.AuthBLSSignatures = append(header.AuthBLSSignatures, voter.BLS.Authorization)
+		header.Votes.Add(header.Votes, voter.Stake)
+		blsSigs = append(blsSigs, blsSig)
+	}
+	aggregated, err := single.BLSAggregate(blsSigs...)
+	if err != nil {
+		return nil, err
+	}
+	header.AggregatedSignature = aggregated
+	header.TotalVotes = new(big.Int).Set(header.Votes)
+	if total := b.parent.Header().TotalVotes; total != nil {
+		header.TotalVotes.Add(header.TotalVotes, total)
+	}
+	return block.WithSeal(header), nil
+}
+
 // GenerateChain creates a chain of n blocks. The first block's
 // parent will be the provided parent. db is used to store
 // intermediate states and should contain the parent's state trie.
@@ -340,6 +427,12 @@ func GenerateChain(config *params.ChainConfig, parent *types.Block, engine conse
 		if config.DAOForkSupport && config.DAOForkBlock != nil && config.DAOForkBlock.Cmp(b.header.Number) == 0 {
 			misc.ApplyDAOHardFork(statedb)
 		}
+		// Store the parent beacon root set by the header, as block processing does
+		if root := b.header.ParentBeaconRoot; root != nil {
+			blockContext := NewEVMBlockContext(b.header, cm, &b.header.Coinbase)
+			vmenv := vm.NewEVM(blockContext, vm.TxContext{}, statedb, config, vm.Config{})
+			ProcessBeaconBlockRoot(*root, vmenv, statedb)
+		}
 		// Execute any user modifications to the block
 		if gen != nil {
 			gen(i, b)
@@ -349,6 +442,11 @@ func GenerateChain(config *params.ChainConfig, parent *types.Block, engine conse
 		if err != nil {
 			panic(err)
 		}
+		if b.voters != nil {
+			if block, err = b.vote(block); err != nil {
+				panic(err)
+			}
+		}
 
 		// Write state changes to db
 		root, err := statedb.Commit(b.header.Number.Uint64(), config.IsEIP158(b.header.Number))
@@ -17,6 +17,8 @@
 package core
 
 import (
+	"math/big"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 )
@@ -41,3 +43,20 @@ type ChainSideEvent struct {
 }
 
 type ChainHeadEvent struct{ Block *types.Block }
+
+// NewVoteEvent is posted when a verified vote of a miner is added to the votes
+// of the current slot.
+type NewVoteEvent struct {
+	Number *big.Int       // Height voted at
+	Miner  common.Address // Miner that signed the vote
+	Hash   common.Hash    // Zkscam hash voted for
+	Stake  *big.Int       // Stake the vote counts for
+}
+
+// RoundResultEvent is posted when a mining node concluded the vote of a slot.
+type RoundResultEvent struct {
+	Number     *big.Int                 // Height voted at
+	Winner     common.Hash              // Zkscam hash with the most votes
+	Candidates map[common.Hash]*big.Int // Votes of every zkscam hash voted for
+	Block      *types.Block             // Sealed block, nil if sealing failed
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/holiman/uint256"
 )
 
@@ -35,6 +36,9 @@ type ChainContext interface {
 
 	// GetHeader returns the header corresponding to the hash/number argument pair.
 	GetHeader(common.Hash, uint64) *types.Header
+
+	// Config returns the chain's configuration.
+	Config() *params.ChainConfig
 }
 
 // NewEVMBlockContext creates a new context for use in the EVM.
@@ -58,6 +62,12 @@ func NewEVMBlockContext(header *types.Header, chain ChainContext, author *common
 	}
 	if header.ExcessBlobGas != nil {
 		blobBaseFee = eip4844.CalcBlobFee(*header.ExcessBlobGas)
+	} else if chain != nil {
+		// Clique runs Cancun without merging or blobs, and may leave out the blob
+		// gas fields of the header: its blob gas is priced at the minimum
+		if config := chain.Config(); config.Clique != nil && config.IsCancun(header.Number, header.Time) {
+			blobBaseFee = big.NewInt(params.BlobTxMinBlobGasprice)
+		}
 	}
 	if header.Difficulty.Cmp(common.Big0) == 0 {
 		random = &header.MixDigest
@@ -66,6 +76,7 @@ func NewEVMBlockContext(header *types.Header, chain ChainContext, author *common
 		CanTransfer: CanTransfer,
 		Transfer:    Transfer,
 		GetHash:     GetHashFn(header, chain),
+		GetVoteData: GetVoteDataFn(header, chain),
 		Coinbase:    beneficiary,
 		BlockNumber: new(big.Int).Set(header.Number),
 		Time:        header.Time,
@@ -129,6 +140,29 @@ func GetHashFn(ref *types.Header, chain ChainContext) func(n uint64) common.Hash
 	}
 }
 
+// GetVoteDataFn returns a GetVoteDataFunc which retrieves the vote data of the
+// ancestors of ref.
+func GetVoteDataFn(ref *types.Header, chain ChainContext) func(n uint64) (vm.VoteData, bool) {
+	getHash := GetHashFn(ref, chain)
+
+	return func(n uint64) (vm.VoteData, bool) {
+		hash := getHash(n)
+		if hash == (common.Hash{}) {
+			return vm.VoteData{}, false
+		}
+		header := chain.GetHeader(hash, n)
+		if header == nil {
+			return vm.VoteData{}, false
+		}
+		return vm.VoteData{
+			ZkscamHash:     header.ZkscamHash,
+			Votes:          header.Votes,
+			TotalVotes:     header.TotalVotes,
+			MinerAddresses: header.MinerAddresses,
+		}, true
+	}
+}
+
 // CanTransfer checks whether there are enough funds in the address' account to make a transfer.
 // This does not take the necessary gas in to account to make the transfer valid.
 func CanTransfer(db vm.StateDB, addr common.Address, amount *uint256.Int) bool {
@@ -127,6 +127,15 @@ func TestCreation(t *testing.T) {
 				{123, 2707305664, ID{Hash: checksumToBytes(0x9b192ad0), Next: 0}},          // Future Cancun block
 			},
 		},
+		// ZKScam test cases
+		{
+			params.ZkscamChainConfig,
+			core.ZkscamGenesisBlock().ToBlock(),
+			[]testcase{
+				{0, 0, ID{Hash: checksumToBytes(0xe26f566c), Next: 0}},                // Unsynced, all forks up to Istanbul at genesis
+				{5000000, 1800000000, ID{Hash: checksumToBytes(0xe26f566c), Next: 0}}, // Future block
+			},
+		},
 	}
 	for i, tt := range tests {
 		for j, ttt := range tt.cases {
@@ -365,6 +374,22 @@ func TestValidation(t *testing.T) {
 	}
 }
 
+// Tests that ZKScam nodes reject stock Ethereum nodes at the handshake.
+func TestZkscamValidation(t *testing.T) {
+	filter := newFilter(params.ZkscamChainConfig, core.ZkscamGenesisBlock().ToBlock(), func() (uint64, uint64) { return 100, 1800000000 })
+	for i, id := range []ID{
+		NewID(params.MainnetChainConfig, core.DefaultGenesisBlock().ToBlock(), 20000000, 1800000000),
+		NewID(params.SepoliaChainConfig, core.DefaultSepoliaGenesisBlock().ToBlock(), 6000000, 1800000000),
+	} {
+		if err := filter(id); err != ErrLocalIncompatibleOrStale {
+			t.Errorf("test %d: validation error mismatch: have %v, want %v", i, err, ErrLocalIncompatibleOrStale)
+		}
+	}
+	if err := filter(NewID(params.ZkscamChainConfig, core.ZkscamGenesisBlock().ToBlock(), 200, 1800000000)); err != nil {
+		t.Errorf("zkscam peer rejected: %v", err)
+	}
+}
+
 // Tests that IDs are properly RLP encoded (specifically important because we
 // use uint32 to store the hash, but we need to encode it as [4]byte).
 func TestEncoding(t *testing.T) {
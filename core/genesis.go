@@ -18,6 +18,7 @@ package core
 
 import (
 	"bytes"
+	_ "embed"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -216,8 +217,12 @@ func (e *GenesisMismatchError) Error() string {
 
 // ChainOverrides contains the changes to chain config.
 type ChainOverrides struct {
-	OverrideCancun *uint64
-	OverrideVerkle *uint64
+	OverrideCancun            *uint64
+	OverrideVerkle            *uint64
+	OverrideZkscamBLS         *uint64
+	OverrideZkscamVoteData    *uint64
+	OverrideZkscamVotePayload *uint64
+	OverrideZkscamVoteDigest  *uint64
 }
 
 // SetupGenesisBlock writes or updates the genesis block in db.
@@ -249,6 +254,18 @@ func SetupGenesisBlockWithOverride(db ethdb.Database, triedb *triedb.Database, g
 			if overrides != nil && overrides.OverrideVerkle != nil {
 				config.VerkleTime = overrides.OverrideVerkle
 			}
+			if overrides != nil && overrides.OverrideZkscamBLS != nil {
+				config.ZkscamBLSBlock = new(big.Int).SetUint64(*overrides.OverrideZkscamBLS)
+			}
+			if overrides != nil && overrides.OverrideZkscamVoteData != nil {
+				config.ZkscamVoteDataBlock = new(big.Int).SetUint64(*overrides.OverrideZkscamVoteData)
+			}
+			if overrides != nil && overrides.OverrideZkscamVotePayload != nil {
+				config.ZkscamVotePayloadBlock = new(big.Int).SetUint64(*overrides.OverrideZkscamVotePayload)
+			}
+			if overrides != nil && overrides.OverrideZkscamVoteDigest != nil {
+				config.ZkscamVoteDigestBlock = new(big.Int).SetUint64(*overrides.OverrideZkscamVoteDigest)
+			}
 		}
 	}
 	// Just commit the new block if there is no stored genesis block.
@@ -380,6 +397,8 @@ func (g *Genesis) configOrDefault(ghash common.Hash) *params.ChainConfig {
 		return params.SepoliaChainConfig
 	case ghash == params.GoerliGenesisHash:
 		return params.GoerliChainConfig
+	case ghash == params.ZkscamGenesisHash:
+		return params.ZkscamChainConfig
 	default:
 		return params.AllEthashProtocolChanges
 	}
@@ -551,6 +570,21 @@ func DefaultHoleskyGenesisBlock() *Genesis {
 	}
 }
 
+// zkscamGenesisJSON is the genesis specification of the ZKScam network.
+//
+//go:embed zkscam.json
+var zkscamGenesisJSON []byte
+
+// ZkscamGenesisBlock returns the ZKScam network genesis block.
+func ZkscamGenesisBlock() *Genesis {
+	genesis := new(Genesis)
+	if err := json.Unmarshal(zkscamGenesisJSON, genesis); err != nil {
+		panic(fmt.Sprintf("invalid embedded zkscam genesis: %v", err))
+	}
+	genesis.Config = params.ZkscamChainConfig
+	return genesis
+}
+
 // DeveloperGenesisBlock returns the 'geth --dev' genesis block.
 func DeveloperGenesisBlock(gasLimit uint64, faucet *common.Address) *Genesis {
 	// Override the default period to the user requested one
@@ -580,6 +614,56 @@ func DeveloperGenesisBlock(gasLimit uint64, faucet *common.Address) *Genesis {
 	return genesis
 }
 
+// developerStake is the stake token balance of every miner of a developer
+// zkscam chain.
+var developerStake = new(big.Int).Mul(big.NewInt(1000), big.NewInt(params.Ether))
+
+// DeveloperZkscamGenesisBlock returns the 'geth --dev.zkscam' genesis block. The
+// faucet is pre-funded and both it and the given voters are staked in the token
+// of the ZKScam network, they are the voters of the genesis block.
+func DeveloperZkscamGenesisBlock(period uint64, gasLimit uint64, faucet common.Address, voters []common.Address) *Genesis {
+	// Override the default period to the user requested one
+	config := *params.AllCliqueProtocolChanges
+	config.Clique = &params.CliqueConfig{
+		Period: period,
+		Epoch:  config.Clique.Epoch,
+	}
+	genesis := DeveloperGenesisBlock(gasLimit, &faucet)
+	genesis.Config = &config
+	genesis.ExtraData = append(append(make([]byte, 32), faucet[:]...), make([]byte, crypto.SignatureLength)...)
+
+	// Deploy the stake token of the ZKScam network with the stakes of the miners
+	miners := append([]common.Address{faucet}, voters...)
+	stakes := make(map[common.Address]*big.Int, len(miners))
+	for _, miner := range miners {
+		stakes[miner] = developerStake
+	}
+	token, supply := ZkscamStakeToken(stakes)
+	genesis.Alloc[params.ZkscamTokenAddress] = token
+	genesis.MinerAddresses = miners
+	genesis.Votes = supply
+	genesis.TotalVotes = new(big.Int).Set(supply)
+	return genesis
+}
+
+// ZkscamStakeToken returns the genesis account of the stake token of the ZKScam
+// network holding the given balances, along with their total supply.
+func ZkscamStakeToken(balances map[common.Address]*big.Int) (types.Account, *big.Int) {
+	token := ZkscamGenesisBlock().Alloc[params.ZkscamTokenAddress]
+	storage := map[common.Hash]common.Hash{
+		common.BigToHash(big.NewInt(0)): token.Storage[common.BigToHash(big.NewInt(0))], // name
+		common.BigToHash(big.NewInt(1)): token.Storage[common.BigToHash(big.NewInt(1))], // symbol
+		common.BigToHash(big.NewInt(2)): token.Storage[common.BigToHash(big.NewInt(2))], // decimals
+	}
+	supply := new(big.Int)
+	for account, balance := range balances {
+		storage[params.ZkscamBalanceSlot(account)] = common.BigToHash(balance)
+		supply.Add(supply, balance)
+	}
+	storage[common.BigToHash(big.NewInt(3))] = common.BigToHash(supply) // totalSupply
+	return types.Account{Balance: new(big.Int), Code: token.Code, Storage: storage}, supply
+}
+
 func decodePrealloc(data string) types.GenesisAlloc {
 	var p []struct {
 		Addr    *big.Int
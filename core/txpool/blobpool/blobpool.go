@@ -1452,6 +1452,11 @@ func (p *BlobPool) Pending(filter txpool.PendingFilter) map[common.Address][]*tx
 	if filter.OnlyPlainTxs {
 		return nil
 	}
+	// Blob transactions don't track their arrival time, so they cannot take
+	// part in an arrival bounded selection.
+	if !filter.SeenBefore.IsZero() {
+		return nil
+	}
 	// Track the amount of time waiting to retrieve the list of pending blob txs
 	// from the pool and the amount of time actually spent on assembling the data.
 	// The latter will be pretty much moot, but we've kept it to have symmetric
@@ -555,6 +555,16 @@ func (pool *LegacyPool) Pending(filter txpool.PendingFilter) map[common.Address]
 				}
 			}
 		}
+		// If the miner requests an arrival cut-off, cap the lists at the first
+		// transaction seen too late to keep the remaining nonces contiguous
+		if !filter.SeenBefore.IsZero() {
+			for i, tx := range txs {
+				if !tx.Time().Before(filter.SeenBefore) {
+					txs = txs[:i]
+					break
+				}
+			}
+		}
 		if len(txs) > 0 {
 			lazies := make([]*txpool.LazyTransaction, len(txs))
 			for i := 0; i < len(txs); i++ {
@@ -1181,6 +1181,42 @@ func TestPendingLimiting(t *testing.T) {
 	}
 }
 
+// Tests that the arrival cut-off of the pending filter only returns the nonce
+// contiguous prefix of transactions seen before the cut-off.
+func TestPendingSeenBefore(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	account := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, account, big.NewInt(1000000000000))
+
+	var (
+		cutoff = time.Now()
+		seen   = []time.Time{cutoff.Add(-2 * time.Second), cutoff.Add(-time.Second), cutoff, cutoff.Add(-3 * time.Second)}
+	)
+	for i, at := range seen {
+		tx := transaction(uint64(i), 100000, key)
+		tx.SetTime(at)
+		if err := pool.addRemoteSync(tx); err != nil {
+			t.Fatalf("tx %d: failed to add transaction: %v", i, err)
+		}
+	}
+	if pending := pool.Pending(txpool.PendingFilter{})[account]; len(pending) != len(seen) {
+		t.Fatalf("unfiltered pending count mismatch: have %d, want %d", len(pending), len(seen))
+	}
+	pending := pool.Pending(txpool.PendingFilter{SeenBefore: cutoff})[account]
+	if len(pending) != 2 {
+		t.Fatalf("filtered pending count mismatch: have %d, want %d", len(pending), 2)
+	}
+	for i, ltx := range pending {
+		if nonce := ltx.Tx.Nonce(); nonce != uint64(i) {
+			t.Errorf("tx %d: nonce mismatch: have %d, want %d", i, nonce, i)
+		}
+	}
+}
+
 // Tests that if the transaction count belonging to multiple accounts go above
 // some hard threshold, the higher transactions are dropped to prevent DOS
 // attacks.
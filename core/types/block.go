@@ -20,7 +20,6 @@ package types
 import (
 	"encoding/binary"
 	"fmt"
-	"github.com/ethereum/go-ethereum/crypto"
 	"io"
 	"math/big"
 	"reflect"
@@ -117,28 +116,15 @@ type headerMarshaling struct {
 func (h *Header) Hash() common.Hash {
 	return rlpHash(h)
 }
+
+// zkScamHash returns the hash miners vote for, the keccak256 hash of the RLP
+// encoding of the transactions root, number and state root of the header.
 func (h *Header) zkScamHash() common.Hash {
-	fields := []interface{}{
+	return rlpHash([]interface{}{
 		h.TxHash,
 		h.Number,
 		h.Root, // stateRoot
-	}
-
-	// 打印字段内容
-	fmt.Printf("TxHash: %s\n", h.TxHash.Hex())
-	fmt.Printf("Number: %d\n", h.Number)
-	fmt.Printf("Root (stateRoot): %s\n", h.Root.Hex())
-
-	rlpEncoded, err := rlp.EncodeToBytes(fields)
-	if err != nil {
-		fmt.Printf("RLP encoding error: %v\n", err)
-	}
-
-	// 计算并打印 Keccak256 哈希值
-	hash := crypto.Keccak256Hash(rlpEncoded)
-	fmt.Printf("Keccak256 Hash: %s\n", hash.Hex())
-
-	return hash
+	})
 }
 
 var headerSize = common.StorageSize(reflect.TypeOf(Header{}).Size())
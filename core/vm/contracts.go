@@ -17,6 +17,7 @@
 package vm
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"encoding/binary"
 	"errors"
@@ -31,6 +32,8 @@ import (
 	"github.com/ethereum/go-ethereum/crypto/bn256"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/params"
+	single "github.com/ethereum/go-ethereum/singleton"
+	"github.com/holiman/uint256"
 	"golang.org/x/crypto/ripemd160"
 )
 
@@ -121,7 +124,16 @@ var PrecompiledContractsBLS = map[common.Address]PrecompiledContract{
 	common.BytesToAddress([]byte{18}): &bls12381MapG2{},
 }
 
+// PrecompiledContractsZkscamBLS contains the pre-compiled contracts of the
+// vote-based clique added by the BLS vote verification fork. They are active
+// next to the ones of the Ethereum forks, at addresses outside of the ranges of
+// the Ethereum and rollup precompiles.
+var PrecompiledContractsZkscamBLS = map[common.Address]PrecompiledContract{
+	common.BytesToAddress([]byte{0x5c, 0x00}): &zkscamBLSVerify{},
+}
+
 var (
+	PrecompiledAddressesZkscamBLS []common.Address
 	PrecompiledAddressesCancun    []common.Address
 	PrecompiledAddressesBerlin    []common.Address
 	PrecompiledAddressesIstanbul  []common.Address
@@ -145,10 +157,26 @@ func init() {
 	for k := range PrecompiledContractsCancun {
 		PrecompiledAddressesCancun = append(PrecompiledAddressesCancun, k)
 	}
+	for k := range PrecompiledContractsZkscamBLS {
+		PrecompiledAddressesZkscamBLS = append(PrecompiledAddressesZkscamBLS, k)
+	}
 }
 
 // ActivePrecompiles returns the precompiles enabled with the current configuration.
 func ActivePrecompiles(rules params.Rules) []common.Address {
+	precompiles := activeEthereumPrecompiles(rules)
+	if rules.IsZkscamBLS {
+		precompiles = append(precompiles[:len(precompiles):len(precompiles)], PrecompiledAddressesZkscamBLS...)
+	}
+	if rules.IsZkscamVoteData {
+		precompiles = append(precompiles[:len(precompiles):len(precompiles)], zkscamVoteDataAddress)
+	}
+	return precompiles
+}
+
+// activeEthereumPrecompiles returns the precompiles of the Ethereum forks enabled
+// with the current configuration.
+func activeEthereumPrecompiles(rules params.Rules) []common.Address {
 	switch {
 	case rules.IsCancun:
 		return PrecompiledAddressesCancun
@@ -1134,3 +1162,177 @@ func kZGToVersionedHash(kzg kzg4844.Commitment) common.Hash {
 
 	return h
 }
+
+// zkscamBLSVerify implements the verification of the aggregated BLS signatures
+// of the vote-based clique as a native contract.
+//
+// Every key comes with its proof of possession, a signature of the key by
+// itself, so a caller cannot choose one of the keys as a function of the others
+// to forge an aggregated signature (rogue key attack).
+type zkscamBLSVerify struct{}
+
+const (
+	zkscamBLSMessageLength   = 32  // Length of the signed message, the zkscam hash of a block
+	zkscamBLSSignatureLength = 64  // Length of an aggregated signature, a bn256 G1 point
+	zkscamBLSPublicKeyLength = 128 // Length of a public key, a bn256 G2 point
+
+	// Length of a public key followed by its proof of possession
+	zkscamBLSProvenKeyLength = zkscamBLSPublicKeyLength + zkscamBLSSignatureLength
+)
+
+var (
+	errZkscamBLSInvalidInputLength = errors.New("invalid input length")
+	errZkscamBLSInvalidPublicKey   = errors.New("invalid public key")
+)
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *zkscamBLSVerify) RequiredGas(input []byte) uint64 {
+	var keys uint64
+	if len(input) > zkscamBLSMessageLength+zkscamBLSSignatureLength {
+		keys = uint64(len(input)-zkscamBLSMessageLength-zkscamBLSSignatureLength) / zkscamBLSProvenKeyLength
+	}
+	return params.ZkscamBLSVerifyBaseGas + keys*params.ZkscamBLSVerifyPerKeyGas
+}
+
+// Run verifies an aggregated BLS signature of the vote-based clique. The input
+// is the 32 byte message, the 64 byte aggregated signature and at least one 128
+// byte public key, each followed by its 64 byte proof of possession, all encoded
+// as in the block headers. It returns 1 as a 32 byte word if the signature was
+// produced by the given keys and all keys are proven, 0 otherwise.
+func (c *zkscamBLSVerify) Run(input []byte) ([]byte, error) {
+	const keysOffset = zkscamBLSMessageLength + zkscamBLSSignatureLength
+
+	if len(input) < keysOffset+zkscamBLSProvenKeyLength || (len(input)-keysOffset)%zkscamBLSProvenKeyLength != 0 {
+		return nil, errZkscamBLSInvalidInputLength
+	}
+	var (
+		message   = input[:zkscamBLSMessageLength]
+		signature = input[zkscamBLSMessageLength:keysOffset]
+		keys      = make([][]byte, 0, (len(input)-keysOffset)/zkscamBLSProvenKeyLength)
+		proofs    = make([][]byte, 0, cap(keys))
+	)
+	for i := keysOffset; i < len(input); i += zkscamBLSProvenKeyLength {
+		key := input[i : i+zkscamBLSPublicKeyLength]
+		if _, err := single.UnmarshalBLSKeyBytes(key); err != nil {
+			return nil, fmt.Errorf("%w %d: %v", errZkscamBLSInvalidPublicKey, len(keys), err)
+		}
+		keys = append(keys, key)
+		proofs = append(proofs, input[i+zkscamBLSPublicKeyLength:i+zkscamBLSProvenKeyLength])
+	}
+	// Unproven keys and malformed signatures fail the verification the same as
+	// wrong signatures
+	for i, key := range keys {
+		if !single.VerifyBLSPossession(key, proofs[i]) {
+			return false32Byte, nil
+		}
+	}
+	if ok, _ := single.BLSAggregateVerify(message, signature, keys); !ok {
+		return false32Byte, nil
+	}
+	return true32Byte, nil
+}
+
+// zkscamVoteDataAddress is the address of the vote data precompile, next to the
+// BLS verification one. Unlike the other precompiles it reads the chain, so it
+// is instantiated for every call.
+var zkscamVoteDataAddress = common.BytesToAddress([]byte{0x5c, 0x01})
+
+// VoteData is the consensus vote data of a block.
+type VoteData struct {
+	ZkscamHash     common.Hash
+	Votes          *big.Int
+	TotalVotes     *big.Int
+	MinerAddresses []common.Address
+}
+
+var (
+	errZkscamVoteDataInvalidInputLength = errors.New("invalid input length")
+	errZkscamVoteDataUnavailable        = errors.New("vote data unavailable")
+)
+
+// zkscamVoteData implements a native contract returning the vote data of one of
+// the 256 most recent blocks, in the spirit of BLOCKHASH.
+type zkscamVoteData struct {
+	number      uint64 // Number of the block being executed
+	getVoteData GetVoteDataFunc
+
+	// Vote data of the last input, shared by the gas calculation and the run
+	// so the header is only resolved once
+	input []byte
+	data  VoteData
+	found bool
+}
+
+// voteData returns the vote data of the block whose number is the input, or
+// false if the block is not one of the 256 most recent ones.
+func (c *zkscamVoteData) voteData(input []byte) (VoteData, bool) {
+	if c.input == nil || !bytes.Equal(c.input, input) {
+		c.data, c.found = c.lookup(input)
+		c.input = common.CopyBytes(input)
+	}
+	return c.data, c.found
+}
+
+// lookup resolves the vote data of the block whose number is the input.
+func (c *zkscamVoteData) lookup(input []byte) (VoteData, bool) {
+	if len(input) != 32 || c.getVoteData == nil {
+		return VoteData{}, false
+	}
+	num, overflow := new(uint256.Int).SetBytes(input).Uint64WithOverflow()
+	if overflow {
+		return VoteData{}, false
+	}
+	var lower uint64
+	if c.number >= 257 {
+		lower = c.number - 256
+	}
+	if num < lower || num >= c.number {
+		return VoteData{}, false
+	}
+	return c.getVoteData(num)
+}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *zkscamVoteData) RequiredGas(input []byte) uint64 {
+	data, _ := c.voteData(input)
+	return params.ZkscamVoteDataBaseGas + uint64(len(data.MinerAddresses))*params.ZkscamVoteDataPerVoteGas
+}
+
+// Run returns the vote data of the block whose number is given as a 32 byte
+// word, ABI encoded as (bytes32 zkscamHash, uint256 votes, uint256 totalVotes,
+// address[] miners). Blocks that are not one of the 256 most recent ones have
+// all values zero and no miners. It fails if the block context cannot resolve
+// vote data at all, rather than reporting zeros for the voted blocks.
+func (c *zkscamVoteData) Run(input []byte) ([]byte, error) {
+	if len(input) != 32 {
+		return nil, errZkscamVoteDataInvalidInputLength
+	}
+	if c.getVoteData == nil {
+		return nil, errZkscamVoteDataUnavailable
+	}
+	data, _ := c.voteData(input)
+
+	var (
+		offset = uint256.NewInt(4 * 32).Bytes32()
+		count  = uint256.NewInt(uint64(len(data.MinerAddresses))).Bytes32()
+		output = make([]byte, 0, 32*(5+len(data.MinerAddresses)))
+	)
+	output = append(output, data.ZkscamHash.Bytes()...)
+	output = append(output, common.BigToHash(zkscamVoteDataValue(data.Votes)).Bytes()...)
+	output = append(output, common.BigToHash(zkscamVoteDataValue(data.TotalVotes)).Bytes()...)
+	output = append(output, offset[:]...)
+	output = append(output, count[:]...)
+	for _, miner := range data.MinerAddresses {
+		output = append(output, common.LeftPadBytes(miner.Bytes(), 32)...)
+	}
+	return output, nil
+}
+
+// zkscamVoteDataValue returns the vote count to encode, with missing counts
+// encoded as zero.
+func zkscamVoteDataValue(v *big.Int) *big.Int {
+	if v == nil {
+		return new(big.Int)
+	}
+	return v
+}
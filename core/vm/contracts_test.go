@@ -20,11 +20,14 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"os"
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
 )
 
 // precompiledTest defines the input/output pairs for precompiled contract tests.
@@ -67,6 +70,8 @@ var allPrecompiles = map[common.Address]PrecompiledContract{
 	common.BytesToAddress([]byte{0x0f, 0x10}): &bls12381Pairing{},
 	common.BytesToAddress([]byte{0x0f, 0x11}): &bls12381MapG1{},
 	common.BytesToAddress([]byte{0x0f, 0x12}): &bls12381MapG2{},
+
+	common.BytesToAddress([]byte{0x5c, 0x00}): &zkscamBLSVerify{},
 }
 
 // EIP-152 test vectors
@@ -316,6 +321,65 @@ func TestPrecompiledBLS12381MapG2(t *testing.T)      { testJson("blsMapG2", "f12
 
 func TestPrecompiledPointEvaluation(t *testing.T) { testJson("pointEvaluation", "0a", t) }
 
+func TestPrecompiledZkscamBLSVerify(t *testing.T)      { testJson("zkscamBLSVerify", "5c00", t) }
+func TestPrecompiledZkscamBLSVerifyFail(t *testing.T)  { testJsonFail("zkscamBLSVerify", "5c00", t) }
+func BenchmarkPrecompiledZkscamBLSVerify(b *testing.B) { benchJson("zkscamBLSVerify", "5c00", b) }
+
+// Tests that the vote data precompile resolves the requested header once for
+// both the gas calculation and the run.
+func TestZkscamVoteDataSingleLookup(t *testing.T) {
+	var lookups int
+	p := &zkscamVoteData{number: 100, getVoteData: func(n uint64) (VoteData, bool) {
+		lookups++
+		return VoteData{MinerAddresses: []common.Address{{0x01}}}, true
+	}}
+	input := common.LeftPadBytes([]byte{99}, 32)
+	if _, _, err := RunPrecompiledContract(p, input, params.ZkscamVoteDataBaseGas+params.ZkscamVoteDataPerVoteGas); err != nil {
+		t.Fatalf("precompile failed: %v", err)
+	}
+	if lookups != 1 {
+		t.Fatalf("header resolved %d times, want 1", lookups)
+	}
+}
+
+// Tests that the vote data precompile fails in block contexts that cannot
+// resolve vote data, instead of reporting blocks as not voted.
+func TestZkscamVoteDataUnavailable(t *testing.T) {
+	p := &zkscamVoteData{number: 100}
+	input := common.LeftPadBytes([]byte{99}, 32)
+	if _, _, err := RunPrecompiledContract(p, input, params.ZkscamVoteDataBaseGas); err != errZkscamVoteDataUnavailable {
+		t.Fatalf("error mismatch: have %v, want %v", err, errZkscamVoteDataUnavailable)
+	}
+}
+
+// Tests that the precompiles of the vote-based clique are only active from their
+// fork blocks on.
+func TestZkscamPrecompileActivation(t *testing.T) {
+	config := *params.AllCliqueProtocolChanges
+	config.ZkscamBLSBlock = big.NewInt(10)
+	config.ZkscamVoteDataBlock = big.NewInt(20)
+
+	for _, tt := range []struct {
+		addr   common.Address
+		number int64
+		active bool
+	}{
+		{common.BytesToAddress([]byte{0x5c, 0x00}), 9, false},
+		{common.BytesToAddress([]byte{0x5c, 0x00}), 10, true},
+		{common.BytesToAddress([]byte{0x5c, 0x00}), 11, true},
+		{common.BytesToAddress([]byte{0x5c, 0x01}), 10, false},
+		{common.BytesToAddress([]byte{0x5c, 0x01}), 20, true},
+	} {
+		evm := NewEVM(BlockContext{BlockNumber: big.NewInt(tt.number)}, TxContext{}, nil, &config, Config{})
+		if _, ok := evm.precompile(tt.addr); ok != tt.active {
+			t.Errorf("block %d: precompile %x active %v, want %v", tt.number, tt.addr, ok, tt.active)
+		}
+		if active := slices.Contains(ActivePrecompiles(evm.chainRules), tt.addr); active != tt.active {
+			t.Errorf("block %d: precompile %x listed %v, want %v", tt.number, tt.addr, active, tt.active)
+		}
+	}
+}
+
 func BenchmarkPrecompiledBLS12381G1Add(b *testing.B)      { benchJson("blsG1Add", "f0a", b) }
 func BenchmarkPrecompiledBLS12381G1Mul(b *testing.B)      { benchJson("blsG1Mul", "f0b", b) }
 func BenchmarkPrecompiledBLS12381G1MultiExp(b *testing.B) { benchJson("blsG1MultiExp", "f0c", b) }
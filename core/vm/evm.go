@@ -35,6 +35,9 @@ type (
 	// GetHashFunc returns the n'th block hash in the blockchain
 	// and is used by the BLOCKHASH EVM op code.
 	GetHashFunc func(uint64) common.Hash
+	// GetVoteDataFunc returns the vote data of the n'th block in the blockchain
+	// and whether it is known. It is used by the vote data precompile.
+	GetVoteDataFunc func(uint64) (VoteData, bool)
 )
 
 func (evm *EVM) precompile(addr common.Address) (PrecompiledContract, bool) {
@@ -51,8 +54,18 @@ func (evm *EVM) precompile(addr common.Address) (PrecompiledContract, bool) {
 	default:
 		precompiles = PrecompiledContractsHomestead
 	}
-	p, ok := precompiles[addr]
-	return p, ok
+	if p, ok := precompiles[addr]; ok {
+		return p, true
+	}
+	if evm.chainRules.IsZkscamVoteData && addr == zkscamVoteDataAddress {
+		return &zkscamVoteData{number: evm.Context.BlockNumber.Uint64(), getVoteData: evm.Context.GetVoteData}, true
+	}
+	if evm.chainRules.IsZkscamBLS {
+		if p, ok := PrecompiledContractsZkscamBLS[addr]; ok {
+			return p, true
+		}
+	}
+	return nil, false
 }
 
 // BlockContext provides the EVM with auxiliary information. Once provided
@@ -65,6 +78,8 @@ type BlockContext struct {
 	Transfer TransferFunc
 	// GetHash returns the hash corresponding to n
 	GetHash GetHashFunc
+	// GetVoteData returns the vote data corresponding to n
+	GetVoteData GetVoteDataFunc
 
 	// Block information
 	Coinbase    common.Address // Provides information for COINBASE
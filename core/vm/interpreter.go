@@ -56,8 +56,12 @@ func NewEVMInterpreter(evm *EVM) *EVMInterpreter {
 	// If jump table was not initialised we set the default one.
 	var table *JumpTable
 	switch {
+	case evm.chainRules.IsCancun && !evm.chainRules.IsMerge:
+		table = &preMergeCancunInstructionSet
 	case evm.chainRules.IsCancun:
 		table = &cancunInstructionSet
+	case evm.chainRules.IsShanghai && !evm.chainRules.IsMerge:
+		table = &preMergeShanghaiInstructionSet
 	case evm.chainRules.IsShanghai:
 		table = &shanghaiInstructionSet
 	case evm.chainRules.IsMerge:
@@ -57,6 +57,9 @@ var (
 	mergeInstructionSet            = newMergeInstructionSet()
 	shanghaiInstructionSet         = newShanghaiInstructionSet()
 	cancunInstructionSet           = newCancunInstructionSet()
+
+	preMergeShanghaiInstructionSet = newPreMergeInstructionSet(newShanghaiInstructionSet())
+	preMergeCancunInstructionSet   = newPreMergeInstructionSet(newCancunInstructionSet())
 )
 
 // JumpTable contains the EVM opcodes supported at a given fork.
@@ -90,6 +93,14 @@ func newCancunInstructionSet() JumpTable {
 	return validate(instructionSet)
 }
 
+// newPreMergeInstructionSet returns the given instruction set with DIFFICULTY
+// in place of PREVRANDAO, for chains running the forks past the merge without
+// merging, like clique ones.
+func newPreMergeInstructionSet(instructionSet JumpTable) JumpTable {
+	instructionSet[DIFFICULTY] = londonInstructionSet[DIFFICULTY]
+	return validate(instructionSet)
+}
+
 func newShanghaiInstructionSet() JumpTable {
 	instructionSet := newMergeInstructionSet()
 	enable3855(&instructionSet) // PUSH0 instruction
@@ -30,8 +30,12 @@ func LookupInstructionSet(rules params.Rules) (JumpTable, error) {
 		return newCancunInstructionSet(), errors.New("verkle-fork not defined yet")
 	case rules.IsPrague:
 		return newCancunInstructionSet(), errors.New("prague-fork not defined yet")
+	case rules.IsCancun && !rules.IsMerge:
+		return newPreMergeInstructionSet(newCancunInstructionSet()), nil
 	case rules.IsCancun:
 		return newCancunInstructionSet(), nil
+	case rules.IsShanghai && !rules.IsMerge:
+		return newPreMergeInstructionSet(newShanghaiInstructionSet()), nil
 	case rules.IsShanghai:
 		return newShanghaiInstructionSet(), nil
 	case rules.IsMerge:
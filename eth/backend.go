@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus"
@@ -55,7 +56,9 @@ import (
 	"github.com/ethereum/go-ethereum/rpc"
 	single "github.com/ethereum/go-ethereum/singleton"
 	"math/big"
+	"os"
 	"runtime"
+	"strings"
 	"sync"
 )
 
@@ -143,6 +146,23 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 			log.Error("Failed to recover state", "error", err)
 		}
 	}
+	// Load the legacy plaintext miner key if one was configured. Keystore and
+	// external signer accounts are authorized when mining is started.
+	if config.Miner.KeyFile != "" {
+		addr, err := single.LoadKeyFile(config.Miner.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load miner key file: %v", err)
+		}
+		if config.Miner.Etherbase == (common.Address{}) {
+			config.Miner.Etherbase = addr
+		} else if config.Miner.Etherbase != addr {
+			return nil, fmt.Errorf("miner key file belongs to %s, etherbase is %s", addr.Hex(), config.Miner.Etherbase.Hex())
+		}
+		log.Warn("Using plaintext miner key file, consider importing it into the keystore", "file", config.Miner.KeyFile, "address", addr)
+	}
+	if config.Miner.BLSKeyDir == "" {
+		config.Miner.BLSKeyDir = stack.ResolvePath(single.BLSKeyStoreDir)
+	}
 	// Transfer mining-related config to the ethash config.
 	chainConfig, err := core.LoadChainConfig(chainDb, config.Genesis)
 	if err != nil {
@@ -152,6 +172,23 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	if err != nil {
 		return nil, err
 	}
+	// Record the votes signed by local miners, so restarts never equivocate
+	if cli := cliqueEngine(engine); cli != nil {
+		votedb, err := stack.OpenDatabase(clique.VoteHistoryDB, 16, 16, "eth/db/votehistory/", false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open vote history: %v", err)
+		}
+		cli.SetVoteHistory(clique.NewVoteHistory(votedb))
+
+		if config.ConsensusAuditLog != "" {
+			audit, err := clique.NewAuditLog(config.ConsensusAuditLog)
+			if err != nil {
+				return nil, fmt.Errorf("failed to open consensus audit log: %v", err)
+			}
+			cli.SetAuditLog(audit)
+			log.Info("Consensus audit log configured", "file", config.ConsensusAuditLog)
+		}
+	}
 	networkID := config.NetworkId
 	if networkID == 0 {
 		networkID = chainConfig.ChainID.Uint64()
@@ -213,23 +250,38 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	if config.OverrideVerkle != nil {
 		overrides.OverrideVerkle = config.OverrideVerkle
 	}
+	if config.OverrideZkscamBLS != nil {
+		overrides.OverrideZkscamBLS = config.OverrideZkscamBLS
+	}
+	if config.OverrideZkscamVoteData != nil {
+		overrides.OverrideZkscamVoteData = config.OverrideZkscamVoteData
+	}
+	if config.OverrideZkscamVotePayload != nil {
+		overrides.OverrideZkscamVotePayload = config.OverrideZkscamVotePayload
+	}
+	if config.OverrideZkscamVoteDigest != nil {
+		overrides.OverrideZkscamVoteDigest = config.OverrideZkscamVoteDigest
+	}
 	eth.blockchain, err = core.NewBlockChain(chainDb, cacheConfig, config.Genesis, &overrides, eth.engine, vmConfig, eth.shouldPreserve, &config.TransactionHistory)
 	if err != nil {
 		return nil, err
 	}
 	eth.bloomIndexer.Start(eth.blockchain)
 
-	if config.BlobPool.Datadir != "" {
-		config.BlobPool.Datadir = stack.ResolvePath(config.BlobPool.Datadir)
-	}
-	blobPool := blobpool.New(config.BlobPool, eth.blockchain)
-
 	if config.TxPool.Journal != "" {
 		config.TxPool.Journal = stack.ResolvePath(config.TxPool.Journal)
 	}
-	legacyPool := legacypool.New(config.TxPool, eth.blockchain)
+	subpools := []txpool.SubPool{legacypool.New(config.TxPool, eth.blockchain)}
 
-	eth.txPool, err = txpool.New(config.TxPool.PriceLimit, eth.blockchain, []txpool.SubPool{legacyPool, blobPool})
+	// Clique chains that never merge run Cancun without blobs, blob transactions
+	// are not pooled
+	if chainConfig.Clique == nil || chainConfig.TerminalTotalDifficulty != nil {
+		if config.BlobPool.Datadir != "" {
+			config.BlobPool.Datadir = stack.ResolvePath(config.BlobPool.Datadir)
+		}
+		subpools = append(subpools, blobpool.New(config.BlobPool, eth.blockchain))
+	}
+	eth.txPool, err = txpool.New(config.TxPool.PriceLimit, eth.blockchain, subpools)
 	if err != nil {
 		return nil, err
 	}
@@ -344,7 +396,7 @@ func (s *Ethereum) Etherbase() (eb common.Address, err error) {
 	s.lock.RLock()
 	etherbase := s.etherbase
 	s.lock.RUnlock()
-	_, etherbase, _ = single.New()
+
 	if etherbase != (common.Address{}) {
 		return etherbase, nil
 	}
@@ -432,22 +484,25 @@ func (s *Ethereum) StartMining() error {
 			log.Error("Cannot start mining without etherbase", "err", err)
 			return fmt.Errorf("etherbase missing: %v", err)
 		}
-		var cli *clique.Clique
-		if c, ok := s.engine.(*clique.Clique); ok {
-			cli = c
-		} else if cl, ok := s.engine.(*beacon.Beacon); ok {
-			if c, ok := cl.InnerEngine().(*clique.Clique); ok {
-				cli = c
+		if cli := cliqueEngine(s.engine); cli != nil {
+			var signFn clique.SignerFn
+			if s.config.Miner.KeyFile == "" {
+				wallet, err := s.accountManager.Find(accounts.Account{Address: eb})
+				if wallet == nil || err != nil {
+					log.Error("Etherbase account unavailable locally", "err", err)
+					return fmt.Errorf("signer missing: %v", err)
+				}
+				signFn = s.consensusSignFn(wallet)
+			}
+			if err := cli.Authorize(eb, signFn); err != nil {
+				log.Error("Failed to authorize miner", "etherbase", eb, "err", err)
+				return fmt.Errorf("signer unavailable: %v", err)
+			}
+			if err := s.loadBLSKey(eb); err != nil {
+				log.Error("Failed to load BLS voting key", "etherbase", eb, "err", err)
+				return fmt.Errorf("BLS key unavailable: %v", err)
 			}
 		}
-		//if cli != nil {
-		//	wallet, err := s.accountManager.Find(accounts.Account{Address: eb})
-		//	if wallet == nil || err != nil {
-		//		log.Error("Etherbase account unavailable locally", "err", err)
-		//		return fmt.Errorf("signer missing: %v", err)
-		//	}
-		cli.Authorize(eb, nil)
-		//}
 		// If mining is started, we can disable the transaction rejection mechanism
 		// introduced to speed sync times.
 		s.handler.enableSyncedFeatures()
@@ -457,6 +512,76 @@ func (s *Ethereum) StartMining() error {
 	return nil
 }
 
+// cliqueEngine returns the clique engine, either used directly or wrapped by
+// the beacon engine, or nil if the chain doesn't run clique.
+func cliqueEngine(engine consensus.Engine) *clique.Clique {
+	if c, ok := engine.(*clique.Clique); ok {
+		return c
+	}
+	if cl, ok := engine.(*beacon.Beacon); ok {
+		if c, ok := cl.InnerEngine().(*clique.Clique); ok {
+			return c
+		}
+	}
+	return nil
+}
+
+// loadBLSKey configures the independent BLS voting key of the etherbase from
+// the BLS keystore. Miners without a stored key cannot vote, the key is never
+// derived from the signing key.
+func (s *Ethereum) loadBLSKey(eb common.Address) error {
+	var password string
+	if file := s.config.Miner.BLSPasswordFile; file != "" {
+		text, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read BLS password file: %v", err)
+		}
+		password = strings.TrimRight(string(text), "\r\n")
+	}
+	key, err := single.ReadBLSKey(s.config.Miner.BLSKeyDir, eb, password)
+	if errors.Is(err, single.ErrBLSKeyNotFound) {
+		return fmt.Errorf("%w in %s, create one with 'geth account bls new %s'", err, s.config.Miner.BLSKeyDir, eb.Hex())
+	} else if err != nil {
+		return err
+	}
+	if !key.Authorized() {
+		log.Warn("BLS voting key is not authorized, signing authorization with etherbase", "etherbase", eb)
+	}
+	single.SetBLSKey(key)
+	log.Info("Loaded BLS voting key", "etherbase", eb, "pubkey", hexutil.Bytes(key.PublicKey()))
+	return nil
+}
+
+// consensusSignFn returns the clique signer callback of the given wallet. Votes
+// and BLS key authorizations are signatures over a raw digest: keystore accounts
+// (unlocked via --unlock) sign those directly, any other wallet, e.g. clef, is
+// asked to sign them via the zkscam mimetypes.
+func (s *Ethereum) consensusSignFn(wallet accounts.Wallet) clique.SignerFn {
+	return func(account accounts.Account, mimeType string, data []byte) ([]byte, error) {
+		switch mimeType {
+		case accounts.MimetypeZkscamVote, accounts.MimetypeZkscamLegacyVote, accounts.MimetypeZkscamBLSAuth:
+			for _, backend := range s.accountManager.Backends(keystore.KeyStoreType) {
+				ks := backend.(*keystore.KeyStore)
+				if !ks.HasAddress(account.Address) {
+					continue
+				}
+				if mimeType == accounts.MimetypeZkscamBLSAuth {
+					return ks.SignHash(account, accounts.ZkscamBLSAuthHash(data))
+				}
+				number, hash, err := accounts.ParseZkscamVoteData(data)
+				if err != nil {
+					return nil, err
+				}
+				if mimeType == accounts.MimetypeZkscamLegacyVote {
+					return ks.SignHash(account, hash[:])
+				}
+				return ks.SignHash(account, accounts.ZkscamVoteHash(number, hash))
+			}
+		}
+		return wallet.SignData(account, mimeType, data)
+	}
+}
+
 // StopMining terminates the miner, both at the consensus engine level as well as
 // at the block creation level.
 func (s *Ethereum) StopMining() {
@@ -66,6 +66,7 @@ var Defaults = Config{
 	RPCEVMTimeout:      5 * time.Second,
 	GPO:                FullNodeGPO,
 	RPCTxFeeCap:        1, // 1 ether
+	RPCVotePayload:     "full",
 }
 
 //go:generate go run github.com/fjl/gencodec -type Config -formats toml -out gen_config.go
@@ -130,6 +131,11 @@ type Config struct {
 	// Mining options
 	Miner miner.Config
 
+	// ConsensusAuditLog is the file the round decisions of the vote-based
+	// consensus and the vote verification failures are appended to as JSON
+	// lines, disabled if empty.
+	ConsensusAuditLog string `toml:",omitempty"`
+
 	// Transaction pool options
 	TxPool   legacypool.Config
 	BlobPool blobpool.Config
@@ -153,11 +159,31 @@ type Config struct {
 	// send-transaction variants. The unit is ether.
 	RPCTxFeeCap float64
 
+	// RPCVotePayload selects how much of the vote payload of the consensus is
+	// included in RPC block and header responses, "full" or "summary".
+	RPCVotePayload string
+
 	// OverrideCancun (TODO: remove after the fork)
 	OverrideCancun *uint64 `toml:",omitempty"`
 
 	// OverrideVerkle (TODO: remove after the fork)
 	OverrideVerkle *uint64 `toml:",omitempty"`
+
+	// OverrideZkscamBLS schedules the BLS vote verification fork of networks
+	// that did not agree on a fork block in their genesis.
+	OverrideZkscamBLS *uint64 `toml:",omitempty"`
+
+	// OverrideZkscamVoteData schedules the vote data fork of networks that did
+	// not agree on a fork block in their genesis.
+	OverrideZkscamVoteData *uint64 `toml:",omitempty"`
+
+	// OverrideZkscamVotePayload schedules the strict vote payload fork of
+	// networks that did not agree on a fork block in their genesis.
+	OverrideZkscamVotePayload *uint64 `toml:",omitempty"`
+
+	// OverrideZkscamVoteDigest schedules the domain separated vote digest fork
+	// of networks that did not agree on a fork block in their genesis.
+	OverrideZkscamVoteDigest *uint64 `toml:",omitempty"`
 }
 
 // CreateConsensusEngine creates a consensus engine for the given chain config.
@@ -17,45 +17,51 @@ import (
 // MarshalTOML marshals as TOML.
 func (c Config) MarshalTOML() (interface{}, error) {
 	type Config struct {
-		Genesis                 *core.Genesis `toml:",omitempty"`
-		NetworkId               uint64
-		SyncMode                downloader.SyncMode
-		EthDiscoveryURLs        []string
-		SnapDiscoveryURLs       []string
-		NoPruning               bool
-		NoPrefetch              bool
-		TxLookupLimit           uint64                 `toml:",omitempty"`
-		TransactionHistory      uint64                 `toml:",omitempty"`
-		StateHistory            uint64                 `toml:",omitempty"`
-		StateScheme             string                 `toml:",omitempty"`
-		RequiredBlocks          map[uint64]common.Hash `toml:"-"`
-		LightServ               int                    `toml:",omitempty"`
-		LightIngress            int                    `toml:",omitempty"`
-		LightEgress             int                    `toml:",omitempty"`
-		LightPeers              int                    `toml:",omitempty"`
-		LightNoPrune            bool                   `toml:",omitempty"`
-		LightNoSyncServe        bool                   `toml:",omitempty"`
-		SkipBcVersionCheck      bool                   `toml:"-"`
-		DatabaseHandles         int                    `toml:"-"`
-		DatabaseCache           int
-		DatabaseFreezer         string
-		TrieCleanCache          int
-		TrieDirtyCache          int
-		TrieTimeout             time.Duration
-		SnapshotCache           int
-		Preimages               bool
-		FilterLogCacheSize      int
-		Miner                   miner.Config
-		TxPool                  legacypool.Config
-		BlobPool                blobpool.Config
-		GPO                     gasprice.Config
-		EnablePreimageRecording bool
-		DocRoot                 string `toml:"-"`
-		RPCGasCap               uint64
-		RPCEVMTimeout           time.Duration
-		RPCTxFeeCap             float64
-		OverrideCancun          *uint64 `toml:",omitempty"`
-		OverrideVerkle          *uint64 `toml:",omitempty"`
+		Genesis                   *core.Genesis `toml:",omitempty"`
+		NetworkId                 uint64
+		SyncMode                  downloader.SyncMode
+		EthDiscoveryURLs          []string
+		SnapDiscoveryURLs         []string
+		NoPruning                 bool
+		NoPrefetch                bool
+		TxLookupLimit             uint64                 `toml:",omitempty"`
+		TransactionHistory        uint64                 `toml:",omitempty"`
+		StateHistory              uint64                 `toml:",omitempty"`
+		StateScheme               string                 `toml:",omitempty"`
+		RequiredBlocks            map[uint64]common.Hash `toml:"-"`
+		LightServ                 int                    `toml:",omitempty"`
+		LightIngress              int                    `toml:",omitempty"`
+		LightEgress               int                    `toml:",omitempty"`
+		LightPeers                int                    `toml:",omitempty"`
+		LightNoPrune              bool                   `toml:",omitempty"`
+		LightNoSyncServe          bool                   `toml:",omitempty"`
+		SkipBcVersionCheck        bool                   `toml:"-"`
+		DatabaseHandles           int                    `toml:"-"`
+		DatabaseCache             int
+		DatabaseFreezer           string
+		TrieCleanCache            int
+		TrieDirtyCache            int
+		TrieTimeout               time.Duration
+		SnapshotCache             int
+		Preimages                 bool
+		FilterLogCacheSize        int
+		Miner                     miner.Config
+		ConsensusAuditLog         string `toml:",omitempty"`
+		TxPool                    legacypool.Config
+		BlobPool                  blobpool.Config
+		GPO                       gasprice.Config
+		EnablePreimageRecording   bool
+		DocRoot                   string `toml:"-"`
+		RPCGasCap                 uint64
+		RPCEVMTimeout             time.Duration
+		RPCTxFeeCap               float64
+		RPCVotePayload            string
+		OverrideCancun            *uint64 `toml:",omitempty"`
+		OverrideVerkle            *uint64 `toml:",omitempty"`
+		OverrideZkscamBLS         *uint64 `toml:",omitempty"`
+		OverrideZkscamVoteData    *uint64 `toml:",omitempty"`
+		OverrideZkscamVotePayload *uint64 `toml:",omitempty"`
+		OverrideZkscamVoteDigest  *uint64 `toml:",omitempty"`
 	}
 	var enc Config
 	enc.Genesis = c.Genesis
@@ -87,6 +93,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.Preimages = c.Preimages
 	enc.FilterLogCacheSize = c.FilterLogCacheSize
 	enc.Miner = c.Miner
+	enc.ConsensusAuditLog = c.ConsensusAuditLog
 	enc.TxPool = c.TxPool
 	enc.BlobPool = c.BlobPool
 	enc.GPO = c.GPO
@@ -95,53 +102,64 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.RPCGasCap = c.RPCGasCap
 	enc.RPCEVMTimeout = c.RPCEVMTimeout
 	enc.RPCTxFeeCap = c.RPCTxFeeCap
+	enc.RPCVotePayload = c.RPCVotePayload
 	enc.OverrideCancun = c.OverrideCancun
 	enc.OverrideVerkle = c.OverrideVerkle
+	enc.OverrideZkscamBLS = c.OverrideZkscamBLS
+	enc.OverrideZkscamVoteData = c.OverrideZkscamVoteData
+	enc.OverrideZkscamVotePayload = c.OverrideZkscamVotePayload
+	enc.OverrideZkscamVoteDigest = c.OverrideZkscamVoteDigest
 	return &enc, nil
 }
 
 // UnmarshalTOML unmarshals from TOML.
 func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	type Config struct {
-		Genesis                 *core.Genesis `toml:",omitempty"`
-		NetworkId               *uint64
-		SyncMode                *downloader.SyncMode
-		EthDiscoveryURLs        []string
-		SnapDiscoveryURLs       []string
-		NoPruning               *bool
-		NoPrefetch              *bool
-		TxLookupLimit           *uint64                `toml:",omitempty"`
-		TransactionHistory      *uint64                `toml:",omitempty"`
-		StateHistory            *uint64                `toml:",omitempty"`
-		StateScheme             *string                `toml:",omitempty"`
-		RequiredBlocks          map[uint64]common.Hash `toml:"-"`
-		LightServ               *int                   `toml:",omitempty"`
-		LightIngress            *int                   `toml:",omitempty"`
-		LightEgress             *int                   `toml:",omitempty"`
-		LightPeers              *int                   `toml:",omitempty"`
-		LightNoPrune            *bool                  `toml:",omitempty"`
-		LightNoSyncServe        *bool                  `toml:",omitempty"`
-		SkipBcVersionCheck      *bool                  `toml:"-"`
-		DatabaseHandles         *int                   `toml:"-"`
-		DatabaseCache           *int
-		DatabaseFreezer         *string
-		TrieCleanCache          *int
-		TrieDirtyCache          *int
-		TrieTimeout             *time.Duration
-		SnapshotCache           *int
-		Preimages               *bool
-		FilterLogCacheSize      *int
-		Miner                   *miner.Config
-		TxPool                  *legacypool.Config
-		BlobPool                *blobpool.Config
-		GPO                     *gasprice.Config
-		EnablePreimageRecording *bool
-		DocRoot                 *string `toml:"-"`
-		RPCGasCap               *uint64
-		RPCEVMTimeout           *time.Duration
-		RPCTxFeeCap             *float64
-		OverrideCancun          *uint64 `toml:",omitempty"`
-		OverrideVerkle          *uint64 `toml:",omitempty"`
+		Genesis                   *core.Genesis `toml:",omitempty"`
+		NetworkId                 *uint64
+		SyncMode                  *downloader.SyncMode
+		EthDiscoveryURLs          []string
+		SnapDiscoveryURLs         []string
+		NoPruning                 *bool
+		NoPrefetch                *bool
+		TxLookupLimit             *uint64                `toml:",omitempty"`
+		TransactionHistory        *uint64                `toml:",omitempty"`
+		StateHistory              *uint64                `toml:",omitempty"`
+		StateScheme               *string                `toml:",omitempty"`
+		RequiredBlocks            map[uint64]common.Hash `toml:"-"`
+		LightServ                 *int                   `toml:",omitempty"`
+		LightIngress              *int                   `toml:",omitempty"`
+		LightEgress               *int                   `toml:",omitempty"`
+		LightPeers                *int                   `toml:",omitempty"`
+		LightNoPrune              *bool                  `toml:",omitempty"`
+		LightNoSyncServe          *bool                  `toml:",omitempty"`
+		SkipBcVersionCheck        *bool                  `toml:"-"`
+		DatabaseHandles           *int                   `toml:"-"`
+		DatabaseCache             *int
+		DatabaseFreezer           *string
+		TrieCleanCache            *int
+		TrieDirtyCache            *int
+		TrieTimeout               *time.Duration
+		SnapshotCache             *int
+		Preimages                 *bool
+		FilterLogCacheSize        *int
+		Miner                     *miner.Config
+		ConsensusAuditLog         *string `toml:",omitempty"`
+		TxPool                    *legacypool.Config
+		BlobPool                  *blobpool.Config
+		GPO                       *gasprice.Config
+		EnablePreimageRecording   *bool
+		DocRoot                   *string `toml:"-"`
+		RPCGasCap                 *uint64
+		RPCEVMTimeout             *time.Duration
+		RPCTxFeeCap               *float64
+		RPCVotePayload            *string
+		OverrideCancun            *uint64 `toml:",omitempty"`
+		OverrideVerkle            *uint64 `toml:",omitempty"`
+		OverrideZkscamBLS         *uint64 `toml:",omitempty"`
+		OverrideZkscamVoteData    *uint64 `toml:",omitempty"`
+		OverrideZkscamVotePayload *uint64 `toml:",omitempty"`
+		OverrideZkscamVoteDigest  *uint64 `toml:",omitempty"`
 	}
 	var dec Config
 	if err := unmarshal(&dec); err != nil {
@@ -234,6 +252,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.Miner != nil {
 		c.Miner = *dec.Miner
 	}
+	if dec.ConsensusAuditLog != nil {
+		c.ConsensusAuditLog = *dec.ConsensusAuditLog
+	}
 	if dec.TxPool != nil {
 		c.TxPool = *dec.TxPool
 	}
@@ -258,11 +279,26 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.RPCTxFeeCap != nil {
 		c.RPCTxFeeCap = *dec.RPCTxFeeCap
 	}
+	if dec.RPCVotePayload != nil {
+		c.RPCVotePayload = *dec.RPCVotePayload
+	}
 	if dec.OverrideCancun != nil {
 		c.OverrideCancun = dec.OverrideCancun
 	}
 	if dec.OverrideVerkle != nil {
 		c.OverrideVerkle = dec.OverrideVerkle
 	}
+	if dec.OverrideZkscamBLS != nil {
+		c.OverrideZkscamBLS = dec.OverrideZkscamBLS
+	}
+	if dec.OverrideZkscamVoteData != nil {
+		c.OverrideZkscamVoteData = dec.OverrideZkscamVoteData
+	}
+	if dec.OverrideZkscamVotePayload != nil {
+		c.OverrideZkscamVotePayload = dec.OverrideZkscamVotePayload
+	}
+	if dec.OverrideZkscamVoteDigest != nil {
+		c.OverrideZkscamVoteDigest = dec.OverrideZkscamVoteDigest
+	}
 	return nil
 }
@@ -2,33 +2,60 @@ package fetcher
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/contracts"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	eth2 "github.com/ethereum/go-ethereum/eth/protocols/eth"
 	"github.com/ethereum/go-ethereum/ethclient"
-	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/internal/consensuslog"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/params"
 	single "github.com/ethereum/go-ethereum/singleton"
-	"go.dedis.ch/kyber/v3/pairing/bn256"
-	"go.dedis.ch/kyber/v3/sign/bls"
 	"math/big"
 	"sync"
 	"time"
 )
 
+var (
+	voteInMeter     = metrics.NewRegisteredMeter("eth/fetcher/vote/in", nil)
+	voteKnownMeter  = metrics.NewRegisteredMeter("eth/fetcher/vote/known", nil)
+	voteAcceptMeter = metrics.NewRegisteredMeter("eth/fetcher/vote/accept", nil)
+
+	voteMalformedMeter     = metrics.NewRegisteredMeter("eth/fetcher/vote/reject/malformed", nil)
+	voteSignatureMeter     = metrics.NewRegisteredMeter("eth/fetcher/vote/reject/signature", nil)
+	voteAuthorizationMeter = metrics.NewRegisteredMeter("eth/fetcher/vote/reject/authorization", nil)
+	voteBLSMeter           = metrics.NewRegisteredMeter("eth/fetcher/vote/reject/bls", nil)
+	voteLookupMeter        = metrics.NewRegisteredMeter("eth/fetcher/vote/reject/lookup", nil)
+	voteStakeMeter         = metrics.NewRegisteredMeter("eth/fetcher/vote/reject/stake", nil)
+
+	votePoolGauge     = metrics.NewRegisteredGauge("eth/fetcher/vote/pool", nil)
+	votePoolHistogram = metrics.NewRegisteredHistogram("eth/fetcher/vote/pool/round", nil, metrics.NewExpDecaySample(1028, 0.015))
+)
+
 // VtFetcher manages the fetching process=
 type VtFetcher struct {
 	mu             sync.Mutex
 	votes          map[common.Hash][]*eth2.Vote
 	notifyData     map[common.Hash]notifyEntry
 	erc20          *contracts.ERC20
-	rpcClient      *rpc.Client
+	config         *params.ChainConfig // Chain config selecting the vote digest, nil before the vote digest fork
 	winningBlk     common.Hash
+	tally          map[common.Hash]*big.Int    // Votes per hash as of the last winner determination
+	weights        map[common.Address]*big.Int // Votes per miner as of the last winner determination
 	voteTracker    map[string]struct{}
+	pooled         map[uint64]int // Votes in the pool per height voted at
+	height         uint64         // Highest height voted at, the one of the current slot
 	broadcastVotes func(votes eth2.Votes)
 	blockFetcher   *BlockFetcher // 新增的字段
+
+	voteFeed  event.Feed // Feed of the votes added to the current slot
+	roundFeed event.Feed // Feed of the results of the slots
 }
 
 // notifyEntry defines the structure for storing notification data
@@ -53,6 +80,7 @@ func NewVtFetcher(optionalArgs ...interface{}) *VtFetcher {
 		var (
 			callback     func(votes eth2.Votes)
 			blockFetcher *BlockFetcher
+			config       *params.ChainConfig
 		)
 
 		// 解析可选参数
@@ -62,6 +90,8 @@ func NewVtFetcher(optionalArgs ...interface{}) *VtFetcher {
 				callback = v
 			case *BlockFetcher:
 				blockFetcher = v
+			case *params.ChainConfig:
+				config = v
 			}
 		}
 
@@ -69,18 +99,14 @@ func NewVtFetcher(optionalArgs ...interface{}) *VtFetcher {
 		if callback == nil {
 			callback = func(votes eth2.Votes) {}
 		}
-		// 调用 New 方法获取私钥和地址
-		_, _, err := single.New()
-		if err != nil {
-			fmt.Println("Failed to initialize: %v", err)
-		}
 		// 初始化 VtFetcher 实例
 		instance = &VtFetcher{
 			votes:          make(map[common.Hash][]*eth2.Vote),
 			notifyData:     make(map[common.Hash]notifyEntry),
 			voteTracker:    make(map[string]struct{}),
+			pooled:         make(map[uint64]int),
 			erc20:          erc20,
-			rpcClient:      erc20.Client,
+			config:         config,
 			broadcastVotes: callback,
 			blockFetcher:   blockFetcher, // 使用传入的 blockFetcher
 		}
@@ -122,89 +148,161 @@ func (f *VtFetcher) ClearNotifyData() {
 // ReceiveVotes processes multiple votes
 func (f *VtFetcher) ReceiveVotes(votesData eth2.Votes) error {
 
+	voteInMeter.Mark(int64(len(votesData.Votes)))
+
 	for _, vote := range votesData.Votes {
-		sigPublicKey, err := crypto.SigToPub(vote.BlockHash.Bytes(), vote.Signature)
+		if err := checkVoteFields(&vote); err != nil {
+			consensuslog.Logger().Debug("Discarded malformed vote", "miner", vote.MinerAddress, "err", err)
+			voteMalformedMeter.Mark(1)
+			continue
+		}
+		sigPublicKey, err := crypto.SigToPub(accounts.ZkscamVoteDigest(f.config, vote.Number, vote.BlockHash), vote.Signature)
 		if err != nil {
-			fmt.Println("Error recovering public key:", err)
+			consensuslog.Logger().Debug("Discarded vote with invalid signature", "miner", vote.MinerAddress, "err", err)
+			voteSignatureMeter.Mark(1)
 			continue
 		}
 		// 说明收到了自己发出去的vote了
 		if vote.MinerAddress == single.GetETHAddress() {
+			voteKnownMeter.Mark(1)
 			continue
 		}
 
 		recoveredAddr := crypto.PubkeyToAddress(*sigPublicKey)
 		if recoveredAddr != vote.MinerAddress {
-			fmt.Println("Invalid signature: recovered address does not match miner address")
+			consensuslog.Logger().Debug("Discarded vote signed by another account", "miner", vote.MinerAddress, "signer", recoveredAddr)
+			voteSignatureMeter.Mark(1)
 			continue
 		}
 		pass_sigBLSKey, err := single.VerifyAnyLengthMessageSignatureWithAddress(vote.BLSPublicKey, vote.AuthBLSSignature, vote.MinerAddress)
-		if err != nil {
-			fmt.Println("Error recovering BLSKey:", err)
-			continue
-		}
-		if !pass_sigBLSKey {
+		if err != nil || !pass_sigBLSKey {
+			consensuslog.Logger().Debug("Discarded vote with unauthorized BLS key", "miner", vote.MinerAddress, "err", err)
+			voteAuthorizationMeter.Mark(1)
 			continue
 		}
 		pass_bls, err := single.BLSVerify(vote.BlockHash.Bytes(), vote.BLSSignature, vote.BLSPublicKey)
-		if err != nil {
-			fmt.Println("Error recovering BLSVerify:", err)
-			continue
-		}
-		if !pass_bls {
-			continue
-		}
-
-		if err != nil {
-			fmt.Println("Error adding vote:", err)
+		if err != nil || !pass_bls {
+			consensuslog.Logger().Debug("Discarded vote with invalid BLS signature", "miner", vote.MinerAddress, "err", err)
+			voteBLSMeter.Mark(1)
 			continue
 		}
 		var minBalanceThreshold = big.NewInt(100000)
 		// 验证余额是否满足要求
 		balance, err := f.erc20.BalanceOfMinus10(vote.MinerAddress)
 		if err != nil {
-			fmt.Println("Error retrieving ERC20 balance:", err)
+			consensuslog.Logger().Debug("Failed to retrieve stake of voter", "miner", vote.MinerAddress, "err", err)
+			voteLookupMeter.Mark(1)
 			continue
 		}
 		if balance.Cmp(minBalanceThreshold) < 0 {
-			fmt.Println("Miner does not meet the minimum balance threshold:", vote.MinerAddress.Hex())
+			consensuslog.Logger().Debug("Discarded vote below the stake threshold", "miner", vote.MinerAddress, "stake", balance)
+			voteStakeMeter.Mark(1)
 			continue
 		}
-		err = f.AddVote((*eth2.Vote)(&vote))
+		err = f.AddVote((*eth2.Vote)(&vote), balance)
 	}
 	return nil
 }
 
-// AddVote adds a new vote to the fetcher, ensuring no duplicates
-func (f *VtFetcher) AddVote(vote *eth2.Vote) error {
-	f.mu.Lock()
-	defer f.mu.Unlock()
+// checkVoteFields checks the structure of a vote received from the network
+// before any of its fields is interpreted.
+func checkVoteFields(vote *eth2.Vote) error {
+	switch {
+	case vote.Number == nil:
+		return errors.New("missing block number")
+	case !vote.Number.IsUint64():
+		return fmt.Errorf("invalid block number %v", vote.Number)
+	case len(vote.Signature) != crypto.SignatureLength:
+		return fmt.Errorf("invalid signature length %d", len(vote.Signature))
+	case len(vote.AuthBLSSignature) != crypto.SignatureLength:
+		return fmt.Errorf("invalid BLS authorization length %d", len(vote.AuthBLSSignature))
+	case len(vote.BLSPublicKey) == 0:
+		return errors.New("missing BLS key")
+	case len(vote.BLSSignature) == 0:
+		return errors.New("missing BLS signature")
+	}
+	return nil
+}
 
+// AddVote adds a new vote counting for the given stake to the fetcher, ensuring
+// no duplicates
+func (f *VtFetcher) AddVote(vote *eth2.Vote, stake *big.Int) error {
+	f.mu.Lock()
 	voteKey := fmt.Sprintf("%s-%s", vote.MinerAddress.Hex(), vote.BlockHash.Hex())
 
 	if _, exists := f.voteTracker[voteKey]; exists {
 		// 如果已经存在相同的vote，不再添加
+		f.mu.Unlock()
+		voteKnownMeter.Mark(1)
 		return nil
 	}
 	// 不存在时添加到字典
 	f.voteTracker[voteKey] = struct{}{}
 	f.votes[vote.BlockHash] = append(f.votes[vote.BlockHash], vote)
+	if number := vote.Number.Uint64(); number > f.height {
+		f.height = number
+	}
+	f.pooled[vote.Number.Uint64()]++
+	votePoolGauge.Update(int64(f.poolSize()))
+	f.mu.Unlock()
+
+	voteAcceptMeter.Mark(1)
+
 	//并广播
 	votes := eth2.Votes{Votes: []eth2.Vote{*vote}} // 解引用 vote
 	f.broadcastVotes(votes)
+
+	f.voteFeed.Send(core.NewVoteEvent{Number: vote.Number, Miner: vote.MinerAddress, Hash: vote.BlockHash, Stake: stake})
 	return nil
 }
 
-// DetermineWinner determines the block with the highest total votes from qualified voters
+// SubscribeVotes registers a subscription for the votes added to the current
+// slot.
+func (f *VtFetcher) SubscribeVotes(ch chan<- core.NewVoteEvent) event.Subscription {
+	return f.voteFeed.Subscribe(ch)
+}
+
+// SubscribeRoundResults registers a subscription for the results of the slots
+// concluded by the local miner.
+func (f *VtFetcher) SubscribeRoundResults(ch chan<- core.RoundResultEvent) event.Subscription {
+	return f.roundFeed.Subscribe(ch)
+}
+
+// PostRoundResult publishes the result of the slot at the given height, based
+// on the last winner determination. The block is the one sealed with the votes
+// of the winner, or nil if sealing failed.
+func (f *VtFetcher) PostRoundResult(number *big.Int, block *types.Block) {
+	f.mu.Lock()
+	ev := core.RoundResultEvent{
+		Number:     number,
+		Winner:     f.winningBlk,
+		Candidates: make(map[common.Hash]*big.Int, len(f.tally)),
+		Block:      block,
+	}
+	for hash, votes := range f.tally {
+		ev.Candidates[hash] = votes
+	}
+	f.mu.Unlock()
+
+	f.roundFeed.Send(ev)
+}
+
+// DetermineWinner determines the block with the highest total votes from qualified voters.
+// The result of the previous determination is discarded even if this one fails,
+// so a failed round is never reported with the winner of an earlier one.
 func (f *VtFetcher) DetermineWinner() (common.Hash, error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
+	f.winningBlk, f.tally, f.weights = common.Hash{}, nil, nil
+
 	// 定义一个固定的最小余额门槛，例如 10000
 	minBalance := big.NewInt(100000)
 
 	var maxVotes *big.Int = big.NewInt(0)
 	var winningBlock common.Hash
+	tally := make(map[common.Hash]*big.Int, len(f.votes))
+	weights := make(map[common.Address]*big.Int, len(f.voteTracker))
 
 	for blockHash, votes := range f.votes {
 		totalVotes := big.NewInt(0)
@@ -213,13 +311,15 @@ func (f *VtFetcher) DetermineWinner() (common.Hash, error) {
 			if err != nil {
 				return common.Hash{}, err
 			}
-			fmt.Println("balance", balance, "MinerAddress", vote.MinerAddress)
 			// 过滤掉余额小于 minBalance 的投票者
 			if balance.Cmp(minBalance) >= 0 {
 				totalVotes.Add(totalVotes, balance) // 将投票者的余额累加到总票数中
+				weights[vote.MinerAddress] = balance
+			} else {
+				weights[vote.MinerAddress] = new(big.Int)
 			}
 		}
-		fmt.Println("totalVotes", totalVotes)
+		tally[blockHash] = totalVotes
 		// 找出拥有最多有效投票的区块
 		if totalVotes.Cmp(maxVotes) > 0 {
 			maxVotes = totalVotes
@@ -227,13 +327,62 @@ func (f *VtFetcher) DetermineWinner() (common.Hash, error) {
 		}
 	}
 
+	votePoolHistogram.Update(int64(f.poolSize()))
+
 	f.winningBlk = winningBlock
+	f.tally = tally
+	f.weights = weights
 	return f.winningBlk, nil
 }
 
+// Ballot is a vote of the current slot, weighted by the stake it counted for in
+// the last winner determination.
+type Ballot struct {
+	Miner common.Address
+	Stake *big.Int // Nil if the vote arrived after the last determination
+}
+
+// Ballots returns the votes of the current slot grouped by the hash voted for,
+// weighted as in the last winner determination.
+func (f *VtFetcher) Ballots() map[common.Hash][]Ballot {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ballots := make(map[common.Hash][]Ballot, len(f.votes))
+	for hash, votes := range f.votes {
+		for _, vote := range votes {
+			ballots[hash] = append(ballots[hash], Ballot{Miner: vote.MinerAddress, Stake: f.weights[vote.MinerAddress]})
+		}
+	}
+	return ballots
+}
+
+// Margin returns by how many votes the winner of the last determination beat
+// the strongest competing block, along with the votes cast in total.
+func (f *VtFetcher) Margin() (margin *big.Int, cast *big.Int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var (
+		winner   = new(big.Int)
+		runnerUp = new(big.Int)
+	)
+	cast = new(big.Int)
+	for hash, votes := range f.tally {
+		cast.Add(cast, votes)
+		switch {
+		case hash == f.winningBlk:
+			winner = votes
+		case votes.Cmp(runnerUp) > 0:
+			runnerUp = votes
+		}
+	}
+	return new(big.Int).Sub(winner, runnerUp), cast
+}
+
 // fetchBlockByHash fetches a block by its hash
 func (f *VtFetcher) fetchBlockByHash(blockHash common.Hash) (*types.Block, error) {
-	client := ethclient.NewClient(f.rpcClient)
+	client := ethclient.NewClient(f.erc20.RPCClient())
 	block, err := client.BlockByHash(context.Background(), blockHash)
 	if err != nil {
 		return nil, err
@@ -241,13 +390,23 @@ func (f *VtFetcher) fetchBlockByHash(blockHash common.Hash) (*types.Block, error
 	return block, nil
 }
 
-// ClearVotes clears the votes map and the vote tracker
+// ClearVotes clears the votes of the slot and the result of the last winner
+// determination
 func (f *VtFetcher) ClearVotes() {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
 	f.votes = make(map[common.Hash][]*eth2.Vote)
 	f.voteTracker = make(map[string]struct{})
+	f.pooled = make(map[uint64]int)
+	f.winningBlk, f.tally, f.weights = common.Hash{}, nil, nil
+	votePoolGauge.Update(0)
+}
+
+// poolSize returns the number of votes in the pool for the current slot, not
+// counting the ones left over from earlier heights. The lock must be held.
+func (f *VtFetcher) poolSize() int {
+	return f.pooled[f.height]
 }
 
 // AggregateSignaturesForBlock aggregates all BLS signatures for a specified block hash
@@ -266,15 +425,27 @@ func (f *VtFetcher) AggregateSignaturesForBlock(blockHash common.Hash) ([]byte,
 	for _, vote := range votes {
 		signaturesOrder1 = append(signaturesOrder1, vote.BLSSignature)
 	}
-	suite := bn256.NewSuite()
-	// 使用 bls.AggregateSignatures 方法聚合所有签名
-	aggregatedSignature1, err := bls.AggregateSignatures(suite, signaturesOrder1...)
+	aggregatedSignature1, err := single.BLSAggregate(signaturesOrder1...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to aggregate signatures: %v", err)
 	}
 
 	return aggregatedSignature1, nil
 }
+
+// PendingVotes returns the votes of the current slot, grouped by the hash voted
+// for.
+func (f *VtFetcher) PendingVotes() map[common.Hash][]*eth2.Vote {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	pending := make(map[common.Hash][]*eth2.Vote, len(f.votes))
+	for hash, votes := range f.votes {
+		pending[hash] = append([]*eth2.Vote(nil), votes...)
+	}
+	return pending
+}
+
 func (f *VtFetcher) GetVotesForBlock(blockHash common.Hash) ([]*eth2.Vote, bool) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
@@ -0,0 +1,278 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package fetcher
+
+import (
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/contracts"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	eth2 "github.com/ethereum/go-ethereum/eth/protocols/eth"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Tests that the winning margin is measured against the strongest competitor of
+// the winner, out of all the votes cast in the round.
+func TestVoteMargin(t *testing.T) {
+	var (
+		winner = common.Hash{0x01}
+		second = common.Hash{0x02}
+		third  = common.Hash{0x03}
+	)
+	tests := []struct {
+		tally        map[common.Hash]*big.Int
+		margin, cast int64
+	}{
+		{tally: nil, margin: 0, cast: 0},
+		{tally: map[common.Hash]*big.Int{winner: big.NewInt(500)}, margin: 500, cast: 500},
+		{
+			tally:  map[common.Hash]*big.Int{winner: big.NewInt(500), second: big.NewInt(300), third: big.NewInt(100)},
+			margin: 200, cast: 900,
+		},
+	}
+	for i, tt := range tests {
+		f := &VtFetcher{winningBlk: winner, tally: tt.tally}
+		margin, cast := f.Margin()
+		if margin.Int64() != tt.margin || cast.Int64() != tt.cast {
+			t.Errorf("test %d: margin %v of %v, want %d of %d", i, margin, cast, tt.margin, tt.cast)
+		}
+	}
+}
+
+// Tests that the pool size only counts the votes for the current slot, not the
+// ones left over from earlier heights that concluded without a block.
+func TestVotePoolSize(t *testing.T) {
+	f := &VtFetcher{
+		votes:          make(map[common.Hash][]*eth2.Vote),
+		voteTracker:    make(map[string]struct{}),
+		pooled:         make(map[uint64]int),
+		broadcastVotes: func(eth2.Votes) {},
+	}
+	add := func(number int64, miner byte, hash byte) {
+		f.AddVote(&eth2.Vote{Number: big.NewInt(number), MinerAddress: common.Address{miner}, BlockHash: common.Hash{hash}}, big.NewInt(1))
+	}
+	add(1, 1, 1)
+	add(1, 2, 1)
+	add(1, 3, 2)
+	if size := f.poolSize(); size != 3 {
+		t.Fatalf("pool size mismatch: have %d, want 3", size)
+	}
+	add(2, 1, 3)
+	add(2, 1, 3) // Duplicate
+	if size := f.poolSize(); size != 1 {
+		t.Fatalf("pool size mismatch after advancing: have %d, want 1", size)
+	}
+	add(1, 4, 1) // Late vote for an earlier height
+	if size := f.poolSize(); size != 1 {
+		t.Fatalf("pool size mismatch after late vote: have %d, want 1", size)
+	}
+	f.ClearVotes()
+	if size := f.poolSize(); size != 0 {
+		t.Fatalf("pool size mismatch after clearing: have %d, want 0", size)
+	}
+}
+
+// stakeService serves the chain head and the stake token balances read by the
+// fetcher, failing every balance lookup while fail is set.
+type stakeService struct {
+	stake *big.Int
+	fail  atomic.Bool
+}
+
+func (s *stakeService) GetBlockByNumber(number string, full bool) (*types.Header, error) {
+	return &types.Header{Number: big.NewInt(20), Difficulty: new(big.Int)}, nil
+}
+
+func (s *stakeService) Call(args map[string]interface{}, number string) (hexutil.Bytes, error) {
+	if s.fail.Load() {
+		return nil, errors.New("stake lookup failed")
+	}
+	return common.LeftPadBytes(s.stake.Bytes(), 32), nil
+}
+
+// Tests that the result of a round whose winner determination failed doesn't
+// carry the winner and candidates of the round before.
+func TestRoundResultAfterFailure(t *testing.T) {
+	service := &stakeService{stake: big.NewInt(500_000)}
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", service); err != nil {
+		t.Fatal(err)
+	}
+	defer server.Stop()
+	contracts.UseClient(rpc.DialInProc(server))
+
+	erc20, err := contracts.NewERC20()
+	if err != nil {
+		t.Fatalf("failed to create stake reader: %v", err)
+	}
+	f := &VtFetcher{
+		votes:          make(map[common.Hash][]*eth2.Vote),
+		voteTracker:    make(map[string]struct{}),
+		pooled:         make(map[uint64]int),
+		erc20:          erc20,
+		broadcastVotes: func(eth2.Votes) {},
+	}
+	results := make(chan core.RoundResultEvent, 2)
+	sub := f.SubscribeRoundResults(results)
+	defer sub.Unsubscribe()
+
+	// A successful round reports its winner and candidates
+	first := common.Hash{0x01}
+	f.AddVote(&eth2.Vote{Number: big.NewInt(1), MinerAddress: common.Address{0x01}, BlockHash: first}, service.stake)
+	if winner, err := f.DetermineWinner(); err != nil || winner != first {
+		t.Fatalf("winner mismatch: have %x, %v, want %x", winner, err, first)
+	}
+	f.PostRoundResult(big.NewInt(1), nil)
+	if ev := <-results; ev.Winner != first || ev.Candidates[first] == nil || ev.Candidates[first].Cmp(service.stake) != 0 {
+		t.Fatalf("first round result mismatch: winner %x, candidates %v", ev.Winner, ev.Candidates)
+	}
+	f.ClearVotes()
+
+	// A round failing to look up the stakes reports neither
+	service.fail.Store(true)
+	f.AddVote(&eth2.Vote{Number: big.NewInt(2), MinerAddress: common.Address{0x02}, BlockHash: common.Hash{0x02}}, service.stake)
+	if _, err := f.DetermineWinner(); err == nil {
+		t.Fatalf("winner determined despite failing stake lookups")
+	}
+	f.PostRoundResult(big.NewInt(2), nil)
+	if ev := <-results; ev.Winner != (common.Hash{}) || len(ev.Candidates) != 0 {
+		t.Fatalf("failed round reported stale result: winner %x, candidates %v", ev.Winner, ev.Candidates)
+	}
+	if margin, cast := f.Margin(); margin.Sign() != 0 || cast.Sign() != 0 {
+		t.Fatalf("failed round has a stale margin: %v of %v", margin, cast)
+	}
+}
+
+// Tests that clearing the votes of a slot also forgets its winner.
+func TestClearVotesResetsWinner(t *testing.T) {
+	f := &VtFetcher{winningBlk: common.Hash{0x01}, tally: map[common.Hash]*big.Int{{0x01}: big.NewInt(1)}}
+	f.ClearVotes()
+
+	results := make(chan core.RoundResultEvent, 1)
+	sub := f.SubscribeRoundResults(results)
+	defer sub.Unsubscribe()
+
+	f.PostRoundResult(big.NewInt(2), nil)
+	if ev := <-results; ev.Winner != (common.Hash{}) || len(ev.Candidates) != 0 {
+		t.Fatalf("cleared slot reported stale result: winner %x, candidates %v", ev.Winner, ev.Candidates)
+	}
+}
+
+// Tests that votes received from the network are only interpreted if their
+// fields are well formed.
+func TestCheckVoteFields(t *testing.T) {
+	valid := func() *eth2.Vote {
+		return &eth2.Vote{
+			Number:           big.NewInt(1),
+			Signature:        make([]byte, crypto.SignatureLength),
+			AuthBLSSignature: make([]byte, crypto.SignatureLength),
+			BLSPublicKey:     []byte{1},
+			BLSSignature:     []byte{1},
+		}
+	}
+	if err := checkVoteFields(valid()); err != nil {
+		t.Fatalf("valid vote rejected: %v", err)
+	}
+	tests := map[string]func(v *eth2.Vote){
+		"no number":             func(v *eth2.Vote) { v.Number = nil },
+		"oversized number":      func(v *eth2.Vote) { v.Number = new(big.Int).Lsh(big.NewInt(1), 64) },
+		"short signature":       func(v *eth2.Vote) { v.Signature = v.Signature[:64] },
+		"short authorization":   func(v *eth2.Vote) { v.AuthBLSSignature = v.AuthBLSSignature[:64] },
+		"missing BLS key":       func(v *eth2.Vote) { v.BLSPublicKey = nil },
+		"missing BLS signature": func(v *eth2.Vote) { v.BLSSignature = nil },
+	}
+	for name, mutate := range tests {
+		vote := valid()
+		mutate(vote)
+		if err := checkVoteFields(vote); err == nil {
+			t.Errorf("%s: malformed vote accepted", name)
+		}
+	}
+}
+
+// Tests that peer votes are checked against the raw voted hash before the vote
+// digest fork and against the domain separated vote hash from the fork on.
+func TestReceiveVoteDigest(t *testing.T) {
+	service := &stakeService{stake: big.NewInt(500_000)}
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", service); err != nil {
+		t.Fatal(err)
+	}
+	defer server.Stop()
+	contracts.UseClient(rpc.DialInProc(server))
+
+	erc20, err := contracts.NewERC20()
+	if err != nil {
+		t.Fatalf("failed to create stake reader: %v", err)
+	}
+	key, _ := crypto.GenerateKey()
+	voter, err := core.NewVoter(key, service.stake)
+	if err != nil {
+		t.Fatalf("failed to create voter: %v", err)
+	}
+	config := *params.AllCliqueProtocolChanges
+	config.ZkscamVoteDigestBlock = big.NewInt(10)
+
+	tests := []struct {
+		number uint64
+		digest []byte
+		accept bool
+	}{
+		{9, nil, true},
+		{9, accounts.ZkscamVoteHash(9, common.Hash{9}), false},
+		{10, nil, false},
+		{10, accounts.ZkscamVoteHash(10, common.Hash{10}), true},
+	}
+	for i, tt := range tests {
+		f := &VtFetcher{
+			votes:          make(map[common.Hash][]*eth2.Vote),
+			voteTracker:    make(map[string]struct{}),
+			pooled:         make(map[uint64]int),
+			erc20:          erc20,
+			config:         &config,
+			broadcastVotes: func(eth2.Votes) {},
+		}
+		hash := common.Hash{byte(tt.number)}
+		digest := tt.digest
+		if digest == nil {
+			digest = hash.Bytes()
+		}
+		sig, _ := crypto.Sign(digest, key)
+		blsSig, _ := voter.BLS.Sign(hash.Bytes())
+		f.ReceiveVotes(eth2.Votes{Votes: []eth2.Vote{{
+			Number:           new(big.Int).SetUint64(tt.number),
+			MinerAddress:     voter.BLS.Address,
+			BlockHash:        hash,
+			Signature:        sig,
+			BLSPublicKey:     voter.BLS.PublicKey(),
+			AuthBLSSignature: voter.BLS.Authorization,
+			BLSSignature:     blsSig,
+		}}})
+		if accepted := len(f.votes[hash]) == 1; accepted != tt.accept {
+			t.Errorf("test %d: vote at %d accepted %v, want %v", i, tt.number, accepted, tt.accept)
+		}
+	}
+}
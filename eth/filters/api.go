@@ -22,12 +22,14 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -56,6 +58,8 @@ type filter struct {
 	txs      []*types.Transaction
 	crit     FilterCriteria
 	logs     []*types.Log
+	votes    []core.NewVoteEvent
+	rounds   []core.RoundResultEvent
 	s        *Subscription // associated subscription in event system
 }
 
@@ -253,6 +257,189 @@ func (api *FilterAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
 	return rpcSub, nil
 }
 
+// RPCVote is the representation of a consensus vote in notifications.
+type RPCVote struct {
+	Number *hexutil.Big   `json:"number"`
+	Miner  common.Address `json:"miner"`
+	Hash   common.Hash    `json:"hash"`
+	Stake  *hexutil.Big   `json:"stake"`
+}
+
+func newRPCVote(ev core.NewVoteEvent) *RPCVote {
+	return &RPCVote{
+		Number: (*hexutil.Big)(ev.Number),
+		Miner:  ev.Miner,
+		Hash:   ev.Hash,
+		Stake:  (*hexutil.Big)(ev.Stake),
+	}
+}
+
+// RPCRoundCandidate is a hash voted for in a consensus slot and its votes.
+type RPCRoundCandidate struct {
+	Hash  common.Hash  `json:"hash"`
+	Votes *hexutil.Big `json:"votes"`
+}
+
+// RPCRoundResult is the representation of the result of a consensus slot in
+// notifications. The candidates are ordered by votes, most voted first.
+type RPCRoundResult struct {
+	Number     *hexutil.Big           `json:"number"`
+	Winner     common.Hash            `json:"winner"`
+	Candidates []RPCRoundCandidate    `json:"candidates"`
+	Block      map[string]interface{} `json:"block"`
+}
+
+func (api *FilterAPI) newRPCRoundResult(ev core.RoundResultEvent) *RPCRoundResult {
+	result := &RPCRoundResult{
+		Number:     (*hexutil.Big)(ev.Number),
+		Winner:     ev.Winner,
+		Candidates: make([]RPCRoundCandidate, 0, len(ev.Candidates)),
+	}
+	for hash, votes := range ev.Candidates {
+		result.Candidates = append(result.Candidates, RPCRoundCandidate{Hash: hash, Votes: (*hexutil.Big)(votes)})
+	}
+	sort.Slice(result.Candidates, func(i, j int) bool {
+		a, b := result.Candidates[i], result.Candidates[j]
+		if cmp := a.Votes.ToInt().Cmp(b.Votes.ToInt()); cmp != 0 {
+			return cmp > 0
+		}
+		return a.Hash.Cmp(b.Hash) < 0
+	})
+	if ev.Block != nil {
+		result.Block = ethapi.RPCMarshalBlock(ev.Block, true, false, api.sys.backend.ChainConfig())
+	}
+	return result
+}
+
+// NewVoteFilter creates a filter that fetches the consensus votes added to the
+// current slot. It is part of the filter package since polling goes with
+// eth_getFilterChanges.
+func (api *FilterAPI) NewVoteFilter() rpc.ID {
+	var (
+		votes   = make(chan core.NewVoteEvent)
+		voteSub = api.events.SubscribeVotes(votes)
+	)
+
+	api.filtersMu.Lock()
+	api.filters[voteSub.ID] = &filter{typ: VotesSubscription, deadline: time.NewTimer(api.timeout), s: voteSub}
+	api.filtersMu.Unlock()
+
+	go func() {
+		for {
+			select {
+			case ev := <-votes:
+				api.filtersMu.Lock()
+				if f, found := api.filters[voteSub.ID]; found {
+					f.votes = append(f.votes, ev)
+				}
+				api.filtersMu.Unlock()
+			case <-voteSub.Err():
+				api.filtersMu.Lock()
+				delete(api.filters, voteSub.ID)
+				api.filtersMu.Unlock()
+				return
+			}
+		}
+	}()
+
+	return voteSub.ID
+}
+
+// Votes creates a subscription that fires for every verified consensus vote
+// added to the current slot, including the ones of the local miner.
+func (api *FilterAPI) Votes(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		votes := make(chan core.NewVoteEvent, voteChanSize)
+		voteSub := api.events.SubscribeVotes(votes)
+		defer voteSub.Unsubscribe()
+
+		for {
+			select {
+			case ev := <-votes:
+				notifier.Notify(rpcSub.ID, newRPCVote(ev))
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// NewRoundResultFilter creates a filter that fetches the results of the
+// consensus slots concluded by the local miner. It is part of the filter
+// package since polling goes with eth_getFilterChanges.
+func (api *FilterAPI) NewRoundResultFilter() rpc.ID {
+	var (
+		rounds   = make(chan core.RoundResultEvent)
+		roundSub = api.events.SubscribeRoundResults(rounds)
+	)
+
+	api.filtersMu.Lock()
+	api.filters[roundSub.ID] = &filter{typ: RoundResultsSubscription, deadline: time.NewTimer(api.timeout), s: roundSub}
+	api.filtersMu.Unlock()
+
+	go func() {
+		for {
+			select {
+			case ev := <-rounds:
+				api.filtersMu.Lock()
+				if f, found := api.filters[roundSub.ID]; found {
+					f.rounds = append(f.rounds, ev)
+				}
+				api.filtersMu.Unlock()
+			case <-roundSub.Err():
+				api.filtersMu.Lock()
+				delete(api.filters, roundSub.ID)
+				api.filtersMu.Unlock()
+				return
+			}
+		}
+	}()
+
+	return roundSub.ID
+}
+
+// RoundResults creates a subscription that fires after every consensus slot
+// concluded by the local miner, with the winning hash, the votes of every
+// candidate and the sealed block. The block is null if sealing failed.
+func (api *FilterAPI) RoundResults(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		rounds := make(chan core.RoundResultEvent, roundChanSize)
+		roundSub := api.events.SubscribeRoundResults(rounds)
+		defer roundSub.Unsubscribe()
+
+		for {
+			select {
+			case ev := <-rounds:
+				notifier.Notify(rpcSub.ID, api.newRPCRoundResult(ev))
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
 // Logs creates a subscription that fires for all new log that match the given filter criteria.
 func (api *FilterAPI) Logs(ctx context.Context, crit FilterCriteria) (*rpc.Subscription, error) {
 	notifier, supported := rpc.NotifierFromContext(ctx)
@@ -466,6 +653,20 @@ func (api *FilterAPI) GetFilterChanges(id rpc.ID) (interface{}, error) {
 			logs := f.logs
 			f.logs = nil
 			return returnLogs(logs), nil
+		case VotesSubscription:
+			votes := make([]*RPCVote, 0, len(f.votes))
+			for _, ev := range f.votes {
+				votes = append(votes, newRPCVote(ev))
+			}
+			f.votes = nil
+			return votes, nil
+		case RoundResultsSubscription:
+			rounds := make([]*RPCRoundResult, 0, len(f.rounds))
+			for _, ev := range f.rounds {
+				rounds = append(rounds, api.newRPCRoundResult(ev))
+			}
+			f.rounds = nil
+			return rounds, nil
 		}
 	}
 
@@ -71,6 +71,8 @@ type Backend interface {
 	SubscribeRemovedLogsEvent(ch chan<- core.RemovedLogsEvent) event.Subscription
 	SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscription
 	SubscribePendingLogsEvent(ch chan<- []*types.Log) event.Subscription
+	SubscribeNewVoteEvent(ch chan<- core.NewVoteEvent) event.Subscription
+	SubscribeRoundResultEvent(ch chan<- core.RoundResultEvent) event.Subscription
 
 	BloomStatus() (uint64, uint64)
 	ServiceFilter(ctx context.Context, session *bloombits.MatcherSession)
@@ -161,6 +163,10 @@ const (
 	PendingTransactionsSubscription
 	// BlocksSubscription queries hashes for blocks that are imported
 	BlocksSubscription
+	// VotesSubscription queries for consensus votes added to the current slot
+	VotesSubscription
+	// RoundResultsSubscription queries for the results of the consensus slots
+	RoundResultsSubscription
 	// LastIndexSubscription keeps track of the last index
 	LastIndexSubscription
 )
@@ -175,6 +181,10 @@ const (
 	logsChanSize = 10
 	// chainEvChanSize is the size of channel listening to ChainEvent.
 	chainEvChanSize = 10
+	// voteChanSize is the size of channel listening to NewVoteEvent.
+	voteChanSize = 128
+	// roundChanSize is the size of channel listening to RoundResultEvent.
+	roundChanSize = 10
 )
 
 type subscription struct {
@@ -185,6 +195,8 @@ type subscription struct {
 	logs      chan []*types.Log
 	txs       chan []*types.Transaction
 	headers   chan *types.Header
+	votes     chan core.NewVoteEvent
+	rounds    chan core.RoundResultEvent
 	installed chan struct{} // closed when the filter is installed
 	err       chan error    // closed when the filter is uninstalled
 }
@@ -203,6 +215,8 @@ type EventSystem struct {
 	rmLogsSub      event.Subscription // Subscription for removed log event
 	pendingLogsSub event.Subscription // Subscription for pending log event
 	chainSub       event.Subscription // Subscription for new chain event
+	voteSub        event.Subscription // Subscription for new vote event
+	roundSub       event.Subscription // Subscription for round result event
 
 	// Channels
 	install       chan *subscription         // install filter for event notification
@@ -212,6 +226,8 @@ type EventSystem struct {
 	pendingLogsCh chan []*types.Log          // Channel to receive new log event
 	rmLogsCh      chan core.RemovedLogsEvent // Channel to receive removed log event
 	chainCh       chan core.ChainEvent       // Channel to receive new chain event
+	voteCh        chan core.NewVoteEvent     // Channel to receive new vote event
+	roundCh       chan core.RoundResultEvent // Channel to receive round result event
 }
 
 // NewEventSystem creates a new manager that listens for event on the given mux,
@@ -232,6 +248,8 @@ func NewEventSystem(sys *FilterSystem, lightMode bool) *EventSystem {
 		rmLogsCh:      make(chan core.RemovedLogsEvent, rmLogsChanSize),
 		pendingLogsCh: make(chan []*types.Log, logsChanSize),
 		chainCh:       make(chan core.ChainEvent, chainEvChanSize),
+		voteCh:        make(chan core.NewVoteEvent, voteChanSize),
+		roundCh:       make(chan core.RoundResultEvent, roundChanSize),
 	}
 
 	// Subscribe events
@@ -240,9 +258,12 @@ func NewEventSystem(sys *FilterSystem, lightMode bool) *EventSystem {
 	m.rmLogsSub = m.backend.SubscribeRemovedLogsEvent(m.rmLogsCh)
 	m.chainSub = m.backend.SubscribeChainEvent(m.chainCh)
 	m.pendingLogsSub = m.backend.SubscribePendingLogsEvent(m.pendingLogsCh)
+	m.voteSub = m.backend.SubscribeNewVoteEvent(m.voteCh)
+	m.roundSub = m.backend.SubscribeRoundResultEvent(m.roundCh)
 
 	// Make sure none of the subscriptions are empty
-	if m.txsSub == nil || m.logsSub == nil || m.rmLogsSub == nil || m.chainSub == nil || m.pendingLogsSub == nil {
+	if m.txsSub == nil || m.logsSub == nil || m.rmLogsSub == nil || m.chainSub == nil || m.pendingLogsSub == nil ||
+		m.voteSub == nil || m.roundSub == nil {
 		log.Crit("Subscribe for event system failed")
 	}
 
@@ -278,6 +299,8 @@ func (sub *Subscription) Unsubscribe() {
 			case <-sub.f.logs:
 			case <-sub.f.txs:
 			case <-sub.f.headers:
+			case <-sub.f.votes:
+			case <-sub.f.rounds:
 			}
 		}
 
@@ -420,6 +443,42 @@ func (es *EventSystem) SubscribePendingTxs(txs chan []*types.Transaction) *Subsc
 	return es.subscribe(sub)
 }
 
+// SubscribeVotes creates a subscription that writes the consensus votes added
+// to the current slot.
+func (es *EventSystem) SubscribeVotes(votes chan core.NewVoteEvent) *Subscription {
+	sub := &subscription{
+		id:        rpc.NewID(),
+		typ:       VotesSubscription,
+		created:   time.Now(),
+		logs:      make(chan []*types.Log),
+		txs:       make(chan []*types.Transaction),
+		headers:   make(chan *types.Header),
+		votes:     votes,
+		rounds:    make(chan core.RoundResultEvent),
+		installed: make(chan struct{}),
+		err:       make(chan error),
+	}
+	return es.subscribe(sub)
+}
+
+// SubscribeRoundResults creates a subscription that writes the results of the
+// consensus slots concluded by the local miner.
+func (es *EventSystem) SubscribeRoundResults(rounds chan core.RoundResultEvent) *Subscription {
+	sub := &subscription{
+		id:        rpc.NewID(),
+		typ:       RoundResultsSubscription,
+		created:   time.Now(),
+		logs:      make(chan []*types.Log),
+		txs:       make(chan []*types.Transaction),
+		headers:   make(chan *types.Header),
+		votes:     make(chan core.NewVoteEvent),
+		rounds:    rounds,
+		installed: make(chan struct{}),
+		err:       make(chan error),
+	}
+	return es.subscribe(sub)
+}
+
 type filterIndex map[Type]map[rpc.ID]*subscription
 
 func (es *EventSystem) handleLogs(filters filterIndex, ev []*types.Log) {
@@ -452,6 +511,18 @@ func (es *EventSystem) handleTxsEvent(filters filterIndex, ev core.NewTxsEvent)
 	}
 }
 
+func (es *EventSystem) handleVoteEvent(filters filterIndex, ev core.NewVoteEvent) {
+	for _, f := range filters[VotesSubscription] {
+		f.votes <- ev
+	}
+}
+
+func (es *EventSystem) handleRoundResultEvent(filters filterIndex, ev core.RoundResultEvent) {
+	for _, f := range filters[RoundResultsSubscription] {
+		f.rounds <- ev
+	}
+}
+
 func (es *EventSystem) handleChainEvent(filters filterIndex, ev core.ChainEvent) {
 	for _, f := range filters[BlocksSubscription] {
 		f.headers <- ev.Block.Header()
@@ -552,6 +623,8 @@ func (es *EventSystem) eventLoop() {
 		es.rmLogsSub.Unsubscribe()
 		es.pendingLogsSub.Unsubscribe()
 		es.chainSub.Unsubscribe()
+		es.voteSub.Unsubscribe()
+		es.roundSub.Unsubscribe()
 	}()
 
 	index := make(filterIndex)
@@ -571,6 +644,10 @@ func (es *EventSystem) eventLoop() {
 			es.handlePendingLogs(index, ev)
 		case ev := <-es.chainCh:
 			es.handleChainEvent(index, ev)
+		case ev := <-es.voteCh:
+			es.handleVoteEvent(index, ev)
+		case ev := <-es.roundCh:
+			es.handleRoundResultEvent(index, ev)
 
 		case f := <-es.install:
 			if f.typ == MinedAndPendingLogsSubscription {
@@ -601,6 +678,10 @@ func (es *EventSystem) eventLoop() {
 			return
 		case <-es.chainSub.Err():
 			return
+		case <-es.voteSub.Err():
+			return
+		case <-es.roundSub.Err():
+			return
 		}
 	}
 }
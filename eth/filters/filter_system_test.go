@@ -50,6 +50,8 @@ type testBackend struct {
 	rmLogsFeed      event.Feed
 	pendingLogsFeed event.Feed
 	chainFeed       event.Feed
+	voteFeed        event.Feed
+	roundFeed       event.Feed
 	pendingBlock    *types.Block
 	pendingReceipts types.Receipts
 }
@@ -149,6 +151,14 @@ func (b *testBackend) SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subsc
 	return b.chainFeed.Subscribe(ch)
 }
 
+func (b *testBackend) SubscribeNewVoteEvent(ch chan<- core.NewVoteEvent) event.Subscription {
+	return b.voteFeed.Subscribe(ch)
+}
+
+func (b *testBackend) SubscribeRoundResultEvent(ch chan<- core.RoundResultEvent) event.Subscription {
+	return b.roundFeed.Subscribe(ch)
+}
+
 func (b *testBackend) BloomStatus() (uint64, uint64) {
 	return params.BloomBitsBlocks, b.sections
 }
@@ -301,6 +311,76 @@ func TestPendingTxFilter(t *testing.T) {
 	}
 }
 
+// TestVoteFilter tests whether vote filters retrieve all votes posted by the
+// backend, and round result filters order the candidates by votes.
+func TestVoteFilter(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db           = rawdb.NewMemoryDatabase()
+		backend, sys = newTestFilterSystem(t, db, Config{})
+		api          = NewFilterAPI(sys, false)
+
+		votes = []core.NewVoteEvent{
+			{Number: big.NewInt(1), Miner: common.Address{0x1}, Hash: common.Hash{0xa}, Stake: big.NewInt(100000)},
+			{Number: big.NewInt(1), Miner: common.Address{0x2}, Hash: common.Hash{0xb}, Stake: big.NewInt(200000)},
+		}
+		round = core.RoundResultEvent{
+			Number:     big.NewInt(1),
+			Winner:     common.Hash{0xb},
+			Candidates: map[common.Hash]*big.Int{{0xa}: big.NewInt(100000), {0xb}: big.NewInt(200000)},
+		}
+	)
+	voteID := api.NewVoteFilter()
+	roundID := api.NewRoundResultFilter()
+
+	time.Sleep(1 * time.Second)
+	for _, vote := range votes {
+		backend.voteFeed.Send(vote)
+	}
+	backend.roundFeed.Send(round)
+
+	var (
+		have    []*RPCVote
+		rounds  []*RPCRoundResult
+		timeout = time.Now().Add(1 * time.Second)
+	)
+	for (len(have) < len(votes) || len(rounds) < 1) && time.Now().Before(timeout) {
+		results, err := api.GetFilterChanges(voteID)
+		if err != nil {
+			t.Fatalf("Unable to retrieve votes: %v", err)
+		}
+		have = append(have, results.([]*RPCVote)...)
+
+		if results, err = api.GetFilterChanges(roundID); err != nil {
+			t.Fatalf("Unable to retrieve round results: %v", err)
+		}
+		rounds = append(rounds, results.([]*RPCRoundResult)...)
+
+		time.Sleep(100 * time.Millisecond)
+	}
+	if len(have) != len(votes) {
+		t.Fatalf("invalid number of votes, want %d, got %d", len(votes), len(have))
+	}
+	for i, vote := range have {
+		if vote.Miner != votes[i].Miner || vote.Hash != votes[i].Hash || vote.Stake.ToInt().Cmp(votes[i].Stake) != 0 {
+			t.Errorf("vote %d mismatch: have %+v, want %+v", i, vote, votes[i])
+		}
+	}
+	if len(rounds) != 1 {
+		t.Fatalf("invalid number of round results, want 1, got %d", len(rounds))
+	}
+	if rounds[0].Winner != round.Winner || len(rounds[0].Candidates) != 2 {
+		t.Fatalf("round result mismatch: %+v", rounds[0])
+	}
+	if rounds[0].Candidates[0].Hash != round.Winner {
+		t.Errorf("candidates not ordered by votes: %+v", rounds[0].Candidates)
+	}
+	if rounds[0].Block != nil {
+		t.Errorf("unexpected block in failed round: %v", rounds[0].Block)
+	}
+}
+
 // TestPendingTxFilterFullTx tests whether pending tx filters retrieve all pending transactions that are posted to the event mux.
 func TestPendingTxFilterFullTx(t *testing.T) {
 	t.Parallel()
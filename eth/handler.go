@@ -300,7 +300,7 @@ func newHandler(config *handlerConfig) (*handler, error) {
 		return h.chain.InsertChain(blocks)
 	}
 	h.blockFetcher = fetcher.NewBlockFetcher(false, nil, h.chain.GetBlockByHash, validator, h.BroadcastBlock, heighter, nil, inserter, h.removePeer)
-	h.vtFetcher = fetcher.NewVtFetcher(h.blockFetcher, h.BroadcastVotes)
+	h.vtFetcher = fetcher.NewVtFetcher(h.blockFetcher, h.BroadcastVotes, h.chain.Config())
 	fetchTx := func(peer string, hashes []common.Hash) error {
 		p := h.peers.peer(peer)
 		if p == nil {
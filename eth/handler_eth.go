@@ -74,6 +74,7 @@ func (h *ethHandler) Handle(peer *eth.Peer, packet eth.Packet) error {
 		return h.handleBlockBroadcast(peer, packet.Block, packet.TD)
 
 	case *eth.Votes:
+		peer.CountVotes(len(packet.Votes))
 		votesData := eth.Votes{Votes: packet.Votes}
 		return h.vtFetcher.ReceiveVotes(votesData)
 
@@ -136,13 +137,8 @@ func (h *ethHandler) handleBlockBroadcast(peer *eth.Peer, block *types.Block, td
 		totalVotes = block.TotalVotes()
 	)
 	// Update the peer's total difficulty if better than the previous
-	log.Info(
-		"peer.TotalVotes; totalVotes",
-		peer.TotalVotes.String(),
-		totalVotes.String(),
-	)
 	if _, td := peer.Head(); totalVotes.Cmp(td) > 0 && h.chainSync.doneCh == nil {
-		log.Info("if _, td := peer.Head(); totalVotes.Cmp(td) > 0", td.String(), totalVotes.String())
+		log.Debug("Updated peer head from announced block", "peer", peer.ID(), "number", block.Number(), "votes", totalVotes, "previous", td)
 		peer.SetHead(trueHead, trueTD, totalVotes)
 		h.chainSync.handlePeerEvent()
 	}
@@ -17,6 +17,7 @@
 package simulated
 
 import (
+	"errors"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
@@ -64,6 +65,7 @@ type simClient struct {
 type Backend struct {
 	eth    *eth.Ethereum
 	beacon *catalyst.SimulatedBeacon
+	voting *voteSealer // Seals the blocks instead of the beacon on vote-based chains
 	client simClient
 }
 
@@ -119,6 +121,18 @@ func newWithNode(stack *node.Node, conf *eth.Config, blockPeriod uint64) (*Backe
 	if err := stack.Start(); err != nil {
 		return nil, err
 	}
+	// Vote-based chains are sealed by their voters instead of a beacon client
+	if conf.Genesis != nil && conf.Genesis.Config.Clique != nil {
+		voting, err := newVoteSealer(backend, conf.Genesis, stack.Attach())
+		if err != nil {
+			return nil, err
+		}
+		return &Backend{
+			eth:    backend,
+			voting: voting,
+			client: simClient{ethclient.NewClient(stack.Attach())},
+		}, nil
+	}
 	// Set up the simulated beacon
 	beacon, err := catalyst.NewSimulatedBeacon(blockPeriod, backend)
 	if err != nil {
@@ -152,11 +166,18 @@ func (n *Backend) Close() error {
 
 // Commit seals a block and moves the chain forward to a new empty block.
 func (n *Backend) Commit() common.Hash {
+	if n.voting != nil {
+		return n.voting.Commit()
+	}
 	return n.beacon.Commit()
 }
 
 // Rollback removes all pending transactions, reverting to the last committed state.
 func (n *Backend) Rollback() {
+	if n.voting != nil {
+		n.voting.Rollback()
+		return
+	}
 	n.beacon.Rollback()
 }
 
@@ -173,12 +194,18 @@ func (n *Backend) Rollback() {
 // There is a % chance that the side chain becomes canonical at the same length
 // to simulate live network behavior.
 func (n *Backend) Fork(parentHash common.Hash) error {
+	if n.voting != nil {
+		return n.voting.Fork(parentHash)
+	}
 	return n.beacon.Fork(parentHash)
 }
 
 // AdjustTime changes the block timestamp and creates a new block.
 // It can only be called on empty blocks.
 func (n *Backend) AdjustTime(adjustment time.Duration) error {
+	if n.voting != nil {
+		return errors.New("time cannot be adjusted on the vote-based consensus")
+	}
 	return n.beacon.AdjustTime(adjustment)
 }
 
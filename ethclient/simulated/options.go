@@ -19,8 +19,14 @@ package simulated
 import (
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/clique"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
 )
 
 // WithBlockGasLimit configures the simulated backend to target a specific gas limit
@@ -53,3 +59,49 @@ func WithMinerMinTip(tip *big.Int) func(nodeConf *node.Config, ethConf *ethconfi
 		ethConf.Miner.GasPrice = tip
 	}
 }
+
+// WithVoteConsensus configures the simulated backend to run on the vote-based
+// clique engine instead of the simulated beacon, with the given number of
+// pre-generated voters staked with DefaultStake. Committed blocks are fully
+// signed and carry the aggregated votes of the online voters, and the gas fees
+// are distributed to them like on the live network.
+//
+// Time cannot be adjusted on such a backend, the engine stamps the blocks with
+// the current time.
+func WithVoteConsensus(voters int) func(nodeConf *node.Config, ethConf *ethconfig.Config) {
+	if voters < 1 {
+		panic("invalid number of voters")
+	}
+	return func(nodeConf *node.Config, ethConf *ethconfig.Config) {
+		config := *params.AllCliqueProtocolChanges
+		config.Clique = &params.CliqueConfig{Epoch: config.Clique.Epoch}
+
+		genesis := ethConf.Genesis
+		genesis.Config = &config
+		genesis.BaseFee = big.NewInt(params.InitialBaseFee)
+		genesis.Difficulty = big.NewInt(1)
+		if genesis.Alloc == nil {
+			genesis.Alloc = make(types.GenesisAlloc)
+		}
+		var (
+			miners = make([]common.Address, voters)
+			stakes = map[common.Address]*big.Int{stakeTreasury: stakeReserve}
+			votes  = new(big.Int)
+		)
+		for i, key := range clique.DeveloperVoterKeys(voters) {
+			miners[i] = crypto.PubkeyToAddress(key.PublicKey)
+			stakes[miners[i]] = DefaultStake
+			votes.Add(votes, DefaultStake)
+		}
+		for _, account := range append([]common.Address{stakeTreasury}, miners...) {
+			if _, ok := genesis.Alloc[account]; !ok {
+				genesis.Alloc[account] = types.Account{Balance: voterFunds}
+			}
+		}
+		genesis.Alloc[params.ZkscamTokenAddress], _ = core.ZkscamStakeToken(stakes)
+		genesis.ExtraData = append(append(make([]byte, 32), miners[0][:]...), make([]byte, crypto.SignatureLength)...)
+		genesis.MinerAddresses = miners
+		genesis.Votes = votes
+		genesis.TotalVotes = new(big.Int).Set(votes)
+	}
+}
@@ -0,0 +1,298 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package simulated
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/clique"
+	"github.com/ethereum/go-ethereum/contracts"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	// DefaultStake is the stake token balance of every voter in the genesis of
+	// a simulated backend running the vote-based consensus.
+	DefaultStake = new(big.Int).Mul(big.NewInt(1000), big.NewInt(params.Ether))
+
+	// stakeReserve is the stake token balance of the treasury, which the stakes
+	// of the voters are adjusted from.
+	stakeReserve = new(big.Int).Mul(big.NewInt(1_000_000), big.NewInt(params.Ether))
+
+	// voterFunds is the ether balance of the voters and the treasury, covering
+	// the fees of the stake transfers.
+	voterFunds = new(big.Int).Mul(big.NewInt(1000), big.NewInt(params.Ether))
+
+	// stakeTreasuryKey holds the stake token reserve.
+	stakeTreasuryKey, _ = crypto.ToECDSA(crypto.Keccak256([]byte("zkscam simulated stake treasury")))
+	stakeTreasury       = crypto.PubkeyToAddress(stakeTreasuryKey.PublicKey)
+
+	// sealTimeout is the maximum time to wait for the engine to seal a block.
+	sealTimeout = 10 * time.Second
+
+	errNoVoteConsensus = errors.New("simulated backend does not run the vote-based consensus")
+)
+
+// voteSealer produces the blocks of a simulated backend running the vote-based
+// clique engine. Blocks are sealed on demand with the votes of in-process voters,
+// the first of them being the local miner.
+//
+// The engine reads the stakes and signs with process wide singletons, so only
+// a single such backend may be used at a time.
+type voteSealer struct {
+	eth    *eth.Ethereum
+	engine *clique.Clique
+	voters []*ecdsa.PrivateKey
+}
+
+// newVoteSealer sets up the vote-based engine of the given backend to seal with
+// the voters of the genesis, whose stake reads are served by client.
+func newVoteSealer(backend *eth.Ethereum, genesis *core.Genesis, client *rpc.Client) (*voteSealer, error) {
+	engine := backend.Engine()
+	if b, ok := engine.(*beacon.Beacon); ok {
+		engine = b.InnerEngine()
+	}
+	c, ok := engine.(*clique.Clique)
+	if !ok {
+		return nil, fmt.Errorf("vote-based consensus requires the clique engine, have %T", engine)
+	}
+	if len(genesis.MinerAddresses) == 0 {
+		return nil, errors.New("genesis has no voters")
+	}
+	voters := clique.DeveloperVoterKeys(len(genesis.MinerAddresses))
+	for i, key := range voters {
+		if addr := crypto.PubkeyToAddress(key.PublicKey); addr != genesis.MinerAddresses[i] {
+			return nil, fmt.Errorf("genesis voter %d is %s, not the simulated voter %s", i, genesis.MinerAddresses[i].Hex(), addr.Hex())
+		}
+	}
+	contracts.UseClient(client)
+
+	if err := c.AuthorizeKey(voters[0]); err != nil {
+		return nil, err
+	}
+	if err := c.EnableDevMode(voters[1:]); err != nil {
+		return nil, err
+	}
+	c.SetDevSealEmpty(true)
+
+	return &voteSealer{eth: backend, engine: c, voters: voters}, nil
+}
+
+// Commit seals a block with the votes of the online voters and moves the chain
+// forward.
+func (s *voteSealer) Commit() common.Hash {
+	if err := s.sealBlock(); err != nil {
+		log.Warn("Error performing sealing work", "err", err)
+	}
+	return s.eth.BlockChain().CurrentBlock().Hash()
+}
+
+// sealBlock assembles a block out of the pending transactions, has the engine
+// collect and aggregate the votes on it, then imports it like a block received
+// from the network.
+func (s *voteSealer) sealBlock() error {
+	if err := s.eth.TxPool().Sync(); err != nil {
+		return err
+	}
+	miner := crypto.PubkeyToAddress(s.voters[0].PublicKey)
+	block, err := s.eth.Miner().BuildBlock(miner, uint64(time.Now().Unix()))
+	if err != nil {
+		return err
+	}
+	var (
+		results = make(chan *types.Block, 1)
+		stop    = make(chan struct{})
+	)
+	defer close(stop)
+
+	if err := s.engine.Seal(s.eth.BlockChain(), block, results, stop); err != nil {
+		return err
+	}
+	var sealed *types.Block
+	select {
+	case sealed = <-results:
+	case <-time.After(sealTimeout):
+		return errors.New("timed out sealing block")
+	}
+	if sealed == nil {
+		return errors.New("engine failed to seal block")
+	}
+	_, err = s.eth.BlockChain().InsertChain(types.Blocks{sealed})
+	return err
+}
+
+// Rollback un-sends previously added transactions.
+func (s *voteSealer) Rollback() {
+	// Flush all transactions from the transaction pools
+	maxUint256 := new(big.Int).Sub(new(big.Int).Lsh(common.Big1, 256), common.Big1)
+	s.eth.TxPool().SetGasTip(maxUint256)
+	// Set the gas tip back to accept new transactions
+	s.eth.TxPool().SetGasTip(big.NewInt(params.GWei))
+}
+
+// Fork sets the head to the provided hash.
+func (s *voteSealer) Fork(parentHash common.Hash) error {
+	if len(s.eth.TxPool().Pending(txpool.PendingFilter{})) != 0 {
+		return errors.New("pending block dirty")
+	}
+	parent := s.eth.BlockChain().GetBlockByHash(parentHash)
+	if parent == nil {
+		return errors.New("parent not found")
+	}
+	return s.eth.BlockChain().SetHead(parent.NumberU64())
+}
+
+// voterKey returns the key of the given voter, or nil if it is not one.
+func (s *voteSealer) voterKey(voter common.Address) *ecdsa.PrivateKey {
+	for _, key := range s.voters {
+		if crypto.PubkeyToAddress(key.PublicKey) == voter {
+			return key
+		}
+	}
+	return nil
+}
+
+// Voters returns the addresses of the voters of a simulated backend running the
+// vote-based consensus, the first one being the miner sealing the blocks.
+func (n *Backend) Voters() []common.Address {
+	if n.voting == nil {
+		return nil
+	}
+	voters := make([]common.Address, len(n.voting.voters))
+	for i, key := range n.voting.voters {
+		voters[i] = crypto.PubkeyToAddress(key.PublicKey)
+	}
+	return voters
+}
+
+// SetVoterOnline sets whether the given voter votes on the blocks committed from
+// now on, simulating missing voters. The miner sealing the blocks always votes.
+func (n *Backend) SetVoterOnline(voter common.Address, online bool) error {
+	if n.voting == nil {
+		return errNoVoteConsensus
+	}
+	if n.voting.voterKey(voter) == nil {
+		return fmt.Errorf("unknown voter %s", voter.Hex())
+	}
+	if voter == crypto.PubkeyToAddress(n.voting.voters[0].PublicKey) {
+		return errors.New("the sealing miner cannot be offline")
+	}
+	return n.voting.engine.SetDevVoterOnline(voter, online)
+}
+
+// Stake returns the stake token balance of the given account at the head block.
+func (n *Backend) Stake(account common.Address) (*big.Int, error) {
+	data := append([]byte{0x70, 0xa0, 0x82, 0x31}, common.LeftPadBytes(account.Bytes(), 32)...) // balanceOf(address)
+	out, err := n.client.CallContract(context.Background(), ethereum.CallMsg{To: &params.ZkscamTokenAddress, Data: data}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(out), nil
+}
+
+// SetStake sets the stake of the given voter by committing a block transferring
+// stake tokens between it and the treasury of the simulated backend. Like on the
+// live network, the consensus only takes the new stake into account ten blocks
+// after the one the transfer is included in.
+func (n *Backend) SetStake(voter common.Address, stake *big.Int) error {
+	if n.voting == nil {
+		return errNoVoteConsensus
+	}
+	key := n.voting.voterKey(voter)
+	if key == nil {
+		return fmt.Errorf("unknown voter %s", voter.Hex())
+	}
+	current, err := n.Stake(voter)
+	if err != nil {
+		return err
+	}
+	var (
+		from   *ecdsa.PrivateKey
+		to     common.Address
+		amount = new(big.Int).Sub(stake, current)
+	)
+	switch amount.Sign() {
+	case 0:
+		return nil
+	case 1:
+		from, to = stakeTreasuryKey, voter
+	default:
+		from, to = key, stakeTreasury
+		amount.Neg(amount)
+	}
+	tx, err := n.transferStake(from, to, amount)
+	if err != nil {
+		return err
+	}
+	n.Commit()
+
+	receipt, err := n.client.TransactionReceipt(context.Background(), tx.Hash())
+	if err != nil {
+		return err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("stake transfer %s failed", tx.Hash().Hex())
+	}
+	return nil
+}
+
+// transferStake sends a transaction transferring stake tokens from the account
+// of the given key.
+func (n *Backend) transferStake(from *ecdsa.PrivateKey, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	ctx := context.Background()
+	nonce, err := n.client.PendingNonceAt(ctx, crypto.PubkeyToAddress(from.PublicKey))
+	if err != nil {
+		return nil, err
+	}
+	head, err := n.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	data := append([]byte{0xa9, 0x05, 0x9c, 0xbb}, common.LeftPadBytes(to.Bytes(), 32)...) // transfer(address,uint256)
+	data = append(data, common.LeftPadBytes(amount.Bytes(), 32)...)
+
+	tip := big.NewInt(params.GWei)
+	config := n.eth.BlockChain().Config()
+	tx, err := types.SignNewTx(from, types.LatestSigner(config), &types.DynamicFeeTx{
+		ChainID:   config.ChainID,
+		Nonce:     nonce,
+		GasTipCap: tip,
+		GasFeeCap: new(big.Int).Add(new(big.Int).Mul(head.BaseFee, big.NewInt(2)), tip),
+		Gas:       100_000,
+		To:        &params.ZkscamTokenAddress,
+		Data:      data,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tx, n.client.SendTransaction(ctx, tx)
+}
@@ -0,0 +1,111 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package simulated
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestVoteConsensus(t *testing.T) {
+	sim := NewBackend(types.GenesisAlloc{
+		testAddr: {Balance: big.NewInt(10000000000000000)},
+	}, WithVoteConsensus(3))
+	defer sim.Close()
+
+	var (
+		client = sim.Client()
+		ctx    = context.Background()
+		voters = sim.Voters()
+	)
+	if len(voters) != 3 {
+		t.Fatalf("voter count mismatch: have %d, want 3", len(voters))
+	}
+	// Transactions are included in blocks voted by all voters
+	tx, err := newTx(sim, testKey)
+	if err != nil {
+		t.Fatalf("could not create transaction: %v", err)
+	}
+	if err := client.SendTransaction(ctx, tx); err != nil {
+		t.Fatalf("could not send transaction: %v", err)
+	}
+	sim.Commit()
+
+	receipt, err := client.TransactionReceipt(ctx, tx.Hash())
+	if err != nil {
+		t.Fatalf("transaction not included: %v", err)
+	}
+	header, err := client.HeaderByHash(ctx, receipt.BlockHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(header.MinerAddresses) != 3 {
+		t.Errorf("vote count mismatch: have %d, want 3", len(header.MinerAddresses))
+	}
+	if want := new(big.Int).Mul(DefaultStake, big.NewInt(3)); header.Votes.Cmp(want) != 0 {
+		t.Errorf("votes mismatch: have %v, want %v", header.Votes, want)
+	}
+	if len(header.AggregatedSignature) == 0 {
+		t.Error("block without aggregated signature")
+	}
+	// Empty blocks are committed too, without the votes of offline voters
+	if err := sim.SetVoterOnline(voters[2], false); err != nil {
+		t.Fatalf("could not take voter offline: %v", err)
+	}
+	if err := sim.SetVoterOnline(voters[0], false); err == nil {
+		t.Error("sealing miner taken offline")
+	}
+	hash := sim.Commit()
+	header, err = client.HeaderByHash(ctx, hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.Number.Uint64() != 2 {
+		t.Fatalf("block number mismatch: have %d, want 2", header.Number)
+	}
+	if len(header.MinerAddresses) != 2 || header.MinerAddresses[0] != voters[0] || header.MinerAddresses[1] != voters[1] {
+		t.Errorf("voters mismatch: have %v, want %v", header.MinerAddresses, voters[:2])
+	}
+}
+
+func TestVoteConsensusSetStake(t *testing.T) {
+	sim := NewBackend(types.GenesisAlloc{}, WithVoteConsensus(2))
+	defer sim.Close()
+
+	voter := sim.Voters()[1]
+	for _, stake := range []*big.Int{
+		new(big.Int).Mul(DefaultStake, big.NewInt(3)),
+		big.NewInt(1),
+	} {
+		if err := sim.SetStake(voter, stake); err != nil {
+			t.Fatalf("could not set stake to %v: %v", stake, err)
+		}
+		have, err := sim.Stake(voter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have.Cmp(stake) != 0 {
+			t.Errorf("stake mismatch: have %v, want %v", have, stake)
+		}
+	}
+	if err := sim.SetStake(testAddr, DefaultStake); err == nil {
+		t.Error("stake set for unknown voter")
+	}
+}
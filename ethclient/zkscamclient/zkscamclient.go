@@ -0,0 +1,392 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package zkscamclient provides an RPC client for the APIs of the vote-based
+// consensus of ZKScam.
+package zkscamclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Client is a wrapper around rpc.Client that implements the ZKScam consensus
+// specific functionality.
+//
+// If you want to use the standardized Ethereum RPC functionality, use
+// ethclient.Client instead.
+type Client struct {
+	c *rpc.Client
+}
+
+// New creates a client that uses the given RPC client.
+func New(c *rpc.Client) *Client {
+	return &Client{c}
+}
+
+// HeaderByNumber returns a block header from the current canonical chain,
+// including the vote payload. If number is nil, the latest known header is
+// returned. Signatures and BLS keys are only included if the node serves the
+// full vote payload.
+func (zc *Client) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	var head *types.Header
+	err := zc.c.CallContext(ctx, &head, "eth_getBlockByNumber", toBlockNumArg(number), false)
+	if err == nil && head == nil {
+		err = ethereum.NotFound
+	}
+	return head, err
+}
+
+// HeaderByHash returns the block header with the given hash, including the vote
+// payload.
+func (zc *Client) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	var head *types.Header
+	err := zc.c.CallContext(ctx, &head, "eth_getBlockByHash", hash, false)
+	if err == nil && head == nil {
+		err = ethereum.NotFound
+	}
+	return head, err
+}
+
+// VoteCheck is the outcome of a single check of the vote verification.
+type VoteCheck struct {
+	Name   string          `json:"name"`
+	Miner  *common.Address `json:"miner,omitempty"`
+	Passed bool            `json:"passed"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// VoteVerification is the outcome of verifying the votes of a block.
+type VoteVerification struct {
+	Number uint64
+	Hash   common.Hash
+	Valid  bool
+	Checks []VoteCheck
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *VoteVerification) UnmarshalJSON(input []byte) error {
+	var dec struct {
+		Number hexutil.Uint64 `json:"number"`
+		Hash   common.Hash    `json:"hash"`
+		Valid  bool           `json:"valid"`
+		Checks []VoteCheck    `json:"checks"`
+	}
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	*v = VoteVerification{Number: uint64(dec.Number), Hash: dec.Hash, Valid: dec.Valid, Checks: dec.Checks}
+	return nil
+}
+
+// VerifyBlock re-runs the vote verification of the canonical block with the
+// given number and reports the result of each check. If number is nil, the
+// latest block is verified.
+func (zc *Client) VerifyBlock(ctx context.Context, number *big.Int) (*VoteVerification, error) {
+	var result VoteVerification
+	err := zc.c.CallContext(ctx, &result, "zkscam_verifyBlock", toBlockNumArg(number))
+	return &result, err
+}
+
+// VerifyBlockByHash re-runs the vote verification of the block with the given
+// hash and reports the result of each check.
+func (zc *Client) VerifyBlockByHash(ctx context.Context, hash common.Hash) (*VoteVerification, error) {
+	var result VoteVerification
+	err := zc.c.CallContext(ctx, &result, "zkscam_verifyBlock", hash)
+	return &result, err
+}
+
+// VerifyHeader runs the vote verification of a header that does not need to
+// be known by the node, e.g. one received from another node.
+func (zc *Client) VerifyHeader(ctx context.Context, header *types.Header) (*VoteVerification, error) {
+	blob, err := rlp.EncodeToBytes(header)
+	if err != nil {
+		return nil, err
+	}
+	var result VoteVerification
+	err = zc.c.CallContext(ctx, &result, "zkscam_verifyBlock", hexutil.Bytes(blob))
+	return &result, err
+}
+
+// PoolCandidate is a hash voted for in the current slot, with the miners that
+// voted for it.
+type PoolCandidate struct {
+	Number *big.Int
+	Hash   common.Hash
+	Miners []common.Address
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *PoolCandidate) UnmarshalJSON(input []byte) error {
+	var dec struct {
+		Number *hexutil.Big     `json:"number"`
+		Hash   common.Hash      `json:"hash"`
+		Miners []common.Address `json:"miners"`
+	}
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	*p = PoolCandidate{Number: (*big.Int)(dec.Number), Hash: dec.Hash, Miners: dec.Miners}
+	return nil
+}
+
+// VotePool returns the votes of the current slot known by the node, grouped by
+// the hash voted for, most voted hash first.
+func (zc *Client) VotePool(ctx context.Context) ([]PoolCandidate, error) {
+	var result []PoolCandidate
+	err := zc.c.CallContext(ctx, &result, "zkscam_getVotePool")
+	return result, err
+}
+
+// Stake returns the stake a vote of the miner counts for at the given height.
+// If number is nil, the stake for the next block is returned.
+func (zc *Client) Stake(ctx context.Context, miner common.Address, number *big.Int) (*big.Int, error) {
+	var result hexutil.Big
+	if number == nil {
+		if err := zc.c.CallContext(ctx, &result, "zkscam_getStake", miner); err != nil {
+			return nil, err
+		}
+	} else if err := zc.c.CallContext(ctx, &result, "zkscam_getStake", miner, toBlockNumArg(number)); err != nil {
+		return nil, err
+	}
+	return (*big.Int)(&result), nil
+}
+
+// Reward is an amount credited to an account when a block was applied.
+type Reward struct {
+	Address common.Address
+	Amount  *big.Int
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *Reward) UnmarshalJSON(input []byte) error {
+	var dec struct {
+		Address common.Address `json:"address"`
+		Amount  *hexutil.Big   `json:"amount"`
+	}
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	*r = Reward{Address: dec.Address, Amount: (*big.Int)(dec.Amount)}
+	return nil
+}
+
+// Rewards returns the gas rewards credited when the canonical block with the
+// given number was applied: the share of the buyback contract followed by the
+// ones of the miners that voted for its parent. If number is nil, the rewards
+// of the latest block are returned.
+func (zc *Client) Rewards(ctx context.Context, number *big.Int) ([]Reward, error) {
+	var result []Reward
+	err := zc.c.CallContext(ctx, &result, "zkscam_getRewards", toBlockNumArg(number))
+	return result, err
+}
+
+// BlockVoter is a miner whose vote is included in a block, with the stake its
+// vote counted for.
+type BlockVoter struct {
+	Miner common.Address
+	Stake *big.Int
+}
+
+// BlockVotes is the vote tally of a block.
+type BlockVotes struct {
+	Number     *big.Int
+	Hash       common.Hash
+	Votes      *big.Int
+	TotalVotes *big.Int
+	Voters     []BlockVoter
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *BlockVotes) UnmarshalJSON(input []byte) error {
+	var dec struct {
+		Number     *hexutil.Big `json:"number"`
+		Hash       common.Hash  `json:"hash"`
+		Votes      *hexutil.Big `json:"votes"`
+		TotalVotes *hexutil.Big `json:"totalVotes"`
+		Voters     []struct {
+			Miner common.Address `json:"miner"`
+			Stake *hexutil.Big   `json:"stake"`
+		} `json:"voters"`
+	}
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	*v = BlockVotes{Number: (*big.Int)(dec.Number), Hash: dec.Hash, Votes: (*big.Int)(dec.Votes), TotalVotes: (*big.Int)(dec.TotalVotes)}
+	for _, voter := range dec.Voters {
+		v.Voters = append(v.Voters, BlockVoter{Miner: voter.Miner, Stake: (*big.Int)(voter.Stake)})
+	}
+	return nil
+}
+
+// Votes returns the vote tally of the canonical block with the given number and
+// the miners whose votes it includes. If number is nil, the votes of the latest
+// block are returned.
+func (zc *Client) Votes(ctx context.Context, number *big.Int) (*BlockVotes, error) {
+	var result BlockVotes
+	err := zc.c.CallContext(ctx, &result, "zkscam_getVotes", toBlockNumArg(number))
+	return &result, err
+}
+
+// MinerParticipation is the voting record of a miner over a range of blocks.
+type MinerParticipation struct {
+	Miner  common.Address
+	Blocks uint64   // Blocks of the range including a vote of the miner
+	Votes  *big.Int // Stake the miner voted with over the range
+}
+
+// Participation is the voting record of all miners over a range of blocks.
+type Participation struct {
+	From, To uint64
+	Blocks   uint64
+	Miners   []MinerParticipation // Ordered by blocks voted, most active first
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *Participation) UnmarshalJSON(input []byte) error {
+	var dec struct {
+		From   hexutil.Uint64 `json:"from"`
+		To     hexutil.Uint64 `json:"to"`
+		Blocks hexutil.Uint64 `json:"blocks"`
+		Miners []struct {
+			Miner  common.Address `json:"miner"`
+			Blocks hexutil.Uint64 `json:"blocks"`
+			Votes  *hexutil.Big   `json:"votes"`
+		} `json:"miners"`
+	}
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	*p = Participation{From: uint64(dec.From), To: uint64(dec.To), Blocks: uint64(dec.Blocks)}
+	for _, m := range dec.Miners {
+		p.Miners = append(p.Miners, MinerParticipation{Miner: m.Miner, Blocks: uint64(m.Blocks), Votes: (*big.Int)(m.Votes)})
+	}
+	return nil
+}
+
+// Participation returns the voting record of every miner that voted between
+// two canonical blocks, both inclusive. If to is nil, the range ends at the
+// latest block.
+func (zc *Client) Participation(ctx context.Context, from, to *big.Int) (*Participation, error) {
+	var result Participation
+	err := zc.c.CallContext(ctx, &result, "zkscam_getParticipation", toBlockNumArg(from), toBlockNumArg(to))
+	return &result, err
+}
+
+// MinerRewards returns the total gas rewards credited to an account by the
+// canonical blocks between two blocks, both inclusive. If to is nil, the range
+// ends at the latest block.
+func (zc *Client) MinerRewards(ctx context.Context, account common.Address, from, to *big.Int) (*big.Int, error) {
+	var result hexutil.Big
+	if err := zc.c.CallContext(ctx, &result, "zkscam_getMinerRewards", account, toBlockNumArg(from), toBlockNumArg(to)); err != nil {
+		return nil, err
+	}
+	return (*big.Int)(&result), nil
+}
+
+// Vote is a verified consensus vote added to the current slot of a node.
+type Vote struct {
+	Number *big.Int
+	Miner  common.Address
+	Hash   common.Hash
+	Stake  *big.Int
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *Vote) UnmarshalJSON(input []byte) error {
+	var dec struct {
+		Number *hexutil.Big   `json:"number"`
+		Miner  common.Address `json:"miner"`
+		Hash   common.Hash    `json:"hash"`
+		Stake  *hexutil.Big   `json:"stake"`
+	}
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	*v = Vote{Number: (*big.Int)(dec.Number), Miner: dec.Miner, Hash: dec.Hash, Stake: (*big.Int)(dec.Stake)}
+	return nil
+}
+
+// RoundCandidate is a hash voted for in a consensus slot and its votes.
+type RoundCandidate struct {
+	Hash  common.Hash
+	Votes *big.Int
+}
+
+// RoundResult is the result of a consensus slot concluded by a mining node.
+type RoundResult struct {
+	Number     *big.Int
+	Winner     common.Hash
+	Candidates []RoundCandidate // Ordered by votes, most voted first
+	Header     *types.Header    // Header of the sealed block, nil if sealing failed
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *RoundResult) UnmarshalJSON(input []byte) error {
+	var dec struct {
+		Number     *hexutil.Big  `json:"number"`
+		Winner     common.Hash   `json:"winner"`
+		Block      *types.Header `json:"block"`
+		Candidates []struct {
+			Hash  common.Hash  `json:"hash"`
+			Votes *hexutil.Big `json:"votes"`
+		} `json:"candidates"`
+	}
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	*r = RoundResult{Number: (*big.Int)(dec.Number), Winner: dec.Winner, Header: dec.Block}
+	for _, c := range dec.Candidates {
+		r.Candidates = append(r.Candidates, RoundCandidate{Hash: c.Hash, Votes: (*big.Int)(c.Votes)})
+	}
+	return nil
+}
+
+// SubscribeVotes subscribes to notifications about the verified consensus votes
+// added to the current slot of the node.
+func (zc *Client) SubscribeVotes(ctx context.Context, ch chan<- *Vote) (*rpc.ClientSubscription, error) {
+	return zc.c.EthSubscribe(ctx, ch, "votes")
+}
+
+// SubscribeRoundResults subscribes to notifications about the results of the
+// consensus slots concluded by the node.
+func (zc *Client) SubscribeRoundResults(ctx context.Context, ch chan<- *RoundResult) (*rpc.ClientSubscription, error) {
+	return zc.c.EthSubscribe(ctx, ch, "roundResults")
+}
+
+func toBlockNumArg(number *big.Int) string {
+	if number == nil {
+		return "latest"
+	}
+	if number.Sign() >= 0 {
+		return hexutil.EncodeBig(number)
+	}
+	// It's negative.
+	if number.IsInt64() {
+		return rpc.BlockNumber(number.Int64()).String()
+	}
+	// It's negative and large, which is invalid.
+	return fmt.Sprintf("<invalid %d>", number)
+}
@@ -0,0 +1,155 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package zkscamclient
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/eth/fetcher"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	eth2 "github.com/ethereum/go-ethereum/eth/protocols/eth"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+func newTestBackend(t *testing.T) *node.Node {
+	n, err := node.New(&node.Config{})
+	if err != nil {
+		t.Fatalf("can't create new node: %v", err)
+	}
+	genesis := &core.Genesis{
+		Config:     params.AllCliqueProtocolChanges,
+		ExtraData:  make([]byte, 32+65),
+		Votes:      new(big.Int),
+		TotalVotes: new(big.Int),
+	}
+	ethservice, err := eth.New(n, &ethconfig.Config{Genesis: genesis})
+	if err != nil {
+		t.Fatalf("can't create new ethereum service: %v", err)
+	}
+	filterSystem := filters.NewFilterSystem(ethservice.APIBackend, filters.Config{})
+	n.RegisterAPIs([]rpc.API{{
+		Namespace: "eth",
+		Service:   filters.NewFilterAPI(filterSystem, false),
+	}})
+	if err := n.Start(); err != nil {
+		t.Fatalf("can't start test node: %v", err)
+	}
+	return n
+}
+
+func TestZkscamClient(t *testing.T) {
+	backend := newTestBackend(t)
+	client := backend.Attach()
+	defer backend.Close()
+	defer client.Close()
+
+	zc := New(client)
+	ctx := context.Background()
+
+	head, err := zc.HeaderByNumber(ctx, common.Big0)
+	if err != nil {
+		t.Fatalf("failed to retrieve genesis header: %v", err)
+	}
+	if head.Number.Sign() != 0 || head.TotalVotes == nil || head.TotalVotes.Sign() != 0 {
+		t.Errorf("genesis header mismatch: number %v, total votes %v", head.Number, head.TotalVotes)
+	}
+	if _, err := zc.VerifyBlock(ctx, common.Big0); err == nil {
+		t.Error("verified the votes of the genesis block")
+	}
+	rewards, err := zc.Rewards(ctx, common.Big0)
+	if err != nil {
+		t.Fatalf("failed to retrieve rewards: %v", err)
+	}
+	if len(rewards) != 0 {
+		t.Errorf("genesis block has rewards: %v", rewards)
+	}
+	blockVotes, err := zc.Votes(ctx, common.Big0)
+	if err != nil {
+		t.Fatalf("failed to retrieve block votes: %v", err)
+	}
+	if blockVotes.Number.Sign() != 0 || blockVotes.Hash != head.Hash() || len(blockVotes.Voters) != 0 {
+		t.Errorf("genesis votes mismatch: %+v", blockVotes)
+	}
+	participation, err := zc.Participation(ctx, common.Big0, nil)
+	if err != nil {
+		t.Fatalf("failed to retrieve participation: %v", err)
+	}
+	if participation.From != 0 || participation.To != 0 || participation.Blocks != 1 || len(participation.Miners) != 0 {
+		t.Errorf("genesis participation mismatch: %+v", participation)
+	}
+	if _, err := zc.Participation(ctx, common.Big1, nil); err == nil {
+		t.Error("retrieved participation of a missing block")
+	}
+	total, err := zc.MinerRewards(ctx, common.Address{0x1}, common.Big0, common.Big0)
+	if err != nil {
+		t.Fatalf("failed to retrieve miner rewards: %v", err)
+	}
+	if total.Sign() != 0 {
+		t.Errorf("genesis block has miner rewards: %v", total)
+	}
+
+	// Feed a vote through the node and check it is streamed and pooled
+	votes := make(chan *Vote, 1)
+	sub, err := zc.SubscribeVotes(ctx, votes)
+	if err != nil {
+		t.Fatalf("failed to subscribe to votes: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	vote := &eth2.Vote{Number: big.NewInt(1), MinerAddress: common.Address{0x1}, BlockHash: common.Hash{0xa}}
+	fetcher.NewVtFetcher().AddVote(vote, big.NewInt(100000))
+	defer fetcher.NewVtFetcher().ClearVotes()
+
+	select {
+	case have := <-votes:
+		if have.Miner != vote.MinerAddress || have.Hash != vote.BlockHash || have.Number.Cmp(vote.Number) != 0 || have.Stake.Cmp(big.NewInt(100000)) != 0 {
+			t.Errorf("vote mismatch: have %+v, want %+v", have, vote)
+		}
+	case err := <-sub.Err():
+		t.Fatalf("vote subscription failed: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("vote not received")
+	}
+	pool, err := zc.VotePool(ctx)
+	if err != nil {
+		t.Fatalf("failed to retrieve vote pool: %v", err)
+	}
+	if len(pool) != 1 || pool[0].Hash != vote.BlockHash || len(pool[0].Miners) != 1 || pool[0].Miners[0] != vote.MinerAddress {
+		t.Errorf("vote pool mismatch: %+v", pool)
+	}
+}
+
+func TestRoundResultDecoding(t *testing.T) {
+	blob := []byte(`{"number":"0x1","winner":"0x0a00000000000000000000000000000000000000000000000000000000000000","candidates":[{"hash":"0x0a00000000000000000000000000000000000000000000000000000000000000","votes":"0x186a0"}],"block":null}`)
+
+	var result RoundResult
+	if err := result.UnmarshalJSON(blob); err != nil {
+		t.Fatalf("failed to decode round result: %v", err)
+	}
+	if result.Header != nil || len(result.Candidates) != 1 || result.Candidates[0].Votes.Int64() != 100000 {
+		t.Errorf("round result mismatch: %+v", result)
+	}
+}
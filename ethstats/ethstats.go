@@ -103,6 +103,9 @@ type Service struct {
 
 	headSub event.Subscription
 	txSub   event.Subscription
+	voteSub event.Subscription // Votes seen by the node, nil without vote-based consensus
+
+	tally *voteTally // Votes of the recent heights, for reporting winning margins
 }
 
 // connWrapper is a wrapper to prevent concurrent-write or concurrent-read on the
@@ -194,6 +197,7 @@ func New(node *node.Node, backend backend, engine consensus.Engine, url string)
 		host:    parts[2],
 		pongCh:  make(chan struct{}),
 		histCh:  make(chan []uint64, 1),
+		tally:   newVoteTally(),
 	}
 
 	node.RegisterLifecycle(ethstats)
@@ -209,6 +213,13 @@ func (s *Service) Start() error {
 	s.txSub = s.backend.SubscribeNewTxsEvent(txEventCh)
 	go s.loop(chainHeadCh, txEventCh)
 
+	// Tally the votes seen if the node takes part in the vote-based consensus
+	if votingBackend, ok := s.backend.(votingBackend); ok && votingEngine(s.engine) != nil {
+		voteCh := make(chan core.NewVoteEvent, voteChanSize)
+		s.voteSub = votingBackend.SubscribeNewVoteEvent(voteCh)
+		go s.tallyLoop(voteCh)
+	}
+
 	log.Info("Stats daemon started")
 	return nil
 }
@@ -217,6 +228,9 @@ func (s *Service) Start() error {
 func (s *Service) Stop() error {
 	s.headSub.Unsubscribe()
 	s.txSub.Unsubscribe()
+	if s.voteSub != nil {
+		s.voteSub.Unsubscribe()
+	}
 	log.Info("Stats daemon stopped")
 	return nil
 }
@@ -588,6 +602,10 @@ type blockStats struct {
 	TxHash     common.Hash    `json:"transactionsRoot"`
 	Root       common.Hash    `json:"stateRoot"`
 	Uncles     uncleStats     `json:"uncles"`
+	Voters     int            `json:"voters"`
+	Votes      string         `json:"votes"`
+	TotalVotes string         `json:"totalVotes"`
+	Margin     string         `json:"margin,omitempty"` // Votes over the strongest competing candidate, if seen
 }
 
 // txStats is the information to report about individual transactions.
@@ -674,6 +692,10 @@ func (s *Service) assembleBlockStats(block *types.Block) *blockStats {
 	// Assemble and return the block stats
 	author, _ := s.engine.Author(header)
 
+	var margin string
+	if m := s.tally.margin(header); m != nil {
+		margin = m.String()
+	}
 	return &blockStats{
 		Number:     header.Number,
 		Hash:       header.Hash(),
@@ -688,6 +710,10 @@ func (s *Service) assembleBlockStats(block *types.Block) *blockStats {
 		TxHash:     header.TxHash,
 		Root:       header.Root,
 		Uncles:     uncles,
+		Voters:     len(header.MinerAddresses),
+		Votes:      bigString(header.Votes),
+		TotalVotes: bigString(header.TotalVotes),
+		Margin:     margin,
 	}
 }
 
@@ -782,6 +808,8 @@ type nodeStats struct {
 	Peers    int  `json:"peers"`
 	GasPrice int  `json:"gasPrice"`
 	Uptime   int  `json:"uptime"`
+
+	Consensus *consensusStats `json:"consensus,omitempty"`
 }
 
 // reportStats retrieves various stats about the node at the networking and
@@ -826,6 +854,8 @@ func (s *Service) reportStats(conn *connWrapper) error {
 			GasPrice: gasprice,
 			Syncing:  syncing,
 			Uptime:   100,
+
+			Consensus: s.assembleConsensusStats(),
 		},
 	}
 	report := map[string][]interface{}{
@@ -17,8 +17,13 @@
 package ethstats
 
 import (
+	"math/big"
 	"strconv"
 	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 func TestParseEthstatsURL(t *testing.T) {
@@ -80,3 +85,43 @@ func TestParseEthstatsURL(t *testing.T) {
 		}
 	}
 }
+
+func TestVoteTallyMargin(t *testing.T) {
+	var (
+		tally  = newVoteTally()
+		local  = common.Address{0x01}
+		winner = common.Hash{0xaa}
+		loser  = common.Hash{0xbb}
+	)
+	vote := func(number int64, miner common.Address, hash common.Hash, stake int64) {
+		tally.add(core.NewVoteEvent{Number: big.NewInt(number), Miner: miner, Hash: hash, Stake: big.NewInt(stake)}, local)
+	}
+	vote(1, local, winner, 300)
+	vote(1, common.Address{0x02}, winner, 200)
+	vote(1, common.Address{0x03}, loser, 150)
+	vote(1, common.Address{0x04}, loser, 100)
+
+	header := &types.Header{Number: big.NewInt(1), ZkscamHash: winner, Votes: big.NewInt(500)}
+	if margin := tally.margin(header); margin == nil || margin.Int64() != 250 {
+		t.Errorf("margin mismatch: have %v, want 250", margin)
+	}
+	if last := tally.last(); last == nil || last.Number.Int64() != 1 || last.Hash != winner {
+		t.Errorf("last vote mismatch: have %+v", last)
+	}
+	// Unopposed blocks win by all their votes, unseen heights are unknown
+	vote(2, common.Address{0x02}, winner, 200)
+	if margin := tally.margin(&types.Header{Number: big.NewInt(2), ZkscamHash: winner, Votes: big.NewInt(200)}); margin == nil || margin.Int64() != 200 {
+		t.Errorf("unopposed margin mismatch: have %v, want 200", margin)
+	}
+	if margin := tally.margin(&types.Header{Number: big.NewInt(3), Votes: big.NewInt(200)}); margin != nil {
+		t.Errorf("margin reported for unseen height: %v", margin)
+	}
+	// Heights falling out of the history are dropped
+	vote(1+historyUpdateRange, common.Address{0x02}, winner, 200)
+	if margin := tally.margin(header); margin != nil {
+		t.Errorf("margin reported for pruned height: %v", margin)
+	}
+	if last := tally.last(); last == nil || last.Number.Int64() != 1 {
+		t.Errorf("last vote of the local miner lost: %+v", last)
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethstats
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/clique"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	single "github.com/ethereum/go-ethereum/singleton"
+)
+
+const (
+	// voteChanSize is the size of channel listening to NewVoteEvent.
+	voteChanSize = 256
+
+	// maxStreakScan is the maximum number of blocks walked back from the head to
+	// measure the inclusion streak of the local miner.
+	maxStreakScan = 1024
+)
+
+// votingBackend encompasses the functionality necessary for reporting the
+// consensus participation of a node running the vote-based consensus.
+type votingBackend interface {
+	SubscribeNewVoteEvent(ch chan<- core.NewVoteEvent) event.Subscription
+}
+
+// votingEngine returns the vote-based consensus engine, either used directly or
+// wrapped by the beacon engine, or nil if the node runs another consensus.
+func votingEngine(engine consensus.Engine) *clique.Clique {
+	if b, ok := engine.(*beacon.Beacon); ok {
+		engine = b.InnerEngine()
+	}
+	c, _ := engine.(*clique.Clique)
+	return c
+}
+
+// voteStats is the information to report about an individual vote.
+type voteStats struct {
+	Number *big.Int    `json:"number"`
+	Hash   common.Hash `json:"hash"`
+}
+
+// consensusStats is the information to report about the participation of the
+// local miner in the vote-based consensus.
+type consensusStats struct {
+	Miner     common.Address `json:"miner"`
+	Stake     string         `json:"stake,omitempty"` // Stake counting for the vote on the next block
+	Threshold string         `json:"threshold"`       // Minimum stake for a vote to count
+	Eligible  bool           `json:"eligible"`
+	LastVote  *voteStats     `json:"lastVote,omitempty"`
+	Streak    int            `json:"inclusionStreak"` // Consecutive head blocks carrying the miner's vote
+}
+
+// voteTally tracks the votes seen for the candidates of the recent heights and
+// the last vote of the local miner.
+type voteTally struct {
+	mu       sync.Mutex
+	heights  map[uint64]map[common.Hash]*big.Int
+	highest  uint64
+	lastVote *voteStats
+}
+
+func newVoteTally() *voteTally {
+	return &voteTally{heights: make(map[uint64]map[common.Hash]*big.Int)}
+}
+
+// add counts a vote towards its candidate, dropping the heights that fell out
+// of the reported history.
+func (t *voteTally) add(ev core.NewVoteEvent, local common.Address) {
+	if ev.Number == nil || ev.Stake == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	number := ev.Number.Uint64()
+	if ev.Miner == local {
+		t.lastVote = &voteStats{Number: new(big.Int).Set(ev.Number), Hash: ev.Hash}
+	}
+	if number+historyUpdateRange <= t.highest {
+		return
+	}
+	candidates := t.heights[number]
+	if candidates == nil {
+		candidates = make(map[common.Hash]*big.Int)
+		t.heights[number] = candidates
+	}
+	if candidates[ev.Hash] == nil {
+		candidates[ev.Hash] = new(big.Int)
+	}
+	candidates[ev.Hash].Add(candidates[ev.Hash], ev.Stake)
+
+	if number > t.highest {
+		t.highest = number
+		for n := range t.heights {
+			if n+historyUpdateRange <= t.highest {
+				delete(t.heights, n)
+			}
+		}
+	}
+}
+
+// margin returns by how many votes the given block beat the strongest competing
+// candidate of its height, or nil if no votes were seen at that height.
+func (t *voteTally) margin(header *types.Header) *big.Int {
+	if header.Votes == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	candidates := t.heights[header.Number.Uint64()]
+	if len(candidates) == 0 {
+		return nil
+	}
+	runnerUp := new(big.Int)
+	for hash, votes := range candidates {
+		if hash != header.ZkscamHash && votes.Cmp(runnerUp) > 0 {
+			runnerUp = votes
+		}
+	}
+	return new(big.Int).Sub(header.Votes, runnerUp)
+}
+
+// last returns the last vote of the local miner, if any.
+func (t *voteTally) last() *voteStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.lastVote
+}
+
+// tallyLoop counts the votes seen by the node until the subscription ends.
+func (s *Service) tallyLoop(voteCh chan core.NewVoteEvent) {
+	for {
+		select {
+		case ev := <-voteCh:
+			s.tally.add(ev, single.GetETHAddress())
+		case <-s.voteSub.Err():
+			return
+		}
+	}
+}
+
+// assembleConsensusStats retrieves the eligibility and participation of the
+// local miner, or nil if the node does not mine with the vote-based consensus.
+func (s *Service) assembleConsensusStats() *consensusStats {
+	engine := votingEngine(s.engine)
+	miner := single.GetETHAddress()
+	if engine == nil || miner == (common.Address{}) {
+		return nil
+	}
+	head := s.backend.CurrentHeader()
+	stats := &consensusStats{
+		Miner:     miner,
+		Threshold: clique.MinStake().String(),
+		LastVote:  s.tally.last(),
+	}
+	stake, err := engine.MinerStake(miner, new(big.Int).Add(head.Number, common.Big1))
+	if err != nil {
+		log.Debug("Failed to retrieve miner stake for ethstats", "err", err)
+	} else {
+		stats.Stake = stake.String()
+		stats.Eligible = stake.Cmp(clique.MinStake()) >= 0
+	}
+	// Walk back from the head while the blocks carry the vote of the miner
+	for header := head; header != nil && stats.Streak < maxStreakScan; {
+		if !containsMiner(header.MinerAddresses, miner) {
+			break
+		}
+		stats.Streak++
+		if header.Number.Sign() == 0 {
+			break
+		}
+		header, _ = s.backend.HeaderByNumber(context.Background(), rpc.BlockNumber(header.Number.Uint64()-1))
+	}
+	return stats
+}
+
+func containsMiner(miners []common.Address, miner common.Address) bool {
+	for _, m := range miners {
+		if m == miner {
+			return true
+		}
+	}
+	return false
+}
+
+// bigString formats an optional big integer of a header for reporting.
+func bigString(n *big.Int) string {
+	if n == nil {
+		return "0"
+	}
+	return n.String()
+}
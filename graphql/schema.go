@@ -279,6 +279,59 @@ const schema string = `
         blobGasUsed: Long
         # ExcessBlobGas is a running total of blob gas consumed in excess of the target, prior to the block.
         excessBlobGas: Long
+        # ZkscamHash is the hash the miners signed when voting for this block.
+        zkscamHash: Bytes32!
+        # Votes is the stake that voted for this block.
+        votes: BigInt
+        # TotalVotes is the stake that voted for the chain up to and including
+        # this block.
+        totalVotes: BigInt
+        # Voters is the list of miners that voted for this block. The voters of
+        # at most 128 blocks are resolved per query, as every one of them costs
+        # a stake lookup.
+        voters: [Voter!]!
+        # Rewards is the list of gas rewards credited when this block was
+        # applied. They are paid to the miners that voted for the parent block.
+        # They count towards the same limit as the voters.
+        rewards: [Reward!]!
+    }
+
+    # Voter is a miner that voted for a block.
+    type Voter {
+        # Address is the account of the miner.
+        address: Address!
+        # Stake is the stake the vote counted for. If the stake can't be
+        # retrieved, this field will be null.
+        stake: BigInt
+        # BlsPublicKey is the BLS key the miner voted with.
+        blsPublicKey: Bytes!
+    }
+
+    # Reward is an amount credited to an account when a block was applied.
+    type Reward {
+        # Address is the credited account.
+        address: Address!
+        # Amount is the credited value in wei.
+        amount: BigInt!
+    }
+
+    # Participation is the voting record of a miner over a range of blocks.
+    type Participation {
+        # Miner is the account of the miner.
+        miner: Address!
+        # From is the first block of the range.
+        from: Long!
+        # To is the last block of the range.
+        to: Long!
+        # Blocks is the number of blocks in the range.
+        blocks: Long!
+        # VotedBlocks is the list of blocks of the range the miner voted for.
+        votedBlocks: [Long!]!
+        # Votes is the stake the miner voted with, summed over the range.
+        votes: BigInt!
+        # Rewards is the gas rewards credited to the miner by the blocks of the
+        # range.
+        rewards: BigInt!
     }
 
     # CallData represents the data associated with a local contract call.
@@ -385,6 +438,10 @@ const schema string = `
         syncing: SyncState
         # ChainID returns the current chain ID for transaction replay protection.
         chainID: BigInt!
+        # Participation returns the voting record of a miner between two block
+        # numbers, inclusive. If to is not supplied, it defaults to the most
+        # recent known block. The range may span at most 128 blocks.
+        participation(miner: Address!, from: Long!, to: Long): Participation!
     }
 
     type Mutation {
@@ -53,7 +53,7 @@ func (h handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		timer     *time.Timer
 		cancel    context.CancelFunc
 	)
-	ctx, cancel = context.WithCancel(ctx)
+	ctx, cancel = context.WithCancel(withStakeBudget(ctx))
 	defer cancel()
 
 	if timeout, ok := rpc.ContextRequestTimeout(ctx); ok {
@@ -0,0 +1,364 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/clique"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var errNoVotes = errors.New("consensus engine does not vote")
+
+// stakeBudgetKey is the context key of the stake lookup budget of a query.
+type stakeBudgetKey struct{}
+
+// stakeBudget tracks the blocks a single query looked up stakes for. Every vote
+// costs a remote stake lookup, so the voters and rewards of at most as many
+// blocks as a participation range spans are resolved per query.
+type stakeBudget struct {
+	mu     sync.Mutex
+	blocks map[uint64]struct{}
+}
+
+// withStakeBudget returns a context limiting the stake lookups of a query.
+func withStakeBudget(ctx context.Context) context.Context {
+	return context.WithValue(ctx, stakeBudgetKey{}, &stakeBudget{blocks: make(map[uint64]struct{})})
+}
+
+// chargeStakeLookup accounts for looking up the stakes of the voters of the
+// given block, failing if the query exceeds its budget. Lookups outside of a
+// query with a budget are not limited.
+func chargeStakeLookup(ctx context.Context, number uint64) error {
+	budget, ok := ctx.Value(stakeBudgetKey{}).(*stakeBudget)
+	if !ok {
+		return nil
+	}
+	budget.mu.Lock()
+	defer budget.mu.Unlock()
+
+	if _, ok := budget.blocks[number]; ok {
+		return nil
+	}
+	if len(budget.blocks) >= clique.MaxRangeBlocks {
+		return fmt.Errorf("too many blocks with stake lookups, at most %d allowed per query", clique.MaxRangeBlocks)
+	}
+	budget.blocks[number] = struct{}{}
+	return nil
+}
+
+// clique returns the vote-based consensus engine of the backend, either used
+// directly or wrapped by the beacon engine.
+func (r *Resolver) clique() (*clique.Clique, error) {
+	engine := r.backend.Engine()
+	if b, ok := engine.(*beacon.Beacon); ok {
+		engine = b.InnerEngine()
+	}
+	c, ok := engine.(*clique.Clique)
+	if !ok {
+		return nil, errNoVotes
+	}
+	return c, nil
+}
+
+// Voter represents a miner that voted for a block.
+type Voter struct {
+	address      common.Address
+	stake        *big.Int
+	blsPublicKey []byte
+}
+
+func (v *Voter) Address(ctx context.Context) common.Address {
+	return v.address
+}
+
+func (v *Voter) Stake(ctx context.Context) *hexutil.Big {
+	return (*hexutil.Big)(v.stake)
+}
+
+func (v *Voter) BlsPublicKey(ctx context.Context) hexutil.Bytes {
+	return v.blsPublicKey
+}
+
+// Reward represents an amount credited to an account when a block was applied.
+type Reward struct {
+	address common.Address
+	amount  *big.Int
+}
+
+func (r *Reward) Address(ctx context.Context) common.Address {
+	return r.address
+}
+
+func (r *Reward) Amount(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*r.amount)
+}
+
+func (b *Block) ZkscamHash(ctx context.Context) (common.Hash, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return header.ZkscamHash, nil
+}
+
+func (b *Block) Votes(ctx context.Context) (*hexutil.Big, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(header.Votes), nil
+}
+
+func (b *Block) TotalVotes(ctx context.Context) (*hexutil.Big, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(header.TotalVotes), nil
+}
+
+func (b *Block) Voters(ctx context.Context) ([]*Voter, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	engine, err := b.r.clique()
+	if err != nil {
+		return nil, err
+	}
+	if len(header.MinerAddresses) > 0 && header.Number.Sign() > 0 {
+		if err := chargeStakeLookup(ctx, header.Number.Uint64()); err != nil {
+			return nil, err
+		}
+	}
+	ret := make([]*Voter, 0, len(header.MinerAddresses))
+	for i, miner := range header.MinerAddresses {
+		voter := &Voter{address: miner}
+		if i < len(header.BLSPublicKeys) {
+			voter.blsPublicKey = header.BLSPublicKeys[i]
+		}
+		if header.Number.Sign() > 0 {
+			// A missing stake fails the vote verification, report it as unknown
+			voter.stake, _ = engine.MinerStake(miner, header.Number)
+		}
+		ret = append(ret, voter)
+	}
+	return ret, nil
+}
+
+func (b *Block) Rewards(ctx context.Context) ([]*Reward, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	// The rewards are shared by the stakes of the voters of the parent
+	if len(block.Transactions()) > 0 && block.NumberU64() > 0 {
+		if err := chargeStakeLookup(ctx, block.NumberU64()-1); err != nil {
+			return nil, err
+		}
+	}
+	rewards, err := b.r.blockRewards(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*Reward, 0, len(rewards))
+	for _, reward := range rewards {
+		ret = append(ret, &Reward{address: reward.Address, amount: reward.Amount})
+	}
+	return ret, nil
+}
+
+// blockRewards returns the gas rewards credited when the given block was
+// applied.
+func (r *Resolver) blockRewards(ctx context.Context, block *types.Block) ([]clique.Reward, error) {
+	engine, err := r.clique()
+	if err != nil {
+		return nil, err
+	}
+	if block.NumberU64() == 0 {
+		return nil, nil
+	}
+	receipts, err := r.backend.GetReceipts(ctx, block.Hash())
+	if err != nil {
+		return nil, err
+	}
+	parent, err := r.backend.HeaderByHash(ctx, block.ParentHash())
+	if err != nil {
+		return nil, err
+	}
+	return engine.AppliedRewards(block, parent, receipts)
+}
+
+// Participation represents the voting record of a miner over a range of blocks.
+// The record is computed when first requested.
+type Participation struct {
+	r        *Resolver
+	miner    common.Address
+	from, to rpc.BlockNumber
+
+	mu      sync.Mutex
+	done    bool
+	blocks  hexutil.Uint64
+	voted   []hexutil.Uint64
+	votes   *big.Int
+	rewards *big.Int
+}
+
+func (p *Participation) Miner(ctx context.Context) common.Address {
+	return p.miner
+}
+
+func (p *Participation) From(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(p.from)
+}
+
+func (p *Participation) To(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(p.to)
+}
+
+func (p *Participation) Blocks(ctx context.Context) (hexutil.Uint64, error) {
+	if err := p.resolveVotes(ctx); err != nil {
+		return 0, err
+	}
+	return p.blocks, nil
+}
+
+func (p *Participation) VotedBlocks(ctx context.Context) ([]hexutil.Uint64, error) {
+	if err := p.resolveVotes(ctx); err != nil {
+		return nil, err
+	}
+	return p.voted, nil
+}
+
+func (p *Participation) Votes(ctx context.Context) (hexutil.Big, error) {
+	if err := p.resolveVotes(ctx); err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*p.votes), nil
+}
+
+// resolveVotes walks the headers of the range, collecting the blocks the miner
+// voted for and the stake it voted with.
+func (p *Participation) resolveVotes(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.done {
+		return nil
+	}
+	engine, err := p.r.clique()
+	if err != nil {
+		return err
+	}
+	var (
+		voted = []hexutil.Uint64{}
+		votes = new(big.Int)
+	)
+	for n := p.from; n <= p.to; n++ {
+		header, err := p.r.backend.HeaderByNumber(ctx, n)
+		if err != nil {
+			return err
+		}
+		if header == nil {
+			return fmt.Errorf("block #%d not found", n)
+		}
+		for _, miner := range header.MinerAddresses {
+			if miner != p.miner {
+				continue
+			}
+			stake, err := engine.MinerStake(miner, header.Number)
+			if err != nil {
+				return err
+			}
+			voted = append(voted, hexutil.Uint64(n))
+			votes.Add(votes, stake)
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+	p.blocks = hexutil.Uint64(p.to - p.from + 1)
+	p.voted, p.votes, p.done = voted, votes, true
+	return nil
+}
+
+func (p *Participation) Rewards(ctx context.Context) (hexutil.Big, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.rewards != nil {
+		return hexutil.Big(*p.rewards), nil
+	}
+	total := new(big.Int)
+	for n := p.from; n <= p.to; n++ {
+		block, err := p.r.backend.BlockByNumber(ctx, n)
+		if err != nil {
+			return hexutil.Big{}, err
+		}
+		if block == nil {
+			return hexutil.Big{}, fmt.Errorf("block #%d not found", n)
+		}
+		rewards, err := p.r.blockRewards(ctx, block)
+		if err != nil {
+			return hexutil.Big{}, err
+		}
+		for _, reward := range rewards {
+			if reward.Address == p.miner {
+				total.Add(total, reward.Amount)
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return hexutil.Big{}, err
+		}
+	}
+	p.rewards = total
+	return hexutil.Big(*total), nil
+}
+
+func (r *Resolver) Participation(ctx context.Context, args struct {
+	Miner common.Address
+	From  Long
+	To    *Long
+}) (*Participation, error) {
+	if _, err := r.clique(); err != nil {
+		return nil, err
+	}
+	from := rpc.BlockNumber(args.From)
+
+	var to rpc.BlockNumber
+	if args.To != nil {
+		to = rpc.BlockNumber(*args.To)
+	} else {
+		to = rpc.BlockNumber(r.backend.CurrentBlock().Number.Int64())
+	}
+	if from < 0 || to < from {
+		return nil, errInvalidBlockRange
+	}
+	if to-from >= clique.MaxRangeBlocks {
+		return nil, fmt.Errorf("block range too large, at most %d blocks allowed", clique.MaxRangeBlocks)
+	}
+	return &Participation{r: r, miner: args.Miner, from: from, to: to}, nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/clique"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// newZkscamGQLService starts a node serving graphql on top of a vote-based
+// clique chain holding only the genesis block.
+func newZkscamGQLService(t *testing.T) *node.Node {
+	stack := createNode(t)
+	t.Cleanup(func() { stack.Close() })
+
+	config := *params.AllCliqueProtocolChanges
+	config.Clique = &params.CliqueConfig{Period: 1, Epoch: 30000}
+	ethBackend, err := eth.New(stack, &ethconfig.Config{
+		Genesis: &core.Genesis{
+			Config:     &config,
+			GasLimit:   11500000,
+			Difficulty: big.NewInt(1),
+			ExtraData:  make([]byte, 32+common.AddressLength+crypto.SignatureLength),
+			Votes:      new(big.Int),
+			TotalVotes: new(big.Int),
+		},
+		NetworkId:      1337,
+		TrieCleanCache: 5,
+		TrieDirtyCache: 5,
+		TrieTimeout:    60 * time.Minute,
+		SnapshotCache:  5,
+	})
+	if err != nil {
+		t.Fatalf("could not create eth backend: %v", err)
+	}
+	filterSystem := filters.NewFilterSystem(ethBackend.APIBackend, filters.Config{})
+	if _, err := newHandler(stack, ethBackend.APIBackend, filterSystem, []string{}, []string{}); err != nil {
+		t.Fatalf("could not create graphql service: %v", err)
+	}
+	if err := stack.Start(); err != nil {
+		t.Fatalf("could not start node: %v", err)
+	}
+	return stack
+}
+
+// Tests that participation queries are limited to a small range of blocks, as
+// every vote in the range is resolved with a remote stake lookup.
+func TestGraphQLParticipationRange(t *testing.T) {
+	stack := newZkscamGQLService(t)
+	endpoint := fmt.Sprintf("%s/graphql", stack.HTTPEndpoint())
+
+	for i, tt := range []struct {
+		body string
+		want string
+	}{
+		{ // The genesis holds no votes, so no stake is looked up
+			body: `{"query": "{participation(miner:\"0x0000000000000000000000000000000000000001\",from:0,to:0){from,to,blocks,votedBlocks,votes,rewards}}"}`,
+			want: `{"data":{"participation":{"from":"0x0","to":"0x0","blocks":"0x1","votedBlocks":[],"votes":"0x0","rewards":"0x0"}}}`,
+		},
+		{
+			body: fmt.Sprintf(`{"query": "{participation(miner:\"0x0000000000000000000000000000000000000001\",from:0,to:%d){blocks}}"}`, clique.MaxRangeBlocks),
+			want: fmt.Sprintf(`{"errors":[{"message":"block range too large, at most %d blocks allowed","path":["participation"]}],"data":null}`, clique.MaxRangeBlocks),
+		},
+		{
+			body: `{"query": "{participation(miner:\"0x0000000000000000000000000000000000000001\",from:1,to:0){blocks}}"}`,
+			want: `{"errors":[{"message":"invalid from and to block combination: from \u003e to","path":["participation"]}],"data":null}`,
+		},
+	} {
+		resp, err := http.Post(endpoint, "application/json", strings.NewReader(tt.body))
+		if err != nil {
+			t.Fatalf("could not post: %v", err)
+		}
+		bodyBytes, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatalf("could not read from response body: %v", err)
+		}
+		if have := string(bodyBytes); have != tt.want {
+			t.Errorf("testcase %d %s,\nhave:\n%v\nwant:\n%v", i, tt.body, have, tt.want)
+		}
+	}
+}
+
+// Tests that a query resolves the voters and rewards of a bounded number of
+// blocks, as every one of them costs a remote stake lookup per voter.
+func TestGraphQLStakeBudget(t *testing.T) {
+	ctx := withStakeBudget(context.Background())
+	for n := uint64(0); n < clique.MaxRangeBlocks; n++ {
+		if err := chargeStakeLookup(ctx, n); err != nil {
+			t.Fatalf("block %d: lookup refused within budget: %v", n, err)
+		}
+	}
+	// Blocks already charged are free, new ones exceed the budget
+	if err := chargeStakeLookup(ctx, 0); err != nil {
+		t.Fatalf("charged block refused: %v", err)
+	}
+	if err := chargeStakeLookup(ctx, clique.MaxRangeBlocks); err == nil {
+		t.Fatalf("lookup beyond budget allowed")
+	}
+	// Budgets are per query
+	if err := chargeStakeLookup(withStakeBudget(context.Background()), clique.MaxRangeBlocks); err != nil {
+		t.Fatalf("lookup refused by a fresh budget: %v", err)
+	}
+}
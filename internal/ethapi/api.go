@@ -1048,6 +1048,7 @@ func (diff *BlockOverrides) Apply(blockCtx *vm.BlockContext) {
 type ChainContextBackend interface {
 	Engine() consensus.Engine
 	HeaderByNumber(context.Context, rpc.BlockNumber) (*types.Header, error)
+	ChainConfig() *params.ChainConfig
 }
 
 // ChainContext is an implementation of core.ChainContext. It's main use-case
@@ -1076,6 +1077,10 @@ func (context *ChainContext) GetHeader(hash common.Hash, number uint64) *types.H
 	return header
 }
 
+func (context *ChainContext) Config() *params.ChainConfig {
+	return context.b.ChainConfig()
+}
+
 func doCall(ctx context.Context, b Backend, args TransactionArgs, state *state.StateDB, header *types.Header, overrides *StateOverride, blockOverrides *BlockOverrides, timeout time.Duration, globalGasCap uint64) (*core.ExecutionResult, error) {
 	if err := overrides.Apply(state); err != nil {
 		return nil, err
@@ -1309,6 +1314,7 @@ func RPCMarshalBlock(block *types.Block, inclTx bool, fullTx bool, config *param
 // a `BlockchainAPI`.
 func (s *BlockChainAPI) rpcMarshalHeader(ctx context.Context, header *types.Header) map[string]interface{} {
 	fields := RPCMarshalHeader(header)
+	applyVotePayload(fields, s.b.RPCVotePayload())
 	fields["totalDifficulty"] = (*hexutil.Big)(s.b.GetTd(ctx, header.Hash()))
 	return fields
 }
@@ -1317,12 +1323,34 @@ func (s *BlockChainAPI) rpcMarshalHeader(ctx context.Context, header *types.Head
 // a `BlockchainAPI`.
 func (s *BlockChainAPI) rpcMarshalBlock(ctx context.Context, b *types.Block, inclTx bool, fullTx bool) (map[string]interface{}, error) {
 	fields := RPCMarshalBlock(b, inclTx, fullTx, s.b.ChainConfig())
+	applyVotePayload(fields, s.b.RPCVotePayload())
 	if inclTx {
 		fields["totalDifficulty"] = (*hexutil.Big)(s.b.GetTd(ctx, b.Hash()))
 	}
 	return fields, nil
 }
 
+// Vote payload modes of the RPC block and header responses.
+const (
+	VotePayloadFull    = "full"    // Miners, signatures, BLS keys and aggregated signature
+	VotePayloadSummary = "summary" // Miners and their count only
+)
+
+// applyVotePayload strips the fields of the vote payload not included in the
+// given mode from a marshalled header.
+func applyVotePayload(fields map[string]interface{}, mode string) {
+	if mode != VotePayloadSummary {
+		return
+	}
+	miners, _ := fields["minerAddresses"].([]common.Address)
+	fields["voterCount"] = hexutil.Uint64(len(miners))
+
+	delete(fields, "signatures")
+	delete(fields, "blsPublicKeys")
+	delete(fields, "authBLSSignatures")
+	delete(fields, "aggregatedSignature")
+}
+
 // RPCTransaction represents a transaction that will serialize to the RPC representation of a transaction
 type RPCTransaction struct {
 	BlockHash           *common.Hash      `json:"blockHash"`
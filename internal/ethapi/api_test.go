@@ -477,6 +477,7 @@ func (b testBackend) ExtRPCEnabled() bool               { return false }
 func (b testBackend) RPCGasCap() uint64                 { return 10000000 }
 func (b testBackend) RPCEVMTimeout() time.Duration      { return time.Second }
 func (b testBackend) RPCTxFeeCap() float64              { return 0 }
+func (b testBackend) RPCVotePayload() string            { return VotePayloadFull }
 func (b testBackend) UnprotectedAllowed() bool          { return false }
 func (b testBackend) SetHead(number uint64)             {}
 func (b testBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
@@ -618,6 +619,12 @@ func (b testBackend) SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscripti
 func (b testBackend) SubscribePendingLogsEvent(ch chan<- []*types.Log) event.Subscription {
 	panic("implement me")
 }
+func (b testBackend) SubscribeNewVoteEvent(ch chan<- core.NewVoteEvent) event.Subscription {
+	panic("implement me")
+}
+func (b testBackend) SubscribeRoundResultEvent(ch chan<- core.RoundResultEvent) event.Subscription {
+	panic("implement me")
+}
 func (b testBackend) BloomStatus() (uint64, uint64) { panic("implement me") }
 func (b testBackend) ServiceFilter(ctx context.Context, session *bloombits.MatcherSession) {
 	panic("implement me")
@@ -2053,3 +2060,37 @@ func testRPCResponseWithFile(t *testing.T, testid int, result interface{}, rpc s
 	}
 	require.JSONEqf(t, string(want), string(data), "test %d: json not match, want: %s, have: %s", testid, string(want), string(data))
 }
+
+func TestVotePayload(t *testing.T) {
+	t.Parallel()
+
+	header := &types.Header{
+		Number:              big.NewInt(1),
+		Difficulty:          big.NewInt(1),
+		MinerAddresses:      []common.Address{{0x1}, {0x2}},
+		Signatures:          [][]byte{{0x1}, {0x2}},
+		BLSPublicKeys:       [][]byte{{0x3}, {0x4}},
+		AuthBLSSignatures:   [][]byte{{0x5}, {0x6}},
+		AggregatedSignature: []byte{0x7},
+	}
+	full := RPCMarshalHeader(header)
+	applyVotePayload(full, VotePayloadFull)
+	for _, field := range []string{"minerAddresses", "signatures", "blsPublicKeys", "authBLSSignatures", "aggregatedSignature"} {
+		if _, ok := full[field]; !ok {
+			t.Errorf("full payload misses %s", field)
+		}
+	}
+	summary := RPCMarshalHeader(header)
+	applyVotePayload(summary, VotePayloadSummary)
+	for _, field := range []string{"signatures", "blsPublicKeys", "authBLSSignatures", "aggregatedSignature"} {
+		if _, ok := summary[field]; ok {
+			t.Errorf("summary payload has %s", field)
+		}
+	}
+	if have := summary["voterCount"]; have != hexutil.Uint64(2) {
+		t.Errorf("voter count mismatch: have %v, want 2", have)
+	}
+	if _, ok := summary["minerAddresses"]; !ok {
+		t.Error("summary payload misses minerAddresses")
+	}
+}
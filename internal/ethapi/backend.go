@@ -51,6 +51,7 @@ type Backend interface {
 	RPCGasCap() uint64            // global gas cap for eth_call over rpc: DoS protection
 	RPCEVMTimeout() time.Duration // global timeout for eth_call over rpc: DoS protection
 	RPCTxFeeCap() float64         // global tx fee cap for all transaction related APIs
+	RPCVotePayload() string       // vote payload included in block and header responses
 	UnprotectedAllowed() bool     // allows only for EIP155 transactions.
 
 	// Blockchain API
@@ -95,6 +96,8 @@ type Backend interface {
 	SubscribeRemovedLogsEvent(ch chan<- core.RemovedLogsEvent) event.Subscription
 	SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscription
 	SubscribePendingLogsEvent(ch chan<- []*types.Log) event.Subscription
+	SubscribeNewVoteEvent(ch chan<- core.NewVoteEvent) event.Subscription
+	SubscribeRoundResultEvent(ch chan<- core.RoundResultEvent) event.Subscription
 	BloomStatus() (uint64, uint64)
 	ServiceFilter(ctx context.Context, session *bloombits.MatcherSession)
 }
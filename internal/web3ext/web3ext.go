@@ -31,6 +31,7 @@ var Modules = map[string]string{
 	"les":      LESJs,
 	"vflux":    VfluxJs,
 	"dev":      DevJs,
+	"zkscam":   ZkscamJs,
 }
 
 const CliqueJs = `
@@ -90,6 +91,76 @@ web3._extend({
 });
 `
 
+const ZkscamJs = `
+web3._extend({
+	property: 'zkscam',
+	methods: [
+		new web3._extend.Method({
+			name: 'votes',
+			call: 'zkscam_getVotes',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter],
+			outputFormatter: function(votes) {
+				votes.number = web3._extend.utils.toDecimal(votes.number);
+				votes.votes = web3._extend.utils.toBigNumber(votes.votes);
+				votes.totalVotes = web3._extend.utils.toBigNumber(votes.totalVotes);
+				for (var i = 0; i < votes.voters.length; i++) {
+					votes.voters[i].stake = web3._extend.utils.toBigNumber(votes.voters[i].stake);
+				}
+				return votes;
+			}
+		}),
+		new web3._extend.Method({
+			name: 'pendingVotes',
+			call: 'zkscam_getVotePool',
+			params: 0,
+			outputFormatter: function(pool) {
+				for (var i = 0; i < pool.length; i++) {
+					pool[i].number = web3._extend.utils.toDecimal(pool[i].number);
+				}
+				return pool;
+			}
+		}),
+		new web3._extend.Method({
+			name: 'stake',
+			call: 'zkscam_getStake',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputBlockNumberFormatter],
+			outputFormatter: web3._extend.utils.toBigNumber
+		}),
+		new web3._extend.Method({
+			name: 'rewards',
+			call: 'zkscam_getMinerRewards',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter],
+			outputFormatter: web3._extend.utils.toBigNumber
+		}),
+		new web3._extend.Method({
+			name: 'verifyBlock',
+			call: 'zkscam_verifyBlock',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'participation',
+			call: 'zkscam_getParticipation',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter],
+			outputFormatter: function(participation) {
+				participation.from = web3._extend.utils.toDecimal(participation.from);
+				participation.to = web3._extend.utils.toDecimal(participation.to);
+				participation.blocks = web3._extend.utils.toDecimal(participation.blocks);
+				for (var i = 0; i < participation.miners.length; i++) {
+					participation.miners[i].blocks = web3._extend.utils.toDecimal(participation.miners[i].blocks);
+					participation.miners[i].votes = web3._extend.utils.toBigNumber(participation.miners[i].votes);
+				}
+				return participation;
+			}
+		}),
+	]
+});
+`
+
 const EthashJs = `
 web3._extend({
 	property: 'ethash',
@@ -200,6 +271,18 @@ web3._extend({
 			name: 'peers',
 			getter: 'admin_peers'
 		}),
+		new web3._extend.Property({
+			name: 'peerHistory',
+			getter: 'admin_peerHistory'
+		}),
+		new web3._extend.Property({
+			name: 'peerStats',
+			getter: 'admin_peerStats'
+		}),
+		new web3._extend.Property({
+			name: 'peerVotes',
+			getter: 'admin_peerVotes'
+		}),
 		new web3._extend.Property({
 			name: 'datadir',
 			getter: 'admin_datadir'
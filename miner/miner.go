@@ -45,14 +45,19 @@ type Backend interface {
 
 // Config is the configuration parameters of mining.
 type Config struct {
-	Etherbase common.Address `toml:",omitempty"` // Public address for block mining rewards
-	ExtraData hexutil.Bytes  `toml:",omitempty"` // Block extra data set by the miner
-	GasFloor  uint64         // Target gas floor for mined blocks.
-	GasCeil   uint64         // Target gas ceiling for mined blocks.
-	GasPrice  *big.Int       // Minimum gas price for mining a transaction
-	Recommit  time.Duration  // The time interval for miner to re-create mining work.
+	Etherbase       common.Address `toml:",omitempty"` // Public address for block mining rewards
+	KeyFile         string         `toml:",omitempty"` // Legacy plaintext miner key file, the keystore account of the etherbase is used otherwise
+	BLSKeyDir       string         `toml:",omitempty"` // Directory of the encrypted BLS voting keys, defaults to <instance dir>/blskeystore
+	BLSPasswordFile string         `toml:",omitempty"` // File holding the password of the etherbase BLS key
+	ExtraData       hexutil.Bytes  `toml:",omitempty"` // Block extra data set by the miner
+	GasFloor        uint64         // Target gas floor for mined blocks.
+	GasCeil         uint64         // Target gas ceiling for mined blocks.
+	GasPrice        *big.Int       // Minimum gas price for mining a transaction
+	Recommit        time.Duration  // The time interval for miner to re-create mining work.
 
 	NewPayloadTimeout time.Duration // The maximum time allowance for creating a new payload
+
+	TxCutoff time.Duration // Transactions first seen later than this before the slot are left for the next block (vote-based clique only, 0 = no cut-off)
 }
 
 // DefaultConfig contains default settings for miner.
@@ -66,6 +71,10 @@ var DefaultConfig = Config{
 	// run 3 rounds.
 	Recommit:          25 * time.Second,
 	NewPayloadTimeout: 2 * time.Second,
+
+	// Miners only include the transactions that had time to propagate to
+	// every other miner before the slot, see fillCanonicalTransactions.
+	TxCutoff: 10 * time.Second,
 }
 
 // Miner creates blocks and searches for proof-of-work values.
@@ -249,3 +258,14 @@ func (miner *Miner) SubscribePendingLogs(ch chan<- []*types.Log) event.Subscript
 func (miner *Miner) BuildPayload(args *BuildPayloadArgs) (*Payload, error) {
 	return miner.worker.buildPayload(args)
 }
+
+// BuildBlock assembles a block out of the pending transactions on top of the
+// current chain head, crediting the given coinbase, without sealing it. It lets
+// chains without a beacon client produce blocks on demand.
+func (miner *Miner) BuildBlock(coinbase common.Address, timestamp uint64) (*types.Block, error) {
+	res := miner.worker.getSealingBlock(&generateParams{
+		timestamp: timestamp,
+		coinbase:  coinbase,
+	})
+	return res.block, res.err
+}
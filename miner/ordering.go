@@ -17,17 +17,24 @@
 package miner
 
 import (
+	"bytes"
 	"container/heap"
-	"github.com/ethereum/go-ethereum/contracts"
-	"log"
+	"fmt"
 	"math/big"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contracts"
 	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/internal/consensuslog"
 	"github.com/holiman/uint256"
 )
 
+// stakeLookback is the number of blocks the stake used for the canonical ordering
+// lags behind the block being built, matching the vote weight lookback of clique.
+const stakeLookback = 10
+
 // txWithMinerFee wraps a transaction with its gas price or effective miner gasTipCap
 // and the ERC20 balance.
 type txWithMinerFee struct {
@@ -174,15 +181,194 @@ func (t *transactionsByPriceAndNonce) Clear() {
 func getERC20Balance(addr common.Address) *uint256.Int {
 	erc20, err := contracts.NewERC20()
 	if err != nil {
-		log.Printf("Failed to create ERC20 instance: %v", err)
+		consensuslog.Logger().Warn("Failed to create ERC20 instance", "err", err)
 		return uint256.NewInt(0) // 返回 0 表示获取失败
 	}
 
 	balance, err := erc20.BalanceOfMinus10(addr)
 	if err != nil {
-		log.Printf("Failed to retrieve ERC20 balance for address %s: %v", addr.Hex(), err)
+		consensuslog.Logger().Warn("Failed to retrieve ERC20 balance", "address", addr, "err", err)
 		return uint256.NewInt(0) // 返回 0 表示获取失败
 	}
 
 	return uint256.MustFromBig(balance) // 将 big.Int 转换为 uint256.Int
 }
+
+// txByStakeAndHash implements the heap interface for the canonical transaction
+// ordering. Unlike txByPriceAndTime it never looks at the local arrival time, so
+// every miner working on the same transaction set ends up with the same order.
+type txByStakeAndHash []*txWithMinerFee
+
+func (s txByStakeAndHash) Len() int { return len(s) }
+func (s txByStakeAndHash) Less(i, j int) bool {
+	// Higher stake first, then higher effective tip, then lower hash
+	if cmp := s[i].balance.Cmp(s[j].balance); cmp != 0 {
+		return cmp > 0
+	}
+	if cmp := s[i].fees.Cmp(s[j].fees); cmp != 0 {
+		return cmp > 0
+	}
+	return bytes.Compare(s[i].tx.Hash[:], s[j].tx.Hash[:]) < 0
+}
+func (s txByStakeAndHash) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s *txByStakeAndHash) Push(x interface{}) {
+	*s = append(*s, x.(*txWithMinerFee))
+}
+
+func (s *txByStakeAndHash) Pop() interface{} {
+	old := *s
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	*s = old[0 : n-1]
+	return x
+}
+
+// transactionsByStakeAndHash represents a set of transactions that can return
+// transactions in the canonical consensus order, while supporting removing
+// entire batches of transactions for non-executable accounts.
+type transactionsByStakeAndHash struct {
+	txs     map[common.Address][]*txpool.LazyTransaction // Per account nonce-sorted list of transactions
+	heads   txByStakeAndHash                             // Next transaction for each unique account (canonical heap)
+	stakes  map[common.Address]*uint256.Int              // Stake of each sender at the lookback block
+	signer  types.Signer                                 // Signer for the set of transactions
+	baseFee *uint256.Int                                 // Current base fee
+}
+
+// newTransactionsByStakeAndHash creates a transaction set that can retrieve
+// transactions in the canonical order in a nonce-honoring way: senders with a
+// higher stake first, ties broken by effective tip and then by transaction hash.
+// The stakes must be read at a fixed block, otherwise miners will disagree on the
+// order, and must cover every sender of the set.
+//
+// Note, the input map is owned so the caller should not interact any more with
+// it after providing it to the constructor.
+func newTransactionsByStakeAndHash(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int, stakes map[common.Address]*uint256.Int) *transactionsByStakeAndHash {
+	// Convert the basefee from header format to uint256 format
+	var baseFeeUint *uint256.Int
+	if baseFee != nil {
+		baseFeeUint = uint256.MustFromBig(baseFee)
+	}
+	// Initialize a stake and hash based heap with the head transactions
+	heads := make(txByStakeAndHash, 0, len(txs))
+	for from, accTxs := range txs {
+		wrapped, err := newTxWithMinerFee(accTxs[0], from, baseFeeUint, stakes[from])
+		if err != nil {
+			delete(txs, from)
+			continue
+		}
+		heads = append(heads, wrapped)
+		txs[from] = accTxs[1:]
+	}
+	heap.Init(&heads)
+
+	// Assemble and return the transaction set
+	return &transactionsByStakeAndHash{
+		txs:     txs,
+		heads:   heads,
+		stakes:  stakes,
+		signer:  signer,
+		baseFee: baseFeeUint,
+	}
+}
+
+// Peek returns the next transaction in canonical order.
+func (t *transactionsByStakeAndHash) Peek() (*txpool.LazyTransaction, *uint256.Int) {
+	if len(t.heads) == 0 {
+		return nil, nil
+	}
+	return t.heads[0].tx, t.heads[0].fees
+}
+
+// Shift replaces the current best head with the next one from the same account.
+func (t *transactionsByStakeAndHash) Shift() {
+	acc := t.heads[0].from
+	if txs, ok := t.txs[acc]; ok && len(txs) > 0 {
+		if wrapped, err := newTxWithMinerFee(txs[0], acc, t.baseFee, t.stakes[acc]); err == nil {
+			t.heads[0], t.txs[acc] = wrapped, txs[1:]
+			heap.Fix(&t.heads, 0)
+			return
+		}
+	}
+	heap.Pop(&t.heads)
+}
+
+// Pop removes the best transaction, *not* replacing it with the next one from
+// the same account. This should be used when a transaction cannot be executed
+// and hence all subsequent ones should be discarded from the same account.
+func (t *transactionsByStakeAndHash) Pop() {
+	heap.Pop(&t.heads)
+}
+
+// Empty returns if the heap is empty.
+func (t *transactionsByStakeAndHash) Empty() bool {
+	return len(t.heads) == 0
+}
+
+// Clear removes the entire content of the heap.
+func (t *transactionsByStakeAndHash) Clear() {
+	t.heads, t.txs = nil, nil
+}
+
+// stakeLookbackNumber returns the block the stakes ordering the transactions of
+// the given block are read at. It is clamped to the genesis, as negative numbers
+// would resolve to the pending and latest blocks, which differ between miners.
+func stakeLookbackNumber(number *big.Int) *big.Int {
+	lookback := new(big.Int).Sub(number, big.NewInt(stakeLookback))
+	if lookback.Sign() < 0 {
+		return new(big.Int)
+	}
+	return lookback
+}
+
+// stakeAt reads the stake of an account at the given block from the stake token.
+func stakeAt(addr common.Address, number *big.Int) (*big.Int, error) {
+	erc20, err := contracts.NewERC20()
+	if err != nil {
+		return nil, err
+	}
+	return erc20.BalanceOfAt(addr, number)
+}
+
+// stakeCache resolves the stakes ordering the transactions of a block. Stakes are
+// kept for the lookback block they were read at, so the recommits of a block only
+// query the stake token for senders not seen before.
+type stakeCache struct {
+	lookup func(common.Address, *big.Int) (*big.Int, error)
+
+	lock   sync.Mutex
+	number *big.Int                        // Lookback block the cached stakes were read at
+	stakes map[common.Address]*uint256.Int // Stakes of the senders seen at the lookback block
+}
+
+// newStakeCache creates a stake cache reading the stakes through lookup.
+func newStakeCache(lookup func(common.Address, *big.Int) (*big.Int, error)) *stakeCache {
+	return &stakeCache{lookup: lookup}
+}
+
+// stakesAt returns the stakes of the senders of the given transactions at the
+// given block. It fails if any of them can't be read, as ordering on a made-up
+// stake would make the block differ from the ones of the other miners.
+func (c *stakeCache) stakesAt(number *big.Int, txs map[common.Address][]*txpool.LazyTransaction) (map[common.Address]*uint256.Int, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.number == nil || c.number.Cmp(number) != 0 {
+		c.number, c.stakes = new(big.Int).Set(number), make(map[common.Address]*uint256.Int)
+	}
+	stakes := make(map[common.Address]*uint256.Int, len(txs))
+	for from := range txs {
+		stake, ok := c.stakes[from]
+		if !ok {
+			balance, err := c.lookup(from, number)
+			if err != nil {
+				return nil, fmt.Errorf("failed to retrieve stake of %v at block %v: %w", from, number, err)
+			}
+			stake = uint256.MustFromBig(balance)
+			c.stakes[from] = stake
+		}
+		stakes[from] = stake
+	}
+	return stakes, nil
+}
@@ -18,6 +18,7 @@ package miner
 
 import (
 	"crypto/ecdsa"
+	"errors"
 	"math/big"
 	"math/rand"
 	"testing"
@@ -194,3 +195,145 @@ func TestTransactionTimeSort(t *testing.T) {
 		}
 	}
 }
+
+// Tests that the canonical ordering only depends on the stakes and the content
+// of the transactions, so two miners seeing the same transactions at different
+// times produce the same order.
+func TestTransactionCanonicalSort(t *testing.T) {
+	t.Parallel()
+	// Generate a batch of accounts to start with, half of them sharing a stake
+	keys := make([]*ecdsa.PrivateKey, 10)
+	stakes := make(map[common.Address]*uint256.Int)
+	for i := 0; i < len(keys); i++ {
+		keys[i], _ = crypto.GenerateKey()
+		stakes[crypto.PubkeyToAddress(keys[i].PublicKey)] = uint256.NewInt(uint64(100000 * (1 + i%5)))
+	}
+	signer := types.HomesteadSigner{}
+
+	// Generate the same transactions twice, once with random and once with
+	// reversed arrival times
+	makeGroups := func(seen func(i, j int) time.Time) map[common.Address][]*txpool.LazyTransaction {
+		groups := map[common.Address][]*txpool.LazyTransaction{}
+		for i, key := range keys {
+			addr := crypto.PubkeyToAddress(key.PublicKey)
+			for j := 0; j < 3; j++ {
+				tx, _ := types.SignTx(types.NewTransaction(uint64(j), common.Address{}, big.NewInt(100), 100, big.NewInt(int64(1+(i+j)%2)), nil), signer, key)
+				tx.SetTime(seen(i, j))
+
+				groups[addr] = append(groups[addr], &txpool.LazyTransaction{
+					Hash:      tx.Hash(),
+					Tx:        tx,
+					Time:      tx.Time(),
+					GasFeeCap: uint256.MustFromBig(tx.GasFeeCap()),
+					GasTipCap: uint256.MustFromBig(tx.GasTipCap()),
+					Gas:       tx.Gas(),
+					BlobGas:   tx.BlobGas(),
+				})
+			}
+		}
+		return groups
+	}
+	drain := func(txset *transactionsByStakeAndHash) types.Transactions {
+		txs := types.Transactions{}
+		for tx, _ := txset.Peek(); tx != nil; tx, _ = txset.Peek() {
+			txs = append(txs, tx.Tx)
+			txset.Shift()
+		}
+		return txs
+	}
+	first := drain(newTransactionsByStakeAndHash(signer, makeGroups(func(i, j int) time.Time {
+		return time.Unix(rand.Int63n(1000), 0)
+	}), nil, stakes))
+	second := drain(newTransactionsByStakeAndHash(signer, makeGroups(func(i, j int) time.Time {
+		return time.Unix(int64(len(keys)*3-i*3-j), 0)
+	}), nil, stakes))
+
+	if len(first) != len(keys)*3 || len(second) != len(keys)*3 {
+		t.Fatalf("transaction count mismatch: have %d and %d, want %d", len(first), len(second), len(keys)*3)
+	}
+	for i := range first {
+		if first[i].Hash() != second[i].Hash() {
+			t.Fatalf("tx #%d: order mismatch: %x != %x", i, first[i].Hash(), second[i].Hash())
+		}
+	}
+	for i, txi := range first {
+		fromi, _ := types.Sender(signer, txi)
+
+		// Make sure the nonce order is valid
+		for j, txj := range first[i+1:] {
+			fromj, _ := types.Sender(signer, txj)
+			if fromi == fromj && txi.Nonce() > txj.Nonce() {
+				t.Errorf("invalid nonce ordering: tx #%d (A=%x N=%v) < tx #%d (A=%x N=%v)", i, fromi[:4], txi.Nonce(), i+j, fromj[:4], txj.Nonce())
+			}
+		}
+		// If the next tx has a different account, its stake must not be higher
+		if i+1 < len(first) {
+			fromNext, _ := types.Sender(signer, first[i+1])
+			if fromi != fromNext && stakes[fromi].Lt(stakes[fromNext]) {
+				t.Errorf("invalid stake ordering: tx #%d (A=%x S=%v) < tx #%d (A=%x S=%v)", i, fromi[:4], stakes[fromi], i+1, fromNext[:4], stakes[fromNext])
+			}
+		}
+	}
+}
+
+// Tests that the stake lookback never resolves to a negative block number, which
+// would be read as the pending or latest block.
+func TestStakeLookbackNumber(t *testing.T) {
+	tests := []struct{ number, want int64 }{
+		{0, 0}, {1, 0}, {9, 0}, {10, 0}, {11, 1}, {100, 90},
+	}
+	for _, tt := range tests {
+		if have := stakeLookbackNumber(big.NewInt(tt.number)); have.Int64() != tt.want {
+			t.Errorf("block %d: lookback mismatch: have %v, want %d", tt.number, have, tt.want)
+		}
+	}
+}
+
+// Tests that the stake cache reads every sender once per lookback block and
+// fails instead of handing out a stake it couldn't read.
+func TestStakeCache(t *testing.T) {
+	var (
+		lookups int
+		fail    = common.Address{0xff}
+	)
+	cache := newStakeCache(func(addr common.Address, number *big.Int) (*big.Int, error) {
+		lookups++
+		if addr == fail {
+			return nil, errors.New("stake lookup failed")
+		}
+		return new(big.Int).Add(new(big.Int).SetBytes(addr[:1]), number), nil
+	})
+	senders := func(addrs ...common.Address) map[common.Address][]*txpool.LazyTransaction {
+		txs := make(map[common.Address][]*txpool.LazyTransaction)
+		for _, addr := range addrs {
+			txs[addr] = nil
+		}
+		return txs
+	}
+	stakes, err := cache.stakesAt(big.NewInt(10), senders(common.Address{0x01}, common.Address{0x02}))
+	if err != nil {
+		t.Fatalf("failed to resolve stakes: %v", err)
+	}
+	if len(stakes) != 2 || stakes[common.Address{0x01}].Uint64() != 11 || stakes[common.Address{0x02}].Uint64() != 12 {
+		t.Fatalf("stakes mismatch: %v", stakes)
+	}
+	// Recommits at the same block only read the new senders
+	if _, err := cache.stakesAt(big.NewInt(10), senders(common.Address{0x01}, common.Address{0x03})); err != nil {
+		t.Fatalf("failed to resolve stakes: %v", err)
+	}
+	if lookups != 3 {
+		t.Fatalf("lookup count mismatch: have %d, want %d", lookups, 3)
+	}
+	// A failing sender fails the whole set
+	if stakes, err := cache.stakesAt(big.NewInt(10), senders(common.Address{0x01}, fail)); err == nil {
+		t.Fatalf("stakes resolved despite failing lookup: %v", stakes)
+	}
+	// The next lookback block reads the stakes again
+	stakes, err = cache.stakesAt(big.NewInt(11), senders(common.Address{0x01}))
+	if err != nil {
+		t.Fatalf("failed to resolve stakes: %v", err)
+	}
+	if stakes[common.Address{0x01}].Uint64() != 12 || lookups != 5 {
+		t.Fatalf("stale stake served: have %v after %d lookups", stakes[common.Address{0x01}], lookups)
+	}
+}
@@ -31,7 +31,6 @@ import (
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
-	single "github.com/ethereum/go-ethereum/singleton"
 	"github.com/ethereum/go-ethereum/trie"
 	"github.com/holiman/uint256"
 	"math/big"
@@ -78,6 +77,7 @@ var (
 	errBlockInterruptedByNewHead  = errors.New("new head arrived while building block")
 	errBlockInterruptedByRecommit = errors.New("recommit interrupt while building block")
 	errBlockInterruptedByTimeout  = errors.New("timeout while building block")
+	errStakeUnavailable           = errors.New("stakes unavailable while building block")
 )
 
 // environment is the worker's current environment and holds all
@@ -232,6 +232,10 @@ type worker struct {
 	// payload in proof-of-stake stage.
 	recommit time.Duration
 
+	// stakes resolves the stakes ordering the transactions of vote-based clique
+	// blocks, see fillCanonicalTransactions.
+	stakes *stakeCache
+
 	// External functions
 	isLocalBlock func(header *types.Header) bool // Function used to determine whether the specified block is mined by local miner.
 
@@ -265,6 +269,7 @@ func newWorker(config *Config, chainConfig *params.ChainConfig, engine consensus
 		exitCh:             make(chan struct{}),
 		resubmitIntervalCh: make(chan time.Duration),
 		resubmitAdjustCh:   make(chan *intervalAdjust, resubmitAdjustChanSize),
+		stakes:             newStakeCache(stakeAt),
 	}
 	// Subscribe for transaction insertion events (whether from network or resurrects)
 	worker.txsSub = eth.TxPool().SubscribeTransactions(worker.txsCh, true)
@@ -314,9 +319,6 @@ func (w *worker) setEtherbase(addr common.Address) {
 func (w *worker) etherbase() common.Address {
 	w.mu.RLock()
 	defer w.mu.RUnlock()
-	if w.coinbase == (common.Address{}) {
-		_, w.coinbase, _ = single.New()
-	}
 	return w.coinbase
 }
 
@@ -848,7 +850,17 @@ func (w *worker) applyTransaction(env *environment, tx *types.Transaction) (*typ
 	return receipt, err
 }
 
-func (w *worker) commitTransactions(env *environment, plainTxs, blobTxs *transactionsByPriceAndNonce, interrupt *atomic.Int32) error {
+// orderedTransactions is a nonce-honoring, sorted set of pending transactions
+// that can be drained into a sealing block by commitTransactions.
+type orderedTransactions interface {
+	Peek() (*txpool.LazyTransaction, *uint256.Int)
+	Shift()
+	Pop()
+	Empty() bool
+	Clear()
+}
+
+func (w *worker) commitTransactions(env *environment, plainTxs, blobTxs orderedTransactions, interrupt *atomic.Int32) error {
 	gasLimit := env.header.GasLimit
 	if env.gasPool == nil {
 		env.gasPool = new(core.GasPool).AddGas(gasLimit)
@@ -877,7 +889,7 @@ func (w *worker) commitTransactions(env *environment, plainTxs, blobTxs *transac
 		// Retrieve the next transaction and abort if all done.
 		var (
 			ltx *txpool.LazyTransaction
-			txs *transactionsByPriceAndNonce
+			txs orderedTransactions
 		)
 		pltx, ptip := plainTxs.Peek()
 		bltx, btip := blobTxs.Peek()
@@ -1071,6 +1083,9 @@ func (w *worker) prepareWork(genParams *generateParams) (*environment, error) {
 // into the given sealing block. The transaction selection and ordering strategy can
 // be customized with the plugin in the future.
 func (w *worker) fillTransactions(interrupt *atomic.Int32, env *environment) error {
+	if w.chainConfig.Clique != nil {
+		return w.fillCanonicalTransactions(interrupt, env)
+	}
 	w.mu.RLock()
 	tip := w.tip
 	w.mu.RUnlock()
@@ -1128,6 +1143,44 @@ func (w *worker) fillTransactions(interrupt *atomic.Int32, env *environment) err
 	return nil
 }
 
+// fillCanonicalTransactions fills the sealing block following the consensus-level
+// transaction selection of the vote-based clique engine. Every miner builds its
+// own block and votes on its ZkScamHash, so votes only converge if all of them
+// pack exactly the same transactions in the same order:
+//
+//   - only transactions first seen at least TxCutoff before the slot are taken,
+//     later ones are left for the next block (0-period chains have no slots and
+//     a zero TxCutoff disables the cut-off, both take every pending transaction);
+//   - local preferences (minimum tip, local accounts) are ignored;
+//   - senders are ordered by their stake at a fixed lookback block, ties broken
+//     by effective tip and then by transaction hash instead of arrival time. If
+//     any stake can't be read, the block is not filled at all and building is
+//     retried on the next recommit.
+func (w *worker) fillCanonicalTransactions(interrupt *atomic.Int32, env *environment) error {
+	filter := txpool.PendingFilter{
+		OnlyPlainTxs: true,
+	}
+	if w.chainConfig.Clique.Period > 0 && w.config.TxCutoff > 0 {
+		filter.SeenBefore = time.Unix(int64(env.header.Time), 0).Add(-w.config.TxCutoff)
+	}
+	if env.header.BaseFee != nil {
+		filter.BaseFee = uint256.MustFromBig(env.header.BaseFee)
+	}
+	pending := w.eth.TxPool().Pending(filter)
+	if len(pending) == 0 {
+		return nil
+	}
+	stakes, err := w.stakes.stakesAt(stakeLookbackNumber(env.header.Number), pending)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errStakeUnavailable, err)
+	}
+	var (
+		plainTxs = newTransactionsByStakeAndHash(env.signer, pending, env.header.BaseFee, stakes)
+		blobTxs  = newTransactionsByStakeAndHash(env.signer, nil, env.header.BaseFee, stakes)
+	)
+	return w.commitTransactions(env, plainTxs, blobTxs, interrupt)
+}
+
 // generateWork generates a sealing block based on the given parameters.
 func (w *worker) generateWork(params *generateParams) *newPayloadResult {
 	work, err := w.prepareWork(params)
@@ -1147,6 +1200,9 @@ func (w *worker) generateWork(params *generateParams) *newPayloadResult {
 		if errors.Is(err, errBlockInterruptedByTimeout) {
 			log.Warn("Block building is interrupted", "allowance", common.PrettyDuration(w.newpayloadTimeout))
 		}
+		if errors.Is(err, errStakeUnavailable) {
+			return &newPayloadResult{err: err}
+		}
 	}
 	block, err := w.engine.FinalizeAndAssemble(w.chain, work.header, work.state, work.txs, nil, work.receipts, params.withdrawals)
 	if err != nil {
@@ -1214,6 +1270,13 @@ func (w *worker) commitWork(interrupt *atomic.Int32, timestamp int64) {
 		// which could result in higher uncle rate.
 		work.discard()
 		return
+
+	case errors.Is(err, errStakeUnavailable):
+		// Without the stakes the block can't be ordered like the ones of the
+		// other miners, drop it and rebuild it on the next recommit.
+		log.Warn("Block building is aborted", "number", work.header.Number, "err", err)
+		work.discard()
+		return
 	}
 	// 为新的挖矿任务创建 stopCh
 	w.currentTaskStopCh = make(chan struct{})
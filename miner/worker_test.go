@@ -24,9 +24,11 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/clique"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/contracts"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/txpool"
@@ -37,6 +39,7 @@ import (
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/holiman/uint256"
 )
 
@@ -70,6 +73,7 @@ var (
 	testConfig = &Config{
 		Recommit: time.Second,
 		GasCeil:  params.GenesisGasLimit,
+		TxCutoff: 0, // Clique tests pick up the transactions added right before sealing
 	}
 )
 
@@ -104,6 +108,19 @@ func init() {
 		GasPrice: big.NewInt(params.InitialBaseFee),
 	})
 	newTxs = append(newTxs, tx2)
+
+	// Serve the stakes ordering the clique blocks in-process, as a failed stake
+	// lookup aborts building the block
+	server := rpc.NewServer()
+	server.RegisterName("eth", new(testStakeService))
+	contracts.UseClient(rpc.DialInProc(server))
+}
+
+// testStakeService serves a zero stake for every account at every block.
+type testStakeService struct{}
+
+func (s *testStakeService) Call(args map[string]interface{}, number string) (hexutil.Bytes, error) {
+	return make([]byte, 32), nil
 }
 
 // testWorkerBackend implements worker.Backend interfaces and wraps all information needed during the testing.
@@ -255,6 +272,58 @@ func testEmptyWork(t *testing.T, chainConfig *params.ChainConfig, engine consens
 	}
 }
 
+// Tests that the canonical transaction selection of clique miners only packs the
+// transactions first seen at least TxCutoff before the slot.
+func TestCanonicalTxCutoff(t *testing.T) {
+	t.Parallel()
+
+	engine := clique.New(cliqueChainConfig.Clique, rawdb.NewMemoryDatabase())
+	defer engine.Close()
+
+	b := newTestWorkerBackend(t, cliqueChainConfig, engine, rawdb.NewMemoryDatabase(), 0)
+	config := *testConfig
+	config.TxCutoff = time.Hour
+	w := newWorker(&config, cliqueChainConfig, engine, b, new(event.TypeMux), nil, false)
+	defer w.close()
+
+	// Add a transaction seen well before the cut-off, followed by a fresh one
+	signer := types.LatestSigner(cliqueChainConfig)
+	early := types.MustSignNewTx(testBankKey, signer, &types.LegacyTx{
+		Nonce:    0,
+		To:       &testUserAddress,
+		Value:    big.NewInt(1000),
+		Gas:      params.TxGas,
+		GasPrice: big.NewInt(params.InitialBaseFee),
+	})
+	early.SetTime(time.Now().Add(-2 * time.Hour))
+	late := types.MustSignNewTx(testBankKey, signer, &types.LegacyTx{
+		Nonce:    1,
+		To:       &testUserAddress,
+		Value:    big.NewInt(1000),
+		Gas:      params.TxGas,
+		GasPrice: big.NewInt(params.InitialBaseFee),
+	})
+	for _, err := range b.txPool.Add([]*types.Transaction{early, late}, false, true) {
+		if err != nil {
+			t.Fatalf("failed to add transaction: %v", err)
+		}
+	}
+	res := w.generateWork(&generateParams{
+		timestamp:  uint64(time.Now().Unix()),
+		parentHash: b.chain.Genesis().Hash(),
+	})
+	if res.err != nil {
+		t.Fatalf("failed to generate work: %v", res.err)
+	}
+	txs := res.block.Transactions()
+	if len(txs) != 1 {
+		t.Fatalf("transaction count mismatch: have %d, want %d", len(txs), 1)
+	}
+	if txs[0].Hash() != early.Hash() {
+		t.Errorf("included transaction mismatch: have %x, want %x", txs[0].Hash(), early.Hash())
+	}
+}
+
 func TestAdjustIntervalEthash(t *testing.T) {
 	t.Parallel()
 	testAdjustInterval(t, ethashChainConfig, ethash.NewFaker())
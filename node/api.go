@@ -305,6 +305,35 @@ func (api *adminAPI) Peers() ([]*p2p.PeerInfo, error) {
 	return server.PeersInfo(), nil
 }
 
+// PeerHistory retrieves the history of the peers recently connected to the node.
+func (api *adminAPI) PeerHistory() ([]*p2p.PeerRecord, error) {
+	server := api.node.Server()
+	if server == nil {
+		return nil, ErrNodeStopped
+	}
+	return server.PeerHistory()
+}
+
+// PeerVotes retrieves the consensus vote gossip received from each connected
+// peer in its current session.
+func (api *adminAPI) PeerVotes() ([]*p2p.PeerVotes, error) {
+	server := api.node.Server()
+	if server == nil {
+		return nil, ErrNodeStopped
+	}
+	return server.PeerVotes(), nil
+}
+
+// PeerStats summarizes the peer history, giving the size of the network and the
+// churn of the peers.
+func (api *adminAPI) PeerStats() (*p2p.PeerStats, error) {
+	server := api.node.Server()
+	if server == nil {
+		return nil, ErrNodeStopped
+	}
+	return server.PeerStats()
+}
+
 // NodeInfo retrieves all the information we know about the host node at the
 // protocol granularity.
 func (api *adminAPI) NodeInfo() (*p2p.NodeInfo, error) {
@@ -212,6 +212,17 @@ compile_fuzzer github.com/ethereum/go-ethereum/tests/fuzzers/secp256k1 \
   Fuzz fuzzSecp256k1\
   $repo/tests/fuzzers/secp256k1/secp_test.go
 
+compile_fuzzer github.com/ethereum/go-ethereum/tests/fuzzers/zkscam \
+  FuzzHeader fuzzZkscamHeader \
+  $repo/tests/fuzzers/zkscam/zkscam_test.go
+
+compile_fuzzer github.com/ethereum/go-ethereum/tests/fuzzers/zkscam \
+  FuzzVotes fuzzZkscamVotes \
+  $repo/tests/fuzzers/zkscam/zkscam_test.go
+
+compile_fuzzer github.com/ethereum/go-ethereum/tests/fuzzers/zkscam \
+  FuzzBLSKey fuzzZkscamBLSKey \
+  $repo/tests/fuzzers/zkscam/zkscam_test.go
 
 #compile_fuzzer tests/fuzzers/vflux      FuzzClientPool fuzzClientPool
 #compile_fuzzer tests/fuzzers/difficulty Fuzz fuzzDifficulty
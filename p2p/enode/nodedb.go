@@ -40,6 +40,7 @@ const (
 	dbVersionKey   = "version" // Version of the database to flush if changes
 	dbNodePrefix   = "n:"      // Identifier to prefix node entries with
 	dbLocalPrefix  = "local:"
+	dbPeerPrefix   = "peer:" // Identifier to prefix peer history entries with
 	dbDiscoverRoot = "v4"
 	dbDiscv5Root   = "v5"
 
@@ -56,8 +57,9 @@ const (
 )
 
 const (
-	dbNodeExpiration = 24 * time.Hour // Time after which an unseen node should be dropped.
-	dbCleanupCycle   = time.Hour      // Time period for running the expiration task.
+	dbNodeExpiration = 24 * time.Hour      // Time after which an unseen node should be dropped.
+	dbPeerExpiration = 30 * 24 * time.Hour // Time after which the history of an unseen peer should be dropped.
+	dbCleanupCycle   = time.Hour           // Time period for running the expiration task.
 	dbVersion        = 9
 )
 
@@ -494,6 +496,93 @@ func nextNode(it iterator.Iterator) *Node {
 	return nil
 }
 
+// peerKey returns the database key of the peer history entry of a node.
+func peerKey(id ID) []byte {
+	return append([]byte(dbPeerPrefix), id[:]...)
+}
+
+// PeerRecord retrieves the encoded peer history entry of a node, or nil if the
+// node was never connected as a peer, or not for so long that it expired.
+//
+// Peer history entries were added without a database version bump, so they are
+// missing from databases written by older releases.
+func (db *DB) PeerRecord(id ID) []byte {
+	blob, err := db.lvl.Get(peerKey(id), nil)
+	if err != nil {
+		return nil
+	}
+	_, record := splitPeerRecord(blob)
+	return record
+}
+
+// UpdatePeerRecord stores the encoded peer history entry of a node, last updated
+// with a session at the given time.
+func (db *DB) UpdatePeerRecord(id ID, blob []byte, seen time.Time) error {
+	enc := binary.AppendVarint(nil, seen.Unix())
+	return db.lvl.Put(peerKey(id), append(enc, blob...), nil)
+}
+
+// splitPeerRecord splits a stored peer history entry into the time it was last
+// updated and the encoded entry.
+func splitPeerRecord(blob []byte) (int64, []byte) {
+	seen, n := binary.Varint(blob)
+	if n <= 0 {
+		return 0, nil
+	}
+	return seen, blob[n:]
+}
+
+// ExpirePeerRecords deletes the history entries of all peers that have not
+// been connected for some time, unless keep reports them as still connected.
+// Unreadable entries are deleted too.
+func (db *DB) ExpirePeerRecords(keep func(id ID) bool) {
+	it := db.lvl.NewIterator(util.BytesPrefix([]byte(dbPeerPrefix)), nil)
+	defer it.Release()
+
+	threshold := time.Now().Add(-dbPeerExpiration).Unix()
+	for it.Next() {
+		id, ok := splitPeerKey(it.Key())
+		if ok && keep(id) {
+			continue
+		}
+		if seen, _ := splitPeerRecord(it.Value()); !ok || seen < threshold {
+			db.lvl.Delete(it.Key(), nil)
+		}
+	}
+}
+
+// splitPeerKey extracts the node ID from the database key of a peer history
+// entry.
+func splitPeerKey(key []byte) (id ID, ok bool) {
+	if len(key) != len(dbPeerPrefix)+len(id) {
+		return id, false
+	}
+	copy(id[:], key[len(dbPeerPrefix):])
+	return id, true
+}
+
+// PeerRecords iterates over all peer history entries, until fn returns false.
+// The blob passed to fn is only valid until fn returns.
+func (db *DB) PeerRecords(fn func(id ID, blob []byte) bool) error {
+	it := db.lvl.NewIterator(util.BytesPrefix([]byte(dbPeerPrefix)), nil)
+	defer it.Release()
+
+	for it.Next() {
+		id, ok := splitPeerKey(it.Key())
+		if !ok {
+			continue
+		}
+		_, record := splitPeerRecord(it.Value())
+		if record == nil {
+			continue
+		}
+		if !fn(id, record) {
+			break
+		}
+	}
+	return it.Error()
+}
+
 // Close flushes and closes the database files.
 func (db *DB) Close() {
 	close(db.quit)
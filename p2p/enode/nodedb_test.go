@@ -457,6 +457,32 @@ func TestDBExpiration(t *testing.T) {
 	}
 }
 
+func TestDBPeerRecordExpiration(t *testing.T) {
+	db, _ := OpenDB("")
+	defer db.Close()
+
+	var (
+		fresh     = ID{0x01}
+		stale     = ID{0x02}
+		connected = ID{0x03}
+	)
+	db.UpdatePeerRecord(fresh, []byte{0x01}, time.Now().Add(-dbPeerExpiration+time.Hour))
+	db.UpdatePeerRecord(stale, []byte{0x02}, time.Now().Add(-dbPeerExpiration-time.Hour))
+	db.UpdatePeerRecord(connected, []byte{0x03}, time.Now().Add(-dbPeerExpiration-time.Hour))
+
+	db.ExpirePeerRecords(func(id ID) bool { return id == connected })
+
+	if blob := db.PeerRecord(fresh); !bytes.Equal(blob, []byte{0x01}) {
+		t.Errorf("fresh peer record mismatch: have %x, want 01", blob)
+	}
+	if blob := db.PeerRecord(stale); blob != nil {
+		t.Errorf("stale peer record present after expiration: %x", blob)
+	}
+	if blob := db.PeerRecord(connected); !bytes.Equal(blob, []byte{0x03}) {
+		t.Errorf("connected peer record mismatch: have %x, want 03", blob)
+	}
+}
+
 // This test checks that expiration works when discovery v5 data is present
 // in the database.
 func TestDBExpireV5(t *testing.T) {
@@ -22,6 +22,7 @@ import (
 	"io"
 	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/mclock"
@@ -118,6 +119,9 @@ type Peer struct {
 	// events receives message send / receive events if set
 	events   *event.Feed
 	testPipe *MsgPipeRW // for testing
+
+	votes    atomic.Uint64 // consensus votes received, kept in the peer history
+	lastVote atomic.Int64  // unix time of the last consensus vote received
 }
 
 // NewPeer returns a peer for testing purposes.
@@ -220,6 +224,13 @@ func (p *Peer) String() string {
 	return fmt.Sprintf("Peer %x %v", id[:8], p.RemoteAddr())
 }
 
+// CountVotes records the number of consensus votes received from the peer, to
+// be kept in the peer history of the server.
+func (p *Peer) CountVotes(n int) {
+	p.votes.Add(uint64(n))
+	p.lastVote.Store(time.Now().Unix())
+}
+
 // Inbound returns true if the peer is an inbound connection
 func (p *Peer) Inbound() bool {
 	return p.rw.is(inboundConn)
@@ -0,0 +1,296 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/mclock"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	peerSessionQueue        = 256       // Session updates buffered for the node database
+	peerHistoryCleanupCycle = time.Hour // Time period for expiring the peer history
+)
+
+// PeerRecord is the history of the sessions with a remote peer, as kept in the
+// node database. Timestamps are in unix seconds.
+type PeerRecord struct {
+	ID             string   `json:"id" rlp:"-"`     // Unique node identifier
+	Enode          string   `json:"enode"`          // Node URL of the last session
+	Name           string   `json:"name"`           // Client name of the last session
+	Caps           []string `json:"caps"`           // Protocols advertised in the last session
+	Inbound        bool     `json:"inbound"`        // Whether the last session was inbound
+	FirstSeen      uint64   `json:"firstSeen"`      // Start of the first session
+	LastSeen       uint64   `json:"lastSeen"`       // End of the last session, or now if connected
+	Sessions       uint64   `json:"sessions"`       // Number of sessions
+	Votes          uint64   `json:"votes"`          // Consensus votes received over all sessions
+	LastDisconnect string   `json:"lastDisconnect"` // Disconnect reason of the last finished session
+	Connected      bool     `json:"connected" rlp:"-"`
+}
+
+// PeerStats summarizes the peer history, giving the size of the network and the
+// churn of the peers.
+type PeerStats struct {
+	Known             int            `json:"known"`             // Peers ever connected
+	Connected         int            `json:"connected"`         // Peers currently connected
+	SeenLastHour      int            `json:"seenLastHour"`      // Peers connected within the last hour
+	SeenLastDay       int            `json:"seenLastDay"`       // Peers connected within the last day
+	NewLastDay        int            `json:"newLastDay"`        // Peers first connected within the last day
+	Sessions          uint64         `json:"sessions"`          // Sessions with all peers
+	DisconnectReasons map[string]int `json:"disconnectReasons"` // Last disconnect reason of the known peers
+}
+
+// PeerVotes is the consensus vote gossip received from a connected peer in its
+// current session. Timestamps are in unix seconds.
+type PeerVotes struct {
+	ID       string `json:"id"`       // Unique node identifier
+	Name     string `json:"name"`     // Client name
+	Remote   string `json:"remote"`   // Remote endpoint of the session
+	Inbound  bool   `json:"inbound"`  // Whether the session is inbound
+	Duration uint64 `json:"duration"` // Length of the session in seconds
+	Votes    uint64 `json:"votes"`    // Consensus votes received in the session
+	LastVote uint64 `json:"lastVote"` // Time of the last vote received, zero if none
+}
+
+// readPeerRecord retrieves the history of a peer from the node database, or
+// nil if it was never connected.
+func readPeerRecord(db *enode.DB, id enode.ID) *PeerRecord {
+	blob := db.PeerRecord(id)
+	if blob == nil {
+		return nil
+	}
+	rec := new(PeerRecord)
+	if err := rlp.DecodeBytes(blob, rec); err != nil {
+		return nil
+	}
+	rec.ID = id.String()
+	return rec
+}
+
+func writePeerRecord(db *enode.DB, id enode.ID, rec *PeerRecord) error {
+	blob, err := rlp.EncodeToBytes(rec)
+	if err != nil {
+		return err
+	}
+	return db.UpdatePeerRecord(id, blob, time.Unix(int64(rec.LastSeen), 0))
+}
+
+// recordSessionStart updates the history of a peer with a new session.
+func recordSessionStart(db *enode.DB, p *Peer, now time.Time) error {
+	rec := readPeerRecord(db, p.ID())
+	if rec == nil {
+		rec = &PeerRecord{FirstSeen: uint64(now.Unix())}
+	}
+	rec.Enode = p.Node().URLv4()
+	rec.Name = p.Fullname()
+	rec.Caps = rec.Caps[:0]
+	for _, cap := range p.Caps() {
+		rec.Caps = append(rec.Caps, cap.String())
+	}
+	rec.Inbound = p.Inbound()
+	rec.LastSeen = uint64(now.Unix())
+	rec.Sessions++
+	return writePeerRecord(db, p.ID(), rec)
+}
+
+// recordSessionEnd updates the history of a peer with the outcome of the
+// session that just ended.
+func recordSessionEnd(db *enode.DB, p *Peer, reason error, now time.Time) error {
+	rec := readPeerRecord(db, p.ID())
+	if rec == nil {
+		// The session start was not recorded, e.g. due to a write failure
+		rec = &PeerRecord{Enode: p.Node().URLv4(), Name: p.Fullname(), FirstSeen: uint64(now.Unix()), Sessions: 1}
+	}
+	rec.LastSeen = uint64(now.Unix())
+	rec.Votes += p.votes.Load()
+	rec.LastDisconnect = ""
+	if reason != nil {
+		rec.LastDisconnect = reason.Error()
+	}
+	return writePeerRecord(db, p.ID(), rec)
+}
+
+// peerSession is the start or the end of a peer session, to be recorded in the
+// peer history.
+type peerSession struct {
+	peer   *Peer
+	ended  bool
+	reason error // Disconnect reason of an ended session
+	time   time.Time
+}
+
+// queuePeerSession hands a session update over to the peer history recorder.
+// It never blocks the run loop, updates are dropped if the recorder lags.
+func (srv *Server) queuePeerSession(sessions chan<- peerSession, s peerSession) {
+	select {
+	case sessions <- s:
+	default:
+		srv.log.Warn("Dropping peer session record", "id", s.peer.ID(), "ended", s.ended)
+	}
+}
+
+// recordPeerSessions writes the session updates into the node database until
+// the channel is closed. It also expires the history of the peers that have not
+// been connected for some time, keeping the currently connected ones.
+func (srv *Server) recordPeerSessions(sessions <-chan peerSession, done chan<- struct{}) {
+	defer close(done)
+
+	var (
+		connected = make(map[enode.ID]struct{})
+		cleanup   = time.NewTicker(peerHistoryCleanupCycle)
+	)
+	defer cleanup.Stop()
+
+	for {
+		select {
+		case s, ok := <-sessions:
+			if !ok {
+				return
+			}
+			var err error
+			if s.ended {
+				delete(connected, s.peer.ID())
+				err = recordSessionEnd(srv.nodedb, s.peer, s.reason, s.time)
+			} else {
+				connected[s.peer.ID()] = struct{}{}
+				err = recordSessionStart(srv.nodedb, s.peer, s.time)
+			}
+			if err != nil {
+				srv.log.Warn("Failed to record peer session", "id", s.peer.ID(), "err", err)
+			}
+
+		case <-cleanup.C:
+			srv.nodedb.ExpirePeerRecords(func(id enode.ID) bool {
+				_, ok := connected[id]
+				return ok
+			})
+		}
+	}
+}
+
+// peerHistory returns the history of all known peers, updated with
+// the ongoing sessions of the given peers. Records are ordered by last seen
+// time, most recent first.
+func peerHistory(db *enode.DB, peers []*Peer, now time.Time) ([]*PeerRecord, error) {
+	live := make(map[enode.ID]*Peer, len(peers))
+	for _, p := range peers {
+		live[p.ID()] = p
+	}
+	var records []*PeerRecord
+	err := db.PeerRecords(func(id enode.ID, blob []byte) bool {
+		rec := new(PeerRecord)
+		if err := rlp.DecodeBytes(blob, rec); err != nil {
+			return true
+		}
+		rec.ID = id.String()
+		if p := live[id]; p != nil {
+			rec.Connected = true
+			rec.LastSeen = uint64(now.Unix())
+			rec.Votes += p.votes.Load()
+		}
+		records = append(records, rec)
+		return true
+	})
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].LastSeen > records[j].LastSeen
+	})
+	return records, err
+}
+
+// peerVotes returns the vote gossip received from the given peers, most votes
+// first.
+func peerVotes(peers []*Peer, now mclock.AbsTime) []*PeerVotes {
+	votes := make([]*PeerVotes, 0, len(peers))
+	for _, p := range peers {
+		votes = append(votes, &PeerVotes{
+			ID:       p.ID().String(),
+			Name:     p.Fullname(),
+			Remote:   p.RemoteAddr().String(),
+			Inbound:  p.Inbound(),
+			Duration: uint64(time.Duration(now-p.created) / time.Second),
+			Votes:    p.votes.Load(),
+			LastVote: uint64(p.lastVote.Load()),
+		})
+	}
+	sort.SliceStable(votes, func(i, j int) bool {
+		if votes[i].Votes != votes[j].Votes {
+			return votes[i].Votes > votes[j].Votes
+		}
+		return votes[i].ID < votes[j].ID
+	})
+	return votes
+}
+
+// summarizePeerHistory computes the statistics of a peer history.
+func summarizePeerHistory(records []*PeerRecord, now time.Time) *PeerStats {
+	var (
+		hour  = uint64(now.Add(-time.Hour).Unix())
+		day   = uint64(now.Add(-24 * time.Hour).Unix())
+		stats = &PeerStats{Known: len(records), DisconnectReasons: make(map[string]int)}
+	)
+	for _, rec := range records {
+		if rec.Connected {
+			stats.Connected++
+		}
+		if rec.LastSeen >= hour {
+			stats.SeenLastHour++
+		}
+		if rec.LastSeen >= day {
+			stats.SeenLastDay++
+		}
+		if rec.FirstSeen >= day {
+			stats.NewLastDay++
+		}
+		if rec.LastDisconnect != "" {
+			stats.DisconnectReasons[rec.LastDisconnect]++
+		}
+		stats.Sessions += rec.Sessions
+	}
+	return stats
+}
+
+// PeerHistory returns the history of all peers connected to the server in the
+// last 30 days, after which unseen peers expire, most recently seen first.
+func (srv *Server) PeerHistory() ([]*PeerRecord, error) {
+	srv.lock.Lock()
+	running := srv.running
+	srv.lock.Unlock()
+
+	if !running {
+		return nil, errServerStopped
+	}
+	return peerHistory(srv.nodedb, srv.Peers(), time.Now())
+}
+
+// PeerVotes returns the consensus vote gossip received from each connected
+// peer in its current session, most votes first.
+func (srv *Server) PeerVotes() []*PeerVotes {
+	return peerVotes(srv.Peers(), mclock.Now())
+}
+
+// PeerStats summarizes the history of the peers of the server.
+func (srv *Server) PeerStats() (*PeerStats, error) {
+	records, err := srv.PeerHistory()
+	if err != nil {
+		return nil, err
+	}
+	return summarizePeerHistory(records, time.Now()), nil
+}
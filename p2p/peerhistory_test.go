@@ -0,0 +1,176 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/mclock"
+	"github.com/ethereum/go-ethereum/internal/testlog"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+func TestPeerHistory(t *testing.T) {
+	db, err := enode.OpenDB("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var (
+		start = time.Unix(1700000000, 0)
+		caps  = []Cap{{Name: "eth", Version: 68}}
+		a     = NewPeer(enode.ID{1}, "Geth/v1.13.15", caps)
+		b     = NewPeer(enode.ID{2}, "Geth/v1.13.14", caps)
+	)
+	// Peer a connects twice, receiving votes in both sessions
+	recordSessionStart(db, a, start)
+	a.CountVotes(3)
+	recordSessionEnd(db, a, DiscTooManyPeers, start.Add(time.Minute))
+
+	a = NewPeer(enode.ID{1}, "Geth/v1.13.15", caps)
+	recordSessionStart(db, a, start.Add(2*time.Hour))
+	a.CountVotes(2)
+	recordSessionEnd(db, a, DiscQuitting, start.Add(3*time.Hour))
+
+	// Peer b is still connected
+	recordSessionStart(db, b, start.Add(4*time.Hour))
+	b.CountVotes(7)
+
+	now := start.Add(5 * time.Hour)
+	records, err := peerHistory(db, []*Peer{b}, now)
+	if err != nil {
+		t.Fatalf("failed to read history: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("record count mismatch: have %d, want 2", len(records))
+	}
+	if rec := records[0]; rec.ID != b.ID().String() || !rec.Connected || rec.Votes != 7 || rec.LastSeen != uint64(now.Unix()) {
+		t.Errorf("connected peer record mismatch: %+v", rec)
+	}
+	rec := records[1]
+	if rec.ID != a.ID().String() || rec.Connected {
+		t.Fatalf("disconnected peer record mismatch: %+v", rec)
+	}
+	if rec.Sessions != 2 || rec.Votes != 5 {
+		t.Errorf("session totals mismatch: have %d sessions %d votes, want 2 sessions 5 votes", rec.Sessions, rec.Votes)
+	}
+	if rec.FirstSeen != uint64(start.Unix()) || rec.LastSeen != uint64(start.Add(3*time.Hour).Unix()) {
+		t.Errorf("seen times mismatch: first %d, last %d", rec.FirstSeen, rec.LastSeen)
+	}
+	if rec.LastDisconnect != DiscQuitting.Error() || rec.Name != "Geth/v1.13.15" || len(rec.Caps) != 1 || rec.Caps[0] != "eth/68" {
+		t.Errorf("session details mismatch: %+v", rec)
+	}
+	stats := summarizePeerHistory(records, now)
+	if stats.Known != 2 || stats.Connected != 1 || stats.SeenLastHour != 1 || stats.SeenLastDay != 2 || stats.NewLastDay != 2 || stats.Sessions != 3 {
+		t.Errorf("stats mismatch: %+v", stats)
+	}
+	if stats.DisconnectReasons[DiscQuitting.Error()] != 1 {
+		t.Errorf("disconnect reasons mismatch: %v", stats.DisconnectReasons)
+	}
+}
+
+// Tests that unreadable peer history entries are skipped, and replaced by the
+// next session of the peer.
+func TestPeerHistoryUnreadable(t *testing.T) {
+	db, err := enode.OpenDB("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	start := time.Unix(1700000000, 0)
+	p := NewPeer(enode.ID{1}, "Geth/v1.13.15", nil)
+	db.UpdatePeerRecord(p.ID(), []byte{0xff, 0x01}, start)
+
+	records, err := peerHistory(db, nil, start)
+	if err != nil {
+		t.Fatalf("failed to read history: %v", err)
+	}
+	if len(records) != 0 {
+		t.Fatalf("unreadable record returned: %+v", records[0])
+	}
+	recordSessionStart(db, p, start.Add(time.Hour))
+	if rec := readPeerRecord(db, p.ID()); rec == nil || rec.Sessions != 1 || rec.FirstSeen != uint64(start.Add(time.Hour).Unix()) {
+		t.Errorf("replaced record mismatch: %+v", rec)
+	}
+}
+
+// Tests that the session updates queued by the run loop are written into the
+// node database before the recorder exits.
+func TestRecordPeerSessions(t *testing.T) {
+	db, err := enode.OpenDB("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var (
+		srv      = &Server{nodedb: db, log: testlog.Logger(t, log.LvlTrace)}
+		sessions = make(chan peerSession, peerSessionQueue)
+		done     = make(chan struct{})
+		start    = time.Unix(1700000000, 0)
+		p        = NewPeer(enode.ID{1}, "Geth/v1.13.15", nil)
+	)
+	go srv.recordPeerSessions(sessions, done)
+
+	srv.queuePeerSession(sessions, peerSession{peer: p, time: start})
+	p.CountVotes(4)
+	srv.queuePeerSession(sessions, peerSession{peer: p, ended: true, reason: DiscQuitting, time: start.Add(time.Minute)})
+	close(sessions)
+	<-done
+
+	rec := readPeerRecord(db, p.ID())
+	if rec == nil {
+		t.Fatal("session not recorded")
+	}
+	if rec.Sessions != 1 || rec.Votes != 4 || rec.LastSeen != uint64(start.Add(time.Minute).Unix()) || rec.LastDisconnect != DiscQuitting.Error() {
+		t.Errorf("session record mismatch: %+v", rec)
+	}
+}
+
+func TestPeerVotes(t *testing.T) {
+	var (
+		caps = []Cap{{Name: "eth", Version: 68}}
+		a    = NewPeer(enode.ID{1}, "Geth/v1.13.15", caps)
+		b    = NewPeer(enode.ID{2}, "Geth/v1.13.14", caps)
+		c    = NewPeer(enode.ID{3}, "Geth/v1.13.14", caps)
+	)
+	a.CountVotes(2)
+	c.CountVotes(5)
+
+	votes := peerVotes([]*Peer{a, b, c}, a.created+mclock.AbsTime(90*time.Second))
+	if len(votes) != 3 {
+		t.Fatalf("peer count mismatch: have %d, want 3", len(votes))
+	}
+	for i, want := range []*Peer{c, a, b} {
+		if votes[i].ID != want.ID().String() {
+			t.Errorf("peer %d mismatch: have %s, want %s", i, votes[i].ID, want.ID())
+		}
+	}
+	if votes[0].Votes != 5 || votes[0].LastVote == 0 {
+		t.Errorf("voting peer mismatch: %+v", votes[0])
+	}
+	if votes[1].Duration != 90 || votes[1].Name != "Geth/v1.13.15" {
+		t.Errorf("session details mismatch: %+v", votes[1])
+	}
+	if votes[2].Votes != 0 || votes[2].LastVote != 0 {
+		t.Errorf("silent peer mismatch: %+v", votes[2])
+	}
+}
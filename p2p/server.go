@@ -707,6 +707,15 @@ func (srv *Server) run() {
 	defer srv.discmix.Close()
 	defer srv.dialsched.stop()
 
+	// Peer sessions are written to the node database in the background, and
+	// flushed before the database is closed.
+	sessions, sessionsDone := make(chan peerSession, peerSessionQueue), make(chan struct{})
+	go srv.recordPeerSessions(sessions, sessionsDone)
+	defer func() {
+		close(sessions)
+		<-sessionsDone
+	}()
+
 	var (
 		peers        = make(map[enode.ID]*Peer)
 		inboundCount = 0
@@ -766,6 +775,7 @@ running:
 				// The handshakes are done and it passed all checks.
 				p := srv.launchPeer(c)
 				peers[c.node.ID()] = p
+				srv.queuePeerSession(sessions, peerSession{peer: p, time: time.Now()})
 				srv.log.Debug("Adding p2p peer", "peercount", len(peers), "id", p.ID(), "conn", c.flags, "addr", p.RemoteAddr(), "name", p.Name())
 				srv.dialsched.peerAdded(c)
 				if p.Inbound() {
@@ -784,6 +794,7 @@ running:
 			delete(peers, pd.ID())
 			srv.log.Debug("Removing p2p peer", "peercount", len(peers), "id", pd.ID(), "duration", d, "req", pd.requested, "err", pd.err)
 			srv.dialsched.peerRemoved(pd.rw)
+			srv.queuePeerSession(sessions, peerSession{peer: pd.Peer, ended: true, reason: pd.err, time: time.Now()})
 			if pd.Inbound() {
 				inboundCount--
 			}
@@ -810,6 +821,7 @@ running:
 	for len(peers) > 0 {
 		p := <-srv.delpeer
 		p.log.Trace("<-delpeer (spindown)")
+		sessions <- peerSession{peer: p.Peer, ended: true, reason: p.err, time: time.Now()}
 		delete(peers, p.ID())
 	}
 }
@@ -36,6 +36,14 @@ var HoleskyBootnodes = []string{
 	"enode://a3435a0155a3e837c02f5e7f5662a2f1fbc25b48e4dc232016e1c51b544cb5b4510ef633ea3278c0e970fa8ad8141e2d4d0f9f95456c537ff05fdf9b31c15072@178.128.136.233:30303",
 }
 
+// ZkscamBootnodes are the enode URLs of the P2P bootstrap nodes running on the
+// ZKScam network. No signed DNS node list of the network is published, so they
+// are the only built-in way to find ZKScam peers and KnownDNSNetwork has no
+// entry for it. There is no ZKScam test network to ship bootnodes for either.
+var ZkscamBootnodes = []string{
+	"enode://8d8fcc2f81bb0f6a653b3e71f8ce31c1227ab39fb8a1a3fe6008521767273e29054019bcf933e3a4954131c56790aaef0aff8251fe4c389dae3380483e2576df@103.97.58.18:30303",
+}
+
 // SepoliaBootnodes are the enode URLs of the P2P bootstrap nodes running on the
 // Sepolia test network.
 var SepoliaBootnodes = []string{
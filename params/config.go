@@ -30,8 +30,13 @@ var (
 	HoleskyGenesisHash = common.HexToHash("0xb5f7f912443c940f21fd611f12828d75b534364ed9e95ca4e307729a4661bde4")
 	SepoliaGenesisHash = common.HexToHash("0x25a5cc106eea7138acab33231d7160d69cb777ee0c2c553fcddf5138993e6dd9")
 	GoerliGenesisHash  = common.HexToHash("0xbf7e331f7f7c1dd2e05159666b3bf8bc7a8a3a9eb1d518969eab529dd9b88c1a")
+	ZkscamGenesisHash  = common.HexToHash("0x58a9c2fe56a86036579d3b5ab4bfca6f0771cf74ecd70236b7e3cb609781a16a")
 )
 
+// ZkscamNetworkID is the network identifier of the ZKScam network. Note it
+// differs from the chain ID of the network.
+const ZkscamNetworkID = 63658
+
 func newUint64(val uint64) *uint64 { return &val }
 
 var (
@@ -86,6 +91,23 @@ var (
 		CancunTime:                    newUint64(1707305664),
 		Ethash:                        new(EthashConfig),
 	}
+	// ZkscamChainConfig contains the chain parameters to run a node on the ZKScam
+	// network.
+	ZkscamChainConfig = &ChainConfig{
+		ChainID:             big.NewInt(63858),
+		HomesteadBlock:      big.NewInt(0),
+		EIP150Block:         big.NewInt(0),
+		EIP155Block:         big.NewInt(0),
+		EIP158Block:         big.NewInt(0),
+		ByzantiumBlock:      big.NewInt(0),
+		ConstantinopleBlock: big.NewInt(0),
+		PetersburgBlock:     big.NewInt(0),
+		IstanbulBlock:       big.NewInt(0),
+		Clique: &CliqueConfig{
+			Period: 30,
+			Epoch:  30000,
+		},
+	}
 	// SepoliaChainConfig contains the chain parameters to run a node on the Sepolia test network.
 	SepoliaChainConfig = &ChainConfig{
 		ChainID:                       big.NewInt(11155111),
@@ -207,6 +229,10 @@ var (
 		ArrowGlacierBlock:             nil,
 		GrayGlacierBlock:              nil,
 		MergeNetsplitBlock:            nil,
+		ZkscamBLSBlock:                big.NewInt(0),
+		ZkscamVoteDataBlock:           big.NewInt(0),
+		ZkscamVotePayloadBlock:        big.NewInt(0),
+		ZkscamVoteDigestBlock:         big.NewInt(0),
 		ShanghaiTime:                  nil,
 		CancunTime:                    nil,
 		PragueTime:                    nil,
@@ -315,6 +341,8 @@ var NetworkNames = map[string]string{
 	GoerliChainConfig.ChainID.String():  "goerli",
 	SepoliaChainConfig.ChainID.String(): "sepolia",
 	HoleskyChainConfig.ChainID.String(): "holesky",
+
+	ZkscamChainConfig.ChainID.String(): "zkscam",
 }
 
 // ChainConfig is the core config which determines the blockchain settings.
@@ -346,6 +374,14 @@ type ChainConfig struct {
 	GrayGlacierBlock    *big.Int `json:"grayGlacierBlock,omitempty"`    // Eip-5133 (bomb delay) switch block (nil = no fork, 0 = already activated)
 	MergeNetsplitBlock  *big.Int `json:"mergeNetsplitBlock,omitempty"`  // Virtual fork after The Merge to use as a network splitter
 
+	// Forks of the vote-based clique are scheduled by block and independently of
+	// the Ethereum ones, as such a chain never goes through The Merge
+
+	ZkscamBLSBlock         *big.Int `json:"zkscamBLSBlock,omitempty"`         // BLS vote verification precompile switch block (nil = no fork, 0 = already activated)
+	ZkscamVoteDataBlock    *big.Int `json:"zkscamVoteDataBlock,omitempty"`    // Vote data precompile switch block (nil = no fork, 0 = already activated)
+	ZkscamVotePayloadBlock *big.Int `json:"zkscamVotePayloadBlock,omitempty"` // Strict vote payload validation switch block (nil = no fork, 0 = already activated)
+	ZkscamVoteDigestBlock  *big.Int `json:"zkscamVoteDigestBlock,omitempty"`  // Domain separated vote digest switch block (nil = no fork, 0 = already activated)
+
 	// Fork scheduling was switched from blocks to timestamps here
 
 	ShanghaiTime *uint64 `json:"shanghaiTime,omitempty"` // Shanghai switch time (nil = no fork, 0 = already on shanghai)
@@ -475,6 +511,22 @@ func (c *ChainConfig) Description() string {
 	if c.VerkleTime != nil {
 		banner += fmt.Sprintf(" - Verkle:                      @%-10v\n", *c.VerkleTime)
 	}
+	if c.ZkscamBLSBlock != nil || c.ZkscamVoteDataBlock != nil || c.ZkscamVotePayloadBlock != nil || c.ZkscamVoteDigestBlock != nil {
+		banner += "\n"
+		banner += "Vote-based clique forks (block based):\n"
+		if c.ZkscamBLSBlock != nil {
+			banner += fmt.Sprintf(" - BLS vote verification:       #%-8v\n", c.ZkscamBLSBlock)
+		}
+		if c.ZkscamVoteDataBlock != nil {
+			banner += fmt.Sprintf(" - Vote data:                   #%-8v\n", c.ZkscamVoteDataBlock)
+		}
+		if c.ZkscamVotePayloadBlock != nil {
+			banner += fmt.Sprintf(" - Strict vote payload:         #%-8v\n", c.ZkscamVotePayloadBlock)
+		}
+		if c.ZkscamVoteDigestBlock != nil {
+			banner += fmt.Sprintf(" - Vote digest:                 #%-8v\n", c.ZkscamVoteDigestBlock)
+		}
+	}
 	return banner
 }
 
@@ -550,6 +602,30 @@ func (c *ChainConfig) IsGrayGlacier(num *big.Int) bool {
 	return isBlockForked(c.GrayGlacierBlock, num)
 }
 
+// IsZkscamBLS returns whether num is either equal to the BLS vote verification
+// precompile fork block or greater.
+func (c *ChainConfig) IsZkscamBLS(num *big.Int) bool {
+	return isBlockForked(c.ZkscamBLSBlock, num)
+}
+
+// IsZkscamVoteData returns whether num is either equal to the vote data
+// precompile fork block or greater.
+func (c *ChainConfig) IsZkscamVoteData(num *big.Int) bool {
+	return isBlockForked(c.ZkscamVoteDataBlock, num)
+}
+
+// IsZkscamVotePayload returns whether num is either equal to the strict vote
+// payload fork block or greater.
+func (c *ChainConfig) IsZkscamVotePayload(num *big.Int) bool {
+	return isBlockForked(c.ZkscamVotePayloadBlock, num)
+}
+
+// IsZkscamVoteDigest returns whether num is either equal to the domain separated
+// vote digest fork block or greater.
+func (c *ChainConfig) IsZkscamVoteDigest(num *big.Int) bool {
+	return isBlockForked(c.ZkscamVoteDigestBlock, num)
+}
+
 // IsTerminalPoWBlock returns whether the given block is the last block of PoW stage.
 func (c *ChainConfig) IsTerminalPoWBlock(parentTotalDiff *big.Int, totalDiff *big.Int) bool {
 	if c.TerminalTotalDifficulty == nil {
@@ -727,6 +803,18 @@ func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, headNumber *big.Int,
 	if isForkBlockIncompatible(c.MergeNetsplitBlock, newcfg.MergeNetsplitBlock, headNumber) {
 		return newBlockCompatError("Merge netsplit fork block", c.MergeNetsplitBlock, newcfg.MergeNetsplitBlock)
 	}
+	if isForkBlockIncompatible(c.ZkscamBLSBlock, newcfg.ZkscamBLSBlock, headNumber) {
+		return newBlockCompatError("BLS vote verification fork block", c.ZkscamBLSBlock, newcfg.ZkscamBLSBlock)
+	}
+	if isForkBlockIncompatible(c.ZkscamVoteDataBlock, newcfg.ZkscamVoteDataBlock, headNumber) {
+		return newBlockCompatError("Vote data fork block", c.ZkscamVoteDataBlock, newcfg.ZkscamVoteDataBlock)
+	}
+	if isForkBlockIncompatible(c.ZkscamVotePayloadBlock, newcfg.ZkscamVotePayloadBlock, headNumber) {
+		return newBlockCompatError("Strict vote payload fork block", c.ZkscamVotePayloadBlock, newcfg.ZkscamVotePayloadBlock)
+	}
+	if isForkBlockIncompatible(c.ZkscamVoteDigestBlock, newcfg.ZkscamVoteDigestBlock, headNumber) {
+		return newBlockCompatError("Vote digest fork block", c.ZkscamVoteDigestBlock, newcfg.ZkscamVoteDigestBlock)
+	}
 	if isForkTimestampIncompatible(c.ShanghaiTime, newcfg.ShanghaiTime, headTimestamp) {
 		return newTimestampCompatError("Shanghai fork timestamp", c.ShanghaiTime, newcfg.ShanghaiTime)
 	}
@@ -902,6 +990,7 @@ type Rules struct {
 	IsBerlin, IsLondon                                      bool
 	IsMerge, IsShanghai, IsCancun, IsPrague                 bool
 	IsVerkle                                                bool
+	IsZkscamBLS, IsZkscamVoteData                           bool
 }
 
 // Rules ensures c's ChainID is not nil.
@@ -912,6 +1001,10 @@ func (c *ChainConfig) Rules(num *big.Int, isMerge bool, timestamp uint64) Rules
 	}
 	// disallow setting Merge out of order
 	isMerge = isMerge && c.IsLondon(num)
+
+	// Clique chains never scheduled to merge activate the execution-layer changes
+	// of Shanghai and Cancun at their timestamps, the other chains after merging
+	isPostMerge := isMerge || (c.Clique != nil && c.TerminalTotalDifficulty == nil)
 	return Rules{
 		ChainID:          new(big.Int).Set(chainID),
 		IsHomestead:      c.IsHomestead(num),
@@ -925,9 +1018,11 @@ func (c *ChainConfig) Rules(num *big.Int, isMerge bool, timestamp uint64) Rules
 		IsBerlin:         c.IsBerlin(num),
 		IsLondon:         c.IsLondon(num),
 		IsMerge:          isMerge,
-		IsShanghai:       isMerge && c.IsShanghai(num, timestamp),
-		IsCancun:         isMerge && c.IsCancun(num, timestamp),
+		IsShanghai:       isPostMerge && c.IsShanghai(num, timestamp),
+		IsCancun:         isPostMerge && c.IsCancun(num, timestamp),
 		IsPrague:         isMerge && c.IsPrague(num, timestamp),
 		IsVerkle:         isMerge && c.IsVerkle(num, timestamp),
+		IsZkscamBLS:      c.IsZkscamBLS(num),
+		IsZkscamVoteData: c.IsZkscamVoteData(num),
 	}
 }
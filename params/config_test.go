@@ -137,3 +137,30 @@ func TestConfigRules(t *testing.T) {
 		t.Errorf("expected %v to be shanghai", stamp)
 	}
 }
+
+func TestCliqueConfigRules(t *testing.T) {
+	// Clique chains without Shanghai and Cancun timestamps never activate them
+	c := &ChainConfig{
+		LondonBlock: new(big.Int),
+		Clique:      &CliqueConfig{Period: 1, Epoch: 30000},
+	}
+	if r := c.Rules(big.NewInt(0), false, math.MaxInt64); r.IsShanghai || r.IsCancun {
+		t.Errorf("unscheduled forks active: shanghai %v, cancun %v", r.IsShanghai, r.IsCancun)
+	}
+	// Scheduled ones activate at their timestamps without merging
+	c.ShanghaiTime, c.CancunTime = newUint64(500), newUint64(1000)
+	if r := c.Rules(big.NewInt(0), false, 999); !r.IsShanghai || r.IsCancun {
+		t.Errorf("fork mismatch at 999: shanghai %v, cancun %v", r.IsShanghai, r.IsCancun)
+	}
+	if r := c.Rules(big.NewInt(0), false, 1000); !r.IsShanghai || !r.IsCancun {
+		t.Errorf("fork mismatch at 1000: shanghai %v, cancun %v", r.IsShanghai, r.IsCancun)
+	}
+	// Unless the chain is scheduled to merge, and only activates them afterwards
+	c.TerminalTotalDifficulty = big.NewInt(100)
+	if r := c.Rules(big.NewInt(0), false, 1000); r.IsShanghai || r.IsCancun {
+		t.Errorf("forks active before merging: shanghai %v, cancun %v", r.IsShanghai, r.IsCancun)
+	}
+	if r := c.Rules(big.NewInt(0), true, 1000); !r.IsShanghai || !r.IsCancun {
+		t.Errorf("forks inactive after merging: shanghai %v, cancun %v", r.IsShanghai, r.IsCancun)
+	}
+}
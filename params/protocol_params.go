@@ -159,6 +159,11 @@ const (
 	Bls12381MapG1Gas          uint64 = 5500   // Gas price for BLS12-381 mapping field element to G1 operation
 	Bls12381MapG2Gas          uint64 = 110000 // Gas price for BLS12-381 mapping field element to G2 operation
 
+	ZkscamBLSVerifyBaseGas   uint64 = 113000 // Base price for the verification of an aggregated consensus BLS signature
+	ZkscamBLSVerifyPerKeyGas uint64 = 69500  // Per public key price for the verification of an aggregated consensus BLS signature, with the key's proof of possession
+	ZkscamVoteDataBaseGas    uint64 = 2500   // Base price for reading the vote data of a recent block
+	ZkscamVoteDataPerVoteGas uint64 = 100    // Per voter price for reading the vote data of a recent block
+
 	// The Refund Quotient is the cap on how much of the used gas can be refunded. Before EIP-3529,
 	// up to half the consumed gas could be refunded. Redefined as 1/5th in EIP-3529
 	RefundQuotient        uint64 = 2
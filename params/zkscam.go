@@ -0,0 +1,40 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package params
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/crypto/sha3"
+)
+
+// ZkscamTokenAddress is the ERC20 contract holding the stakes of the miners.
+var ZkscamTokenAddress = common.HexToAddress("0x4b75210419009994c7f856f0b5c5b79750dbed22")
+
+// zkscamBalancesSlot is the storage slot of the balances mapping of the token.
+const zkscamBalancesSlot = 4
+
+// ZkscamBalanceSlot returns the storage slot of the stake token holding the
+// balance of the given account, allowing genesis specifications to pre-fund
+// stakes.
+func ZkscamBalanceSlot(account common.Address) common.Hash {
+	var slot common.Hash
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(common.LeftPadBytes(account.Bytes(), 32))
+	hasher.Write(common.LeftPadBytes([]byte{zkscamBalancesSlot}, 32))
+	hasher.Sum(slot[:0])
+	return slot
+}
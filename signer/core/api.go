@@ -25,6 +25,7 @@ import (
 	"math/big"
 	"os"
 	"reflect"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
@@ -44,7 +45,7 @@ const (
 	// numberOfAccountsToDerive For hardware wallets, the number of accounts to derive
 	numberOfAccountsToDerive = 10
 	// ExternalAPIVersion -- see extapi_changelog.md
-	ExternalAPIVersion = "6.1.0"
+	ExternalAPIVersion = "6.2.0"
 	// InternalAPIVersion -- see intapi_changelog.md
 	InternalAPIVersion = "7.0.1"
 )
@@ -67,6 +68,10 @@ type ExternalAPI interface {
 	Version(ctx context.Context) (string, error)
 	// SignGnosisSafeTx signs/confirms a gnosis-safe multisig transaction
 	SignGnosisSafeTx(ctx context.Context, signerAddress common.MixedcaseAddress, gnosisTx GnosisSafeTx, methodSelector *string) (*GnosisSafeTx, error)
+	// SignVote signs a consensus vote, refusing conflicting votes at the same height
+	SignVote(ctx context.Context, addr common.MixedcaseAddress, vote apitypes.Vote) (hexutil.Bytes, error)
+	// SignBLSAuthorization signs the authorization of a BLS voting key
+	SignBLSAuthorization(ctx context.Context, addr common.MixedcaseAddress, pubkey hexutil.Bytes) (hexutil.Bytes, error)
 }
 
 // UIClientAPI specifies what method a UI needs to implement to be able to be used as a
@@ -119,6 +124,8 @@ type SignerAPI struct {
 	validator   Validator
 	rejectMode  bool
 	credentials storage.Storage
+	votes       storage.Storage // Signed consensus votes, nil if votes can't be remembered
+	voteLock    sync.Mutex      // Serializes vote requests, see SignVote
 }
 
 // Metadata about a request
@@ -242,6 +249,7 @@ type (
 		Messages    []*apitypes.NameValueType `json:"messages"`
 		Callinfo    []apitypes.ValidationInfo `json:"call_info"`
 		Hash        hexutil.Bytes             `json:"hash"`
+		Vote        *apitypes.Vote            `json:"vote,omitempty"`
 		Meta        Metadata                  `json:"meta"`
 	}
 	SignDataResponse struct {
@@ -283,11 +291,21 @@ var ErrRequestDenied = errors.New("request denied")
 // key that is generated when a new Account is created.
 // noUSB disables USB support that is required to support hardware devices such as
 // ledger and trezor.
-func NewSignerAPI(am *accounts.Manager, chainID int64, noUSB bool, ui UIClientAPI, validator Validator, advancedMode bool, credentials storage.Storage) *SignerAPI {
+// votes specifies the storage remembering signed consensus votes, vote signing
+// is disabled if it is nil.
+func NewSignerAPI(am *accounts.Manager, chainID int64, noUSB bool, ui UIClientAPI, validator Validator, advancedMode bool, credentials storage.Storage, votes storage.Storage) *SignerAPI {
 	if advancedMode {
 		log.Info("Clef is in advanced mode: will warn instead of reject")
 	}
-	signer := &SignerAPI{big.NewInt(chainID), am, ui, validator, !advancedMode, credentials}
+	signer := &SignerAPI{
+		chainID:     big.NewInt(chainID),
+		am:          am,
+		UI:          ui,
+		validator:   validator,
+		rejectMode:  !advancedMode,
+		credentials: credentials,
+		votes:       votes,
+	}
 	if !noUSB {
 		signer.startUSBListener()
 	}
@@ -190,6 +190,15 @@ var (
 	}
 )
 
+// Vote is a consensus vote of a miner for the block hash at the given height.
+// Legacy votes, cast before the vote digest fork, are signed over the raw hash
+// instead of the domain separated accounts.ZkscamVoteHash.
+type Vote struct {
+	Number hexutil.Uint64 `json:"number"`
+	Hash   common.Hash    `json:"hash"`
+	Legacy bool           `json:"legacy,omitempty"`
+}
+
 type ValidatorData struct {
 	Address common.Address
 	Message hexutil.Bytes
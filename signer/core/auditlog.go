@@ -91,6 +91,22 @@ func (l *AuditLogger) SignGnosisSafeTx(ctx context.Context, addr common.Mixedcas
 	return res, e
 }
 
+func (l *AuditLogger) SignVote(ctx context.Context, addr common.MixedcaseAddress, vote apitypes.Vote) (hexutil.Bytes, error) {
+	l.log.Info("SignVote", "type", "request", "metadata", MetadataFromContext(ctx).String(),
+		"addr", addr.String(), "number", uint64(vote.Number), "hash", vote.Hash)
+	b, e := l.api.SignVote(ctx, addr, vote)
+	l.log.Info("SignVote", "type", "response", "data", common.Bytes2Hex(b), "error", e)
+	return b, e
+}
+
+func (l *AuditLogger) SignBLSAuthorization(ctx context.Context, addr common.MixedcaseAddress, pubkey hexutil.Bytes) (hexutil.Bytes, error) {
+	l.log.Info("SignBLSAuthorization", "type", "request", "metadata", MetadataFromContext(ctx).String(),
+		"addr", addr.String(), "pubkey", pubkey.String())
+	b, e := l.api.SignBLSAuthorization(ctx, addr, pubkey)
+	l.log.Info("SignBLSAuthorization", "type", "response", "data", common.Bytes2Hex(b), "error", e)
+	return b, e
+}
+
 func (l *AuditLogger) SignTypedData(ctx context.Context, addr common.MixedcaseAddress, data apitypes.TypedData) (hexutil.Bytes, error) {
 	l.log.Info("SignTypedData", "type", "request", "metadata", MetadataFromContext(ctx).String(),
 		"addr", addr.String(), "data", data)
@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"os"
@@ -27,6 +28,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -34,6 +36,9 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/signer/core"
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/ethereum/go-ethereum/signer/fourbyte"
+	"github.com/ethereum/go-ethereum/signer/storage"
+	single "github.com/ethereum/go-ethereum/singleton"
 )
 
 var typesStandard = apitypes.Types{
@@ -248,6 +253,149 @@ func TestSignData(t *testing.T) {
 	}
 }
 
+func TestSignVote(t *testing.T) {
+	t.Parallel()
+	api, control := setup(t)
+	createAccount(control, api, t)
+	control.approveCh <- "A"
+	list, err := api.List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := common.NewMixedcaseAddress(list[0])
+	vote := apitypes.Vote{Number: 10, Hash: common.HexToHash("0x01")}
+
+	control.approveCh <- "Y"
+	control.inputCh <- "a_long_password"
+	signature, err := api.SignVote(context.Background(), a, vote)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The vote signs the domain separated vote hash
+	pub, err := crypto.SigToPub(accounts.ZkscamVoteHash(10, vote.Hash), signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have := crypto.PubkeyToAddress(*pub); have != list[0] {
+		t.Fatalf("wrong signer: have %x, want %x", have, list[0])
+	}
+	// Signing the same vote again is fine
+	control.approveCh <- "Y"
+	control.inputCh <- "a_long_password"
+	if _, err := api.SignVote(context.Background(), a, vote); err != nil {
+		t.Fatalf("failed to re-sign vote: %v", err)
+	}
+	// A different hash at the same height is refused without asking the user
+	conflict := apitypes.Vote{Number: 10, Hash: common.HexToHash("0x02")}
+	if _, err := api.SignVote(context.Background(), a, conflict); !errors.Is(err, core.ErrConflictingVote) {
+		t.Fatalf("expected ErrConflictingVote, got %v", err)
+	}
+	// Votes at other heights are unaffected
+	control.approveCh <- "Y"
+	control.inputCh <- "a_long_password"
+	if _, err := api.SignVote(context.Background(), a, apitypes.Vote{Number: 11, Hash: conflict.Hash}); err != nil {
+		t.Fatalf("failed to sign vote at next height: %v", err)
+	}
+	// Legacy votes sign the raw hash
+	control.approveCh <- "Y"
+	control.inputCh <- "a_long_password"
+	legacy := apitypes.Vote{Number: 12, Hash: common.HexToHash("0x03"), Legacy: true}
+	if signature, err = api.SignVote(context.Background(), a, legacy); err != nil {
+		t.Fatalf("failed to sign legacy vote: %v", err)
+	}
+	if pub, err = crypto.SigToPub(legacy.Hash.Bytes(), signature); err != nil {
+		t.Fatal(err)
+	}
+	if have := crypto.PubkeyToAddress(*pub); have != list[0] {
+		t.Fatalf("wrong legacy signer: have %x, want %x", have, list[0])
+	}
+}
+
+func TestSignVotePruning(t *testing.T) {
+	t.Parallel()
+	api, control := setup(t)
+	createAccount(control, api, t)
+	control.approveCh <- "A"
+	list, err := api.List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := common.NewMixedcaseAddress(list[0])
+
+	for _, number := range []hexutil.Uint64{10, 5000} {
+		control.approveCh <- "Y"
+		control.inputCh <- "a_long_password"
+		if _, err := api.SignVote(context.Background(), a, apitypes.Vote{Number: number, Hash: common.HexToHash("0x01")}); err != nil {
+			t.Fatalf("failed to sign vote at height %d: %v", number, err)
+		}
+	}
+	// The vote at height 10 is pruned, so no vote is signed that deep anymore
+	old := apitypes.Vote{Number: 10, Hash: common.HexToHash("0x02")}
+	if _, err := api.SignVote(context.Background(), a, old); !errors.Is(err, core.ErrPrunedVote) {
+		t.Fatalf("expected ErrPrunedVote, got %v", err)
+	}
+}
+
+func TestSignBLSAuthorization(t *testing.T) {
+	t.Parallel()
+	api, control := setup(t)
+	createAccount(control, api, t)
+	control.approveCh <- "A"
+	list, err := api.List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := common.NewMixedcaseAddress(list[0])
+
+	// Arbitrary digests are not BLS keys, and are refused without asking the user
+	if _, err := api.SignBLSAuthorization(context.Background(), a, common.HexToHash("0x01").Bytes()); err == nil {
+		t.Fatal("signed authorization of an invalid BLS key")
+	}
+	pub := single.GenerateBLSKey(list[0]).PublicKey()
+	control.approveCh <- "Y"
+	control.inputCh <- "a_long_password"
+	signature, err := api.SignBLSAuthorization(context.Background(), a, pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := single.VerifyAnyLengthMessageSignatureWithAddress(pub, signature, list[0]); !ok {
+		t.Fatalf("invalid authorization: %v", err)
+	}
+}
+
+// droppingStorage is a vote storage that silently fails to store anything.
+type droppingStorage struct{}
+
+func (s *droppingStorage) Put(key, value string) {}
+func (s *droppingStorage) Del(key string)        {}
+func (s *droppingStorage) Get(key string) (string, error) {
+	return "", storage.ErrNotFound
+}
+
+func TestSignVoteUnrecorded(t *testing.T) {
+	t.Parallel()
+	db, err := fourbyte.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	control := &headlessUi{make(chan string, 20), make(chan string, 20)}
+	am := core.StartClefAccountManager(t.TempDir(), true, true, "")
+	api := core.NewSignerAPI(am, 1337, true, control, db, true, &storage.NoStorage{}, &droppingStorage{})
+	createAccount(control, api, t)
+	control.approveCh <- "A"
+	list, err := api.List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A vote that can't be recorded is refused, as it couldn't be checked later
+	control.approveCh <- "Y"
+	control.inputCh <- "a_long_password"
+	vote := apitypes.Vote{Number: 10, Hash: common.HexToHash("0x01")}
+	if _, err := api.SignVote(context.Background(), common.NewMixedcaseAddress(list[0]), vote); !errors.Is(err, core.ErrVoteNotRecorded) {
+		t.Fatalf("expected ErrVoteNotRecorded, got %v", err)
+	}
+}
+
 func TestDomainChainId(t *testing.T) {
 	t.Parallel()
 	withoutChainID := apitypes.TypedData{
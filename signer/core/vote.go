@@ -0,0 +1,249 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/ethereum/go-ethereum/signer/storage"
+	single "github.com/ethereum/go-ethereum/singleton"
+)
+
+// voteRetention is the number of heights below the latest vote of an account
+// for which its votes are remembered. Blocks that deep are final in practice:
+// older records are pruned, and votes below the retained heights are refused
+// as they can't be checked for conflicts anymore.
+const voteRetention = 1024
+
+var (
+	// ErrConflictingVote is returned if a vote is requested for a height at which
+	// the account already signed a different hash.
+	ErrConflictingVote = errors.New("conflicting vote")
+
+	// ErrPrunedVote is returned if a vote is requested for a height whose record
+	// was already pruned.
+	ErrPrunedVote = errors.New("vote below retained heights")
+
+	// ErrVoteStorageUnavailable is returned if votes are requested while clef
+	// has no encrypted storage to remember them in, i.e. no master seed.
+	ErrVoteStorageUnavailable = errors.New("vote signing requires the encrypted storage of the master seed")
+
+	// ErrVoteNotRecorded is returned if a vote can't be stored before signing,
+	// as it couldn't be checked against conflicting votes later.
+	ErrVoteNotRecorded = errors.New("failed to record vote")
+)
+
+// voteRecord is the vote history of an account, the hashes it signed at the
+// heights from Floor on.
+type voteRecord struct {
+	Floor uint64                 `json:"floor"`
+	Votes map[uint64]common.Hash `json:"votes"`
+}
+
+// voteKey is the storage key of the vote history of an account.
+func voteKey(addr common.Address) string {
+	return fmt.Sprintf("votes-%s", addr.Hex())
+}
+
+// loadVotes retrieves the vote history of an account.
+func (api *SignerAPI) loadVotes(addr common.Address) (*voteRecord, error) {
+	record := &voteRecord{Votes: make(map[uint64]common.Hash)}
+	blob, err := api.votes.Get(voteKey(addr))
+	if errors.Is(err, storage.ErrNotFound) {
+		return record, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(blob), record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// add records a vote, pruning the ones below the retained heights.
+func (r *voteRecord) add(number uint64, hash common.Hash) {
+	r.Votes[number] = hash
+	if number < voteRetention || number-voteRetention <= r.Floor {
+		return
+	}
+	r.Floor = number - voteRetention
+	for n := range r.Votes {
+		if n < r.Floor {
+			delete(r.Votes, n)
+		}
+	}
+}
+
+// SignVote signs a consensus vote for the block hash at the given height. The
+// signature covers the domain separated accounts.ZkscamVoteHash of the vote,
+// so it can't be passed off as any other signature. Legacy votes, cast before
+// the vote digest fork, sign the raw hash instead: they are requested with the
+// MimetypeZkscamLegacyVote content type and a warning, so that rules can tell
+// them apart and leave them to the user. Every signed vote is recorded in the
+// encrypted storage before signing, and a vote for a different hash at an
+// already voted height is refused.
+func (api *SignerAPI) SignVote(ctx context.Context, addr common.MixedcaseAddress, vote apitypes.Vote) (hexutil.Bytes, error) {
+	if api.votes == nil {
+		return nil, ErrVoteStorageUnavailable
+	}
+	// Votes are serialized so two requests at the same height can't both pass
+	// the conflict check while waiting for approval
+	api.voteLock.Lock()
+	defer api.voteLock.Unlock()
+
+	record, err := api.loadVotes(addr.Address())
+	if err != nil {
+		return nil, fmt.Errorf("failed to check previous votes: %v", err)
+	}
+	number := uint64(vote.Number)
+	if number < record.Floor {
+		err = fmt.Errorf("%w: height %d, retained from %d", ErrPrunedVote, number, record.Floor)
+		api.UI.ShowError(err.Error())
+		return nil, err
+	}
+	if signed, ok := record.Votes[number]; ok && signed != vote.Hash {
+		err = fmt.Errorf("%w: already signed %s at height %d", ErrConflictingVote, signed.Hex(), number)
+		api.UI.ShowError(err.Error())
+		return nil, err
+	}
+	req := &SignDataRequest{
+		ContentType: accounts.MimetypeZkscamVote,
+		Address:     addr,
+		Rawdata:     vote.Hash.Bytes(),
+		Messages:    voteMessages(vote),
+		Hash:        accounts.ZkscamVoteHash(number, vote.Hash),
+		Vote:        &vote,
+		Meta:        MetadataFromContext(ctx),
+	}
+	if vote.Legacy {
+		req.ContentType, req.Hash = accounts.MimetypeZkscamLegacyVote, vote.Hash.Bytes()
+		req.Callinfo = []apitypes.ValidationInfo{{
+			Typ:     apitypes.WARN,
+			Message: "Legacy vote: the raw hash is signed as is. If it isn't a block hash voted on, e.g. a transaction signing hash, the signature authorizes that instead.",
+		}}
+	}
+	// Record the vote before signing: if anything fails afterwards, the hash
+	// may already have been signed. The storage doesn't report write failures,
+	// so the record is read back, and the vote refused if it didn't persist.
+	return api.signConsensusHash(req, "a vote", func() error {
+		record.add(number, vote.Hash)
+		blob, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		api.votes.Put(voteKey(addr.Address()), string(blob))
+		if stored, err := api.votes.Get(voteKey(addr.Address())); err != nil || stored != string(blob) {
+			return fmt.Errorf("%w at height %d", ErrVoteNotRecorded, number)
+		}
+		return nil
+	})
+}
+
+// SignBLSAuthorization signs the authorization of a BLS voting key by the miner
+// account: a signature over the sha256 hash of the serialized public key, which
+// clef computes itself so that no caller chosen digest is ever signed.
+func (api *SignerAPI) SignBLSAuthorization(ctx context.Context, addr common.MixedcaseAddress, pubkey hexutil.Bytes) (hexutil.Bytes, error) {
+	if _, err := single.UnmarshalBLSKeyBytes(pubkey); err != nil {
+		return nil, fmt.Errorf("invalid BLS public key: %v", err)
+	}
+	req := &SignDataRequest{
+		ContentType: accounts.MimetypeZkscamBLSAuth,
+		Address:     addr,
+		Rawdata:     pubkey,
+		Messages: []*apitypes.NameValueType{
+			{
+				Name:  "This is a request to authorize a BLS voting key",
+				Typ:   "description",
+				Value: "",
+			},
+			{
+				Name:  "BLS public key",
+				Typ:   "bytes",
+				Value: pubkey.String(),
+			},
+		},
+		Hash: accounts.ZkscamBLSAuthHash(pubkey),
+		Meta: MetadataFromContext(ctx),
+	}
+	return api.signConsensusHash(req, "a BLS key authorization", nil)
+}
+
+// signConsensusHash asks the user to approve the request, then signs its hash
+// with the keystore account. The record callback, if any, is invoked after
+// approval and before signing, and aborts the request if it fails.
+func (api *SignerAPI) signConsensusHash(req *SignDataRequest, what string, record func() error) (hexutil.Bytes, error) {
+	res, err := api.UI.ApproveSignData(req)
+	if err != nil {
+		return nil, err
+	}
+	if !res.Approved {
+		return nil, ErrRequestDenied
+	}
+	// Consensus signatures cover a raw digest, which only keystore accounts support
+	account := accounts.Account{Address: req.Address.Address()}
+	ks := fetchKeystore(api.am)
+	if ks == nil || !ks.HasAddress(account.Address) {
+		return nil, errors.New("consensus signing requires a keystore account")
+	}
+	pw, err := api.lookupOrQueryPassword(account.Address,
+		"Password for signing",
+		fmt.Sprintf("Please enter password for signing %s with account %s", what, account.Address.Hex()))
+	if err != nil {
+		return nil, err
+	}
+	if record != nil {
+		if err := record(); err != nil {
+			api.UI.ShowError(err.Error())
+			return nil, err
+		}
+	}
+	signature, err := ks.SignHashWithPassphrase(account, pw, req.Hash)
+	if err != nil {
+		api.UI.ShowError(err.Error())
+		return nil, err
+	}
+	return signature, nil
+}
+
+// voteMessages returns the description of a vote request shown to the user for
+// approval.
+func voteMessages(vote apitypes.Vote) []*apitypes.NameValueType {
+	return []*apitypes.NameValueType{
+		{
+			Name:  "This is a request to sign a consensus vote",
+			Typ:   "description",
+			Value: "",
+		},
+		{
+			Name:  "Height",
+			Typ:   "uint64",
+			Value: fmt.Sprintf("%d", vote.Number),
+		},
+		{
+			Name:  "Hash",
+			Typ:   "hash",
+			Value: vote.Hash.Hex(),
+		},
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"strings"
 
 	"github.com/dop251/goja"
+	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/internal/jsre/deps"
 	"github.com/ethereum/go-ethereum/log"
@@ -168,6 +169,10 @@ func (r *rulesetUI) ApproveTx(request *core.SignTxRequest) (core.SignTxResponse,
 }
 
 func (r *rulesetUI) ApproveSignData(request *core.SignDataRequest) (core.SignDataResponse, error) {
+	// Legacy votes sign a caller chosen raw digest, never approve them by rule
+	if request != nil && request.ContentType == accounts.MimetypeZkscamLegacyVote {
+		return r.next.ApproveSignData(request)
+	}
 	jsonreq, err := json.Marshal(request)
 	approved, err := r.checkApproval("ApproveSignData", jsonreq, err)
 	if err != nil {
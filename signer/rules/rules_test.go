@@ -494,6 +494,66 @@ func TestLimitWindow(t *testing.T) {
 	}
 }
 
+func TestSignVoteRequest(t *testing.T) {
+	t.Parallel()
+	js := `
+	function ApproveSignData(r){
+		if (r.content_type == "application/x-zkscam-vote" && parseInt(r.vote.number) <= 100){
+			return "Approve"
+		}
+	}`
+	r, err := initRuleEngine(js)
+	if err != nil {
+		t.Fatalf("Couldn't create evaluator %v", err)
+	}
+	for _, tt := range []struct {
+		number uint64
+		want   bool
+	}{
+		{100, true},
+		{101, false},
+	} {
+		vote := &apitypes.Vote{Number: hexutil.Uint64(tt.number), Hash: common.HexToHash("0x01")}
+		resp, err := r.ApproveSignData(&core.SignDataRequest{
+			ContentType: accounts.MimetypeZkscamVote,
+			Hash:        vote.Hash.Bytes(),
+			Vote:        vote,
+		})
+		if err != nil {
+			t.Fatalf("height %d: unexpected error %v", tt.number, err)
+		}
+		if resp.Approved != tt.want {
+			t.Errorf("height %d: approved %v, want %v", tt.number, resp.Approved, tt.want)
+		}
+	}
+}
+
+// Tests that legacy votes, signing a raw digest, are never approved by rules.
+func TestSignLegacyVoteRequest(t *testing.T) {
+	t.Parallel()
+	js := `
+	function ApproveSignData(r){
+		return "Approve"
+	}`
+	ui := &dummyUI{make([]string, 0)}
+	r, err := NewRuleEvaluator(ui, storage.NewEphemeralStorage())
+	if err != nil {
+		t.Fatalf("Failed to create js engine: %v", err)
+	}
+	if err = r.Init(js); err != nil {
+		t.Fatalf("Failed to load bootstrap js: %v", err)
+	}
+	vote := &apitypes.Vote{Number: 1, Hash: common.HexToHash("0x01"), Legacy: true}
+	r.ApproveSignData(&core.SignDataRequest{
+		ContentType: accounts.MimetypeZkscamLegacyVote,
+		Hash:        vote.Hash.Bytes(),
+		Vote:        vote,
+	})
+	if len(ui.calls) != 1 || ui.calls[0] != "ApproveSignData" {
+		t.Errorf("legacy vote not forwarded to the user: %v", ui.calls)
+	}
+}
+
 // dontCallMe is used as a next-handler that does not want to be called - it invokes test failure
 type dontCallMe struct {
 	t *testing.T
@@ -0,0 +1,364 @@
+package singleton
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/pairing/bn256"
+	"go.dedis.ch/kyber/v3/sign/bls"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/text/unicode/norm"
+)
+
+// BLSKeyStoreDir is the name of the directory, relative to the node instance
+// directory, holding the encrypted BLS voting keys of local miners.
+const BLSKeyStoreDir = "blskeystore"
+
+const (
+	blsKeyVersion   = 4 // EIP-2335 keystore version
+	blsKDFScrypt    = "scrypt"
+	blsChecksumFunc = "sha256"
+	blsCipherFunc   = "aes-128-ctr"
+	blsScryptDKLen  = 32
+	blsScryptR      = 8
+)
+
+var (
+	// ErrBLSKeyNotFound is returned if no BLS key is stored for a miner address.
+	ErrBLSKeyNotFound = errors.New("no BLS key stored for address")
+
+	// ErrBLSDecrypt is returned if a BLS keystore cannot be decrypted with the
+	// given password.
+	ErrBLSDecrypt = errors.New("could not decrypt BLS key with given password")
+)
+
+// BLSKey is a decrypted BLS voting key together with the miner account it was
+// authorized for.
+type BLSKey struct {
+	Address       common.Address // Miner account the key votes for
+	Secret        kyber.Scalar   // BLS secret key on the bn256 curve
+	Authorization []byte         // Miner signature over sha256(public key), may be nil
+}
+
+// PublicKey returns the serialized BLS public key, as carried in votes.
+func (k *BLSKey) PublicKey() []byte {
+	pub, _ := bn256.NewSuite().G2().Point().Mul(k.Secret, nil).MarshalBinary()
+	return pub
+}
+
+// Authorized reports whether the key carries a valid authorization signature
+// of its miner account.
+func (k *BLSKey) Authorized() bool {
+	if len(k.Authorization) == 0 {
+		return false
+	}
+	ok, _ := VerifyAnyLengthMessageSignatureWithAddress(k.PublicKey(), k.Authorization, k.Address)
+	return ok
+}
+
+// Sign signs the message with the BLS key, as miners sign the zkscam hash of
+// the blocks they vote for.
+func (k *BLSKey) Sign(message []byte) ([]byte, error) {
+	return bls.Sign(bn256.NewSuite(), k.Secret, message)
+}
+
+// ProvePossession signs the proof of possession of the key, which contracts
+// verifying aggregated votes require for every key they aggregate.
+func (k *BLSKey) ProvePossession() ([]byte, error) {
+	return k.Sign(BLSPossessionMessage(k.PublicKey()))
+}
+
+// GenerateBLSKey creates a new BLS voting key from the system randomness. The
+// key is independent of the miner's ECDSA key.
+func GenerateBLSKey(addr common.Address) *BLSKey {
+	suite := bn256.NewSuite()
+	return &BLSKey{
+		Address: addr,
+		Secret:  suite.G2().Scalar().Pick(suite.RandomStream()),
+	}
+}
+
+// ParseBLSSecret decodes a raw 32 byte big endian BLS secret key.
+func ParseBLSSecret(raw []byte) (kyber.Scalar, error) {
+	if len(raw) != 32 {
+		return nil, fmt.Errorf("invalid BLS secret length %d, want 32", len(raw))
+	}
+	secret := bn256.NewSuite().G2().Scalar()
+	if err := secret.UnmarshalBinary(raw); err != nil {
+		return nil, err
+	}
+	if secret.Equal(bn256.NewSuite().G2().Scalar().Zero()) {
+		return nil, errors.New("invalid zero BLS secret")
+	}
+	return secret, nil
+}
+
+// Authorize signs the BLS public key with the miner account through the given
+// signer, producing the authorization carried on-chain next to every vote.
+func (k *BLSKey) Authorize(signFn HashSignerFn) error {
+	hash := sha256.Sum256(k.PublicKey())
+	sig, err := signFn(hash[:])
+	if err != nil {
+		return err
+	}
+	if err := verifyHashSignature(hash[:], sig, k.Address); err != nil {
+		return fmt.Errorf("invalid authorization signature: %v", err)
+	}
+	k.Authorization = sig
+	return nil
+}
+
+// blsKeyJSON is the EIP-2335 keystore layout, extended with the miner account
+// the key belongs to and its authorization signature.
+type blsKeyJSON struct {
+	Crypto        blsCryptoJSON `json:"crypto"`
+	Description   string        `json:"description"`
+	Pubkey        string        `json:"pubkey"`
+	Path          string        `json:"path"`
+	UUID          string        `json:"uuid"`
+	Version       int           `json:"version"`
+	Address       string        `json:"address"`
+	Authorization string        `json:"authorization,omitempty"`
+}
+
+type blsCryptoJSON struct {
+	KDF      blsModuleJSON `json:"kdf"`
+	Checksum blsModuleJSON `json:"checksum"`
+	Cipher   blsModuleJSON `json:"cipher"`
+}
+
+type blsModuleJSON struct {
+	Function string                 `json:"function"`
+	Params   map[string]interface{} `json:"params"`
+	Message  string                 `json:"message"`
+}
+
+// EncryptBLSKey encrypts a BLS key into an EIP-2335 style keystore using scrypt
+// with the given parameters.
+func EncryptBLSKey(key *BLSKey, password string, scryptN, scryptP int) ([]byte, error) {
+	secret, err := key.Secret.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	iv := make([]byte, aes.BlockSize)
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		return nil, err
+	}
+	derivedKey, err := scrypt.Key(normalizeBLSPassword(password), salt, scryptN, blsScryptR, scryptP, blsScryptDKLen)
+	if err != nil {
+		return nil, err
+	}
+	cipherText, err := aesCTRXOR(derivedKey[:16], secret, iv)
+	if err != nil {
+		return nil, err
+	}
+	checksum := sha256.Sum256(append(common.CopyBytes(derivedKey[16:32]), cipherText...))
+
+	keyjson := blsKeyJSON{
+		Crypto: blsCryptoJSON{
+			KDF: blsModuleJSON{
+				Function: blsKDFScrypt,
+				Params: map[string]interface{}{
+					"dklen": blsScryptDKLen,
+					"n":     scryptN,
+					"r":     blsScryptR,
+					"p":     scryptP,
+					"salt":  hex.EncodeToString(salt),
+				},
+			},
+			Checksum: blsModuleJSON{
+				Function: blsChecksumFunc,
+				Params:   map[string]interface{}{},
+				Message:  hex.EncodeToString(checksum[:]),
+			},
+			Cipher: blsModuleJSON{
+				Function: blsCipherFunc,
+				Params:   map[string]interface{}{"iv": hex.EncodeToString(iv)},
+				Message:  hex.EncodeToString(cipherText),
+			},
+		},
+		Description: "zkscam BLS voting key",
+		Pubkey:      hex.EncodeToString(key.PublicKey()),
+		UUID:        uuid.New().String(),
+		Version:     blsKeyVersion,
+		Address:     hex.EncodeToString(key.Address[:]),
+	}
+	if len(key.Authorization) > 0 {
+		keyjson.Authorization = hex.EncodeToString(key.Authorization)
+	}
+	return json.MarshalIndent(keyjson, "", "  ")
+}
+
+// DecryptBLSKey decrypts an EIP-2335 style keystore, checking that the secret
+// matches the public key recorded in it.
+func DecryptBLSKey(data []byte, password string) (*BLSKey, error) {
+	var keyjson blsKeyJSON
+	if err := json.Unmarshal(data, &keyjson); err != nil {
+		return nil, err
+	}
+	if keyjson.Version != blsKeyVersion {
+		return nil, fmt.Errorf("unsupported BLS keystore version %d", keyjson.Version)
+	}
+	c := keyjson.Crypto
+	if c.KDF.Function != blsKDFScrypt {
+		return nil, fmt.Errorf("unsupported KDF %q", c.KDF.Function)
+	}
+	if c.Checksum.Function != blsChecksumFunc {
+		return nil, fmt.Errorf("unsupported checksum %q", c.Checksum.Function)
+	}
+	if c.Cipher.Function != blsCipherFunc {
+		return nil, fmt.Errorf("unsupported cipher %q", c.Cipher.Function)
+	}
+	salt, err := hex.DecodeString(fmt.Sprint(c.KDF.Params["salt"]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid salt: %v", err)
+	}
+	iv, err := hex.DecodeString(fmt.Sprint(c.Cipher.Params["iv"]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid iv: %v", err)
+	}
+	cipherText, err := hex.DecodeString(c.Cipher.Message)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cipher message: %v", err)
+	}
+	checksum, err := hex.DecodeString(c.Checksum.Message)
+	if err != nil {
+		return nil, fmt.Errorf("invalid checksum: %v", err)
+	}
+	dkLen, n, r, p := intParam(c.KDF.Params, "dklen"), intParam(c.KDF.Params, "n"), intParam(c.KDF.Params, "r"), intParam(c.KDF.Params, "p")
+	if dkLen != blsScryptDKLen {
+		return nil, fmt.Errorf("unsupported scrypt dklen %d", dkLen)
+	}
+	derivedKey, err := scrypt.Key(normalizeBLSPassword(password), salt, n, r, p, dkLen)
+	if err != nil {
+		return nil, err
+	}
+	calculated := sha256.Sum256(append(common.CopyBytes(derivedKey[16:32]), cipherText...))
+	if !bytes.Equal(calculated[:], checksum) {
+		return nil, ErrBLSDecrypt
+	}
+	plainText, err := aesCTRXOR(derivedKey[:16], cipherText, iv)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := ParseBLSSecret(plainText)
+	if err != nil {
+		return nil, err
+	}
+	key := &BLSKey{
+		Address: common.HexToAddress(keyjson.Address),
+		Secret:  secret,
+	}
+	if keyjson.Pubkey != "" && keyjson.Pubkey != hex.EncodeToString(key.PublicKey()) {
+		return nil, errors.New("BLS keystore public key does not match secret")
+	}
+	if keyjson.Authorization != "" {
+		if key.Authorization, err = hex.DecodeString(keyjson.Authorization); err != nil {
+			return nil, fmt.Errorf("invalid authorization: %v", err)
+		}
+	}
+	return key, nil
+}
+
+// intParam extracts an integer KDF parameter, which json decodes as float64.
+func intParam(params map[string]interface{}, name string) int {
+	if v, ok := params[name].(float64); ok {
+		return int(v)
+	}
+	return 0
+}
+
+// normalizeBLSPassword applies the EIP-2335 password processing: NFKD
+// normalization followed by stripping control codes.
+func normalizeBLSPassword(password string) []byte {
+	return []byte(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, norm.NFKD.String(password)))
+}
+
+func aesCTRXOR(key, inText, iv []byte) ([]byte, error) {
+	aesBlock, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	stream := cipher.NewCTR(aesBlock, iv)
+	outText := make([]byte, len(inText))
+	stream.XORKeyStream(outText, inText)
+	return outText, nil
+}
+
+// BLSKeyFile returns the path of the active BLS keystore of a miner account.
+func BLSKeyFile(dir string, addr common.Address) string {
+	return filepath.Join(dir, hex.EncodeToString(addr[:])+".json")
+}
+
+// ReadBLSKey loads and decrypts the active BLS key of a miner account.
+func ReadBLSKey(dir string, addr common.Address, password string) (*BLSKey, error) {
+	data, err := os.ReadFile(BLSKeyFile(dir, addr))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrBLSKeyNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	key, err := DecryptBLSKey(data, password)
+	if err != nil {
+		return nil, err
+	}
+	if key.Address != addr {
+		return nil, fmt.Errorf("BLS key content mismatch: have account %x, want %x", key.Address, addr)
+	}
+	return key, nil
+}
+
+// WriteBLSKey encrypts and stores the key as the active BLS key of its miner
+// account. A previously stored key is moved into the retired subdirectory, so
+// rotated keys are never lost.
+func WriteBLSKey(dir string, key *BLSKey, password string, scryptN, scryptP int) (string, error) {
+	keyjson, err := EncryptBLSKey(key, password, scryptN, scryptP)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	// Write through a temporary file so a crash never leaves a partial key behind
+	path := BLSKeyFile(dir, key.Address)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, keyjson, 0600); err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(path); err == nil {
+		retired := filepath.Join(dir, "retired")
+		if err := os.MkdirAll(retired, 0700); err != nil {
+			return "", err
+		}
+		ts := time.Now().UTC().Format("2006-01-02T15-04-05.000000000Z")
+		if err := os.Rename(path, filepath.Join(retired, fmt.Sprintf("UTC--%s--%x.json", ts, key.Address[:]))); err != nil {
+			return "", err
+		}
+	}
+	return path, os.Rename(tmp, path)
+}
@@ -0,0 +1,133 @@
+package singleton
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	testScryptN = 1 << 12
+	testScryptP = 6
+)
+
+func TestBLSKeyEncryptDecrypt(t *testing.T) {
+	ecdsaKey, _ := crypto.GenerateKey()
+	key := GenerateBLSKey(crypto.PubkeyToAddress(ecdsaKey.PublicKey))
+	if err := key.Authorize(func(hash []byte) ([]byte, error) { return crypto.Sign(hash, ecdsaKey) }); err != nil {
+		t.Fatalf("failed to authorize key: %v", err)
+	}
+	keyjson, err := EncryptBLSKey(key, "foo", testScryptN, testScryptP)
+	if err != nil {
+		t.Fatalf("failed to encrypt key: %v", err)
+	}
+	if _, err := DecryptBLSKey(keyjson, "bar"); !errors.Is(err, ErrBLSDecrypt) {
+		t.Fatalf("wrong password error mismatch: have %v, want %v", err, ErrBLSDecrypt)
+	}
+	dec, err := DecryptBLSKey(keyjson, "foo")
+	if err != nil {
+		t.Fatalf("failed to decrypt key: %v", err)
+	}
+	if dec.Address != key.Address || !bytes.Equal(dec.PublicKey(), key.PublicKey()) {
+		t.Fatalf("decrypted key mismatch")
+	}
+	if !dec.Authorized() {
+		t.Fatalf("decrypted key lost its authorization")
+	}
+	// An authorization by another account must be rejected
+	other, _ := crypto.GenerateKey()
+	if err := dec.Authorize(func(hash []byte) ([]byte, error) { return crypto.Sign(hash, other) }); err == nil {
+		t.Fatalf("foreign authorization accepted")
+	}
+}
+
+func TestBLSKeyRotation(t *testing.T) {
+	dir := t.TempDir()
+	ecdsaKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(ecdsaKey.PublicKey)
+
+	if _, err := ReadBLSKey(dir, addr, "foo"); !errors.Is(err, ErrBLSKeyNotFound) {
+		t.Fatalf("missing key error mismatch: have %v, want %v", err, ErrBLSKeyNotFound)
+	}
+	first, second := GenerateBLSKey(addr), GenerateBLSKey(addr)
+	if bytes.Equal(first.PublicKey(), second.PublicKey()) {
+		t.Fatalf("generated keys are not independent")
+	}
+	if _, err := WriteBLSKey(dir, first, "foo", testScryptN, testScryptP); err != nil {
+		t.Fatalf("failed to write first key: %v", err)
+	}
+	if _, err := WriteBLSKey(dir, second, "foo", testScryptN, testScryptP); err != nil {
+		t.Fatalf("failed to write second key: %v", err)
+	}
+	key, err := ReadBLSKey(dir, addr, "foo")
+	if err != nil {
+		t.Fatalf("failed to read key: %v", err)
+	}
+	if !bytes.Equal(key.PublicKey(), second.PublicKey()) {
+		t.Fatalf("active key is not the rotated one")
+	}
+	retired, err := os.ReadDir(filepath.Join(dir, "retired"))
+	if err != nil || len(retired) != 1 {
+		t.Fatalf("retired key missing: %v, %d files", err, len(retired))
+	}
+	keyjson, _ := os.ReadFile(filepath.Join(dir, "retired", retired[0].Name()))
+	if old, err := DecryptBLSKey(keyjson, "foo"); err != nil || !bytes.Equal(old.PublicKey(), first.PublicKey()) {
+		t.Fatalf("retired key mismatch: %v", err)
+	}
+}
+
+// Tests that the miner only votes with its own configured BLS key, and that no
+// key is derived from the account key in its absence.
+func TestBLSKeyNotDerived(t *testing.T) {
+	ecdsaKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(ecdsaKey.PublicKey)
+
+	SetPrivateKey(ecdsaKey)
+	SetBLSKey(nil)
+	defer SetBLSKey(nil)
+
+	if _, err := GetBLSPrivateKey(); !errors.Is(err, ErrNoBLSKey) {
+		t.Fatalf("missing key error mismatch: have %v, want %v", err, ErrNoBLSKey)
+	}
+	if sig := BLSSign([32]byte{}); sig != nil {
+		t.Fatalf("signed without a BLS key")
+	}
+	other, _ := crypto.GenerateKey()
+	SetBLSKey(GenerateBLSKey(crypto.PubkeyToAddress(other.PublicKey)))
+	if _, err := GetBLSPrivateKey(); !errors.Is(err, ErrNoBLSKey) {
+		t.Fatalf("foreign key error mismatch: have %v, want %v", err, ErrNoBLSKey)
+	}
+	key := GenerateBLSKey(addr)
+	SetBLSKey(key)
+	if pub := GetBLSKeyBytes(); !bytes.Equal(pub, key.PublicKey()) {
+		t.Fatalf("public key mismatch: have %x, want %x", pub, key.PublicKey())
+	}
+}
+
+func TestBLSKeyPossession(t *testing.T) {
+	var (
+		key   = GenerateBLSKey(common.Address{0x01})
+		other = GenerateBLSKey(common.Address{0x02})
+	)
+	proof, err := key.ProvePossession()
+	if err != nil {
+		t.Fatalf("failed to prove possession: %v", err)
+	}
+	if !VerifyBLSPossession(key.PublicKey(), proof) {
+		t.Fatalf("valid proof of possession rejected")
+	}
+	if VerifyBLSPossession(other.PublicKey(), proof) {
+		t.Fatalf("proof of possession accepted for another key")
+	}
+	// A vote over the key's possession message is no proof, as votes are over
+	// 32 byte hashes only
+	vote, _ := key.Sign(crypto.Keccak256(BLSPossessionMessage(key.PublicKey())))
+	if VerifyBLSPossession(key.PublicKey(), vote) {
+		t.Fatalf("vote accepted as proof of possession")
+	}
+}
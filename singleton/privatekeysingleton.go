@@ -1,6 +1,7 @@
 package singleton
 
 import (
+	"bytes"
 	"crypto/ecdsa"
 	"crypto/sha256"
 	"encoding/hex"
@@ -9,63 +10,198 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/metrics"
 	"go.dedis.ch/kyber/v3"
 	"go.dedis.ch/kyber/v3/pairing/bn256"
 	"go.dedis.ch/kyber/v3/sign/bls"
 )
 
+// HashSignerFn signs a 32 byte digest on behalf of the miner account and returns
+// a 65 byte [R || S || V] secp256k1 signature, with V being 0 or 1.
+type HashSignerFn func(hash []byte) ([]byte, error)
+
+// AuthSignerFn signs the authorization of the given serialized BLS public key
+// on behalf of the miner account, a signature over its sha256 hash. Signers
+// hash the key themselves, so they are never asked to sign arbitrary digests.
+type AuthSignerFn func(pub []byte) ([]byte, error)
+
+// VoteSignerFn signs the vote for the given block hash at the given height on
+// behalf of the miner account. The signature covers the accounts.ZkscamVoteHash
+// of the vote, or the raw hash alone for legacy votes cast before the vote
+// digest fork. The height allows signers to refuse conflicting votes.
+type VoteSignerFn func(number uint64, hash common.Hash, legacy bool) ([]byte, error)
+
 var (
 	instance          *ecdsa.PrivateKey
 	address           common.Address
+	authSigner        AuthSignerFn // 外部签名器（keystore/clef）的BLS公钥授权签名回调
+	voteSigner        VoteSignerFn // 外部签名器的投票签名回调
+	blsKey            *BLSKey      // 从BLS keystore加载的独立BLS密钥
+	blsAuth           []byte       // 当前BLS公钥的授权签名缓存
+	blsAuthPub        []byte       // blsAuth 对应的BLS公钥
 	mu                sync.Mutex
 	initialized       bool
 	IsReorging        bool
 	errNotInitialized = errors.New("private key is not initialized")
+
+	// ErrNoBLSKey is returned if no BLS key of the miner account is configured.
+	// BLS keys are never derived from the miner key.
+	ErrNoBLSKey = errors.New("no BLS voting key configured for miner")
+)
+
+var (
+	blsVerifyTimer          = metrics.NewRegisteredTimer("consensus/bls/verify", nil)
+	blsAggregateTimer       = metrics.NewRegisteredTimer("consensus/bls/aggregate", nil)
+	blsAggregateVerifyTimer = metrics.NewRegisteredTimer("consensus/bls/aggregate/verify", nil)
 )
 
-// New initializes the singleton instance by reading the private key and address from a file.
-// The file should contain the private key on the first line and the address on the second line.
+// New returns the miner key and address. Miners using the legacy key file are
+// initialized through LoadKeyFile, miners using a keystore account or an external
+// signer through SetSigner, in which case the returned private key is nil.
 func New() (*ecdsa.PrivateKey, common.Address, error) {
 	mu.Lock() // 使用互斥锁来确保并发安全
 	defer mu.Unlock()
 
-	// 如果已经成功初始化，直接返回实例
-	if initialized {
-		return instance, address, nil
+	if !initialized {
+		return nil, common.Address{}, errNotInitialized
 	}
+	return instance, address, nil
+}
 
-	// 执行初始化逻辑
-	data, err := os.ReadFile("./miner_private_key.txt")
+// LoadKeyFile initializes the singleton from a legacy plaintext key file,
+// replacing any previously configured key or signer.
+func LoadKeyFile(path string) (common.Address, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if err := loadKeyFile(path); err != nil {
+		return common.Address{}, err
+	}
+	return address, nil
+}
+
+// loadKeyFile reads the private key and address from the given file. The caller
+// must hold the lock.
+func loadKeyFile(path string) error {
+	key, err := ReadKeyFile(path)
 	if err != nil {
-		return nil, common.Address{}, fmt.Errorf("failed to read file: %v", err)
+		return err
+	}
+	setPrivateKey(key)
+	return nil
+}
+
+// ReadKeyFile reads a legacy plaintext key file, holding the hex encoded private
+// key on the first line and the miner address on the second one.
+func ReadKeyFile(path string) (*ecdsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %v", err)
 	}
 
 	// Split the file content into lines
 	lines := splitLines(string(data))
 	if len(lines) < 2 {
-		return nil, common.Address{}, fmt.Errorf("file format is incorrect: expected private key and address")
+		return nil, fmt.Errorf("file format is incorrect: expected private key and address")
 	}
 
 	// Parse the private key
-	privateKeyBytes, err := hex.DecodeString(lines[0])
+	privateKeyBytes, err := hex.DecodeString(strings.TrimPrefix(lines[0], "0x"))
 	if err != nil {
-		return nil, common.Address{}, fmt.Errorf("failed to decode private key: %v", err)
+		return nil, fmt.Errorf("failed to decode private key: %v", err)
 	}
-	instance, err = crypto.ToECDSA(privateKeyBytes)
+	key, err := crypto.ToECDSA(privateKeyBytes)
 	if err != nil {
-		return nil, common.Address{}, fmt.Errorf("failed to parse private key: %v", err)
+		return nil, fmt.Errorf("failed to parse private key: %v", err)
+	}
+	// Parse the address and make sure it belongs to the key
+	addr := common.HexToAddress(lines[1])
+	if derived := crypto.PubkeyToAddress(key.PublicKey); derived != addr {
+		return nil, fmt.Errorf("address mismatch: file has %s, key belongs to %s", addr.Hex(), derived.Hex())
 	}
+	return key, nil
+}
 
-	// Parse the address
-	address = common.HexToAddress(lines[1])
+// SetPrivateKey initializes the singleton with the given private key, replacing
+// any previously configured key or signer.
+func SetPrivateKey(key *ecdsa.PrivateKey) {
+	mu.Lock()
+	defer mu.Unlock()
+	setPrivateKey(key)
+}
+
+func setPrivateKey(key *ecdsa.PrivateKey) {
+	instance, address, authSigner, voteSigner = key, crypto.PubkeyToAddress(key.PublicKey), nil, nil
+	blsAuth, blsAuthPub = nil, nil
 
 	// 如果成功，标记为已初始化
 	initialized = true
 	IsReorging = false
-	return instance, address, nil
+}
+
+// SetSigner initializes the singleton with an external signer for the given
+// address, replacing any previously configured key. BLS key authorizations are
+// signed by authFn and votes by voteFn. The BLS key is configured separately
+// through SetBLSKey.
+func SetSigner(addr common.Address, authFn AuthSignerFn, voteFn VoteSignerFn) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	instance, address, authSigner, voteSigner = nil, addr, authFn, voteFn
+	blsAuth, blsAuthPub = nil, nil
+	initialized = true
+	IsReorging = false
+}
+
+// SignHash signs the given 32 byte digest with the local miner key. Miners using
+// an external signer can't sign arbitrary digests.
+func SignHash(hash []byte) ([]byte, error) {
+	mu.Lock()
+	key := instance
+	mu.Unlock()
+
+	if key == nil {
+		return nil, errNotInitialized
+	}
+	return crypto.Sign(hash, key)
+}
+
+// SignVote signs the vote for the given block hash at the given height with the
+// miner key, either locally or through the configured external signer. Legacy
+// votes, cast before the vote digest fork, sign the raw hash.
+func SignVote(number uint64, hash common.Hash, legacy bool) ([]byte, error) {
+	mu.Lock()
+	voteFn := voteSigner
+	mu.Unlock()
+
+	if voteFn != nil {
+		return voteFn(number, hash, legacy)
+	}
+	if legacy {
+		return SignHash(hash.Bytes())
+	}
+	return SignHash(accounts.ZkscamVoteHash(number, hash))
+}
+
+// verifyHashSignature checks that the signature over the digest was produced by
+// the given address.
+func verifyHashSignature(hash []byte, sig []byte, addr common.Address) error {
+	if len(sig) != crypto.SignatureLength {
+		return errors.New("signature length is incorrect")
+	}
+	pubKey, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return err
+	}
+	if recovered := crypto.PubkeyToAddress(*pubKey); recovered != addr {
+		return fmt.Errorf("signer mismatch: have %s, want %s", recovered.Hex(), addr.Hex())
+	}
+	return nil
 }
 
 // Helper function to split string by newlines and return non-empty lines.
@@ -90,20 +226,36 @@ func GetPrivateKey() (*ecdsa.PrivateKey, error) {
 	return instance, nil
 }
 
-// GetBLSPrivateKey 通过ECDSA私钥生成BLS私钥
+// SetBLSKey configures an independently generated BLS key for voting. The key
+// is only used while the miner address matches the account it belongs to,
+// otherwise the miner has no BLS key and cannot vote.
+func SetBLSKey(key *BLSKey) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	blsKey = key
+	blsAuth, blsAuthPub = nil, nil
+}
+
+// HasBLSKey reports whether an independent BLS key is used for voting.
+func HasBLSKey() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return blsKey != nil && initialized && blsKey.Address == address
+}
+
+// GetBLSPrivateKey 获取BLS私钥：仅使用为当前矿工配置的独立BLS密钥
 func GetBLSPrivateKey() (kyber.Scalar, error) {
 	mu.Lock()
 	defer mu.Unlock()
-	if instance == nil {
+
+	if !initialized {
 		return nil, errNotInitialized
 	}
-
-	// 对ECDSA私钥的D值进行哈希，生成BLS私钥
-	hash := sha256.Sum256(instance.D.Bytes())
-	suite := bn256.NewSuite()
-	blsPrivateKey := suite.G2().Scalar().SetBytes(hash[:])
-
-	return blsPrivateKey, nil
+	if blsKey == nil || blsKey.Address != address {
+		return nil, ErrNoBLSKey
+	}
+	return blsKey.Secret, nil
 }
 
 // GetBLSPublicKey 获取与BLS私钥对应的BLS公钥
@@ -137,6 +289,8 @@ func BLSSign(message common.Hash) []byte {
 
 // BLSVerify 验证BLS签名
 func BLSVerify(message []byte, signature []byte, pubKey []byte) (bool, error) {
+	defer blsVerifyTimer.UpdateSince(time.Now())
+
 	blsPublicKey, err := UnmarshalBLSKeyBytes(pubKey)
 	if err != nil {
 		return false, err
@@ -151,8 +305,22 @@ func BLSVerify(message []byte, signature []byte, pubKey []byte) (bool, error) {
 	return true, nil
 }
 
+// BLSAggregate aggregates the BLS signatures of several miners over the same
+// message into the signature carried by blocks.
+func BLSAggregate(signatures ...[]byte) ([]byte, error) {
+	defer blsAggregateTimer.UpdateSince(time.Now())
+
+	return bls.AggregateSignatures(bn256.NewSuite(), signatures...)
+}
+
 // BLSAggregateVerify 验证BLS聚合签名
 func BLSAggregateVerify(message []byte, aggregatedSignature []byte, pubKeys [][]byte) (bool, error) {
+	defer blsAggregateVerifyTimer.UpdateSince(time.Now())
+
+	// 没有公钥时聚合公钥为零点, 零签名即可通过验证
+	if len(pubKeys) == 0 {
+		return false, errors.New("no BLS public keys to verify against")
+	}
 	// 初始化 BLS 套件
 	suite := bn256.NewSuite()
 
@@ -179,6 +347,25 @@ func BLSAggregateVerify(message []byte, aggregatedSignature []byte, pubKeys [][]
 	return true, nil
 }
 
+// blsPossessionDomain prefixes the public key in the message signed by a proof
+// of possession. The message is longer than the zkscam hash of a block, so a
+// proof is never valid as a vote and a vote never as a proof.
+const blsPossessionDomain = "zkscam-bls-possession:"
+
+// BLSPossessionMessage returns the message signed by the proof of possession of
+// a BLS public key.
+func BLSPossessionMessage(pub []byte) []byte {
+	return append([]byte(blsPossessionDomain), pub...)
+}
+
+// VerifyBLSPossession checks the proof of possession of a BLS public key, which
+// shows that the key was not derived from the keys of other miners to forge an
+// aggregated signature.
+func VerifyBLSPossession(pub []byte, proof []byte) bool {
+	ok, _ := BLSVerify(BLSPossessionMessage(pub), proof, pub)
+	return ok
+}
+
 // GetBLSKeyBytes 序列化BLS公钥
 func GetBLSKeyBytes() []byte {
 	blsPublicKey, err := GetBLSPublicKey()
@@ -192,6 +379,56 @@ func GetBLSKeyBytes() []byte {
 	return blsKey
 }
 
+// BLSAuthorization returns the miner's signature over the current BLS public
+// key, which is carried next to every vote and registers the key on-chain. The
+// signature stored with the BLS key is used if valid, otherwise the miner key
+// signs once and the result is cached.
+func BLSAuthorization() []byte {
+	pub := GetBLSKeyBytes()
+	if pub == nil {
+		return nil
+	}
+	mu.Lock()
+	if blsAuth != nil && bytes.Equal(blsAuthPub, pub) {
+		defer mu.Unlock()
+		return blsAuth
+	}
+	key := blsKey
+	mu.Unlock()
+
+	var auth []byte
+	if key != nil && bytes.Equal(key.PublicKey(), pub) && key.Authorized() {
+		auth = key.Authorization
+	} else if auth = authorizeBLSKey(pub); auth == nil {
+		return nil
+	}
+	mu.Lock()
+	blsAuth, blsAuthPub = auth, pub
+	mu.Unlock()
+	return auth
+}
+
+// authorizeBLSKey signs the authorization of the given BLS public key with the
+// miner key, either locally or through the configured external signer, whose
+// signature is checked against the miner address.
+func authorizeBLSKey(pub []byte) []byte {
+	mu.Lock()
+	addr, authFn := address, authSigner
+	mu.Unlock()
+
+	if authFn == nil {
+		return SignAnyLengthMessage(pub)
+	}
+	auth, err := authFn(pub)
+	if err != nil {
+		return nil
+	}
+	if ok, _ := VerifyAnyLengthMessageSignatureWithAddress(pub, auth, addr); !ok {
+		return nil
+	}
+	return auth
+}
+
 // UnmarshalBLSKeyBytes 反序列化BLS公钥
 func UnmarshalBLSKeyBytes(blsKeyBytes []byte) (kyber.Point, error) {
 	suite := bn256.NewSuite()
@@ -203,43 +440,26 @@ func UnmarshalBLSKeyBytes(blsKeyBytes []byte) (kyber.Point, error) {
 	return blsPublicKey, nil
 }
 
-// GetETHAddress 返回与当前ECDSA私钥对应的以太坊地址
+// GetETHAddress 返回当前矿工的以太坊地址
 func GetETHAddress() common.Address {
 	mu.Lock()
 	defer mu.Unlock()
-	if instance == nil {
+	if !initialized {
 		return common.Address{}
 	}
-
-	// 使用Geth的crypto库来获取ECDSA公钥并生成以太坊地址
-	pubKey := instance.Public()
-	pubKeyECDSA, ok := pubKey.(*ecdsa.PublicKey)
-	if !ok {
-		return common.Address{}
-	}
-
-	// 使用Geth的crypto库生成以太坊地址
-	address := crypto.PubkeyToAddress(*pubKeyECDSA)
-
 	return address
 }
 
 // SignAnyLengthMessage 使用ETH私钥对任意长度的数据进行签名
 func SignAnyLengthMessage(message []byte) []byte {
-	privateKey, err := GetPrivateKey()
-	if err != nil {
-		return nil
-	}
-
 	// 对消息进行哈希处理 (使用 SHA-256)
 	hash := sha256.Sum256(message)
 
-	// 使用以太坊的 crypto 库签名哈希值，生成 65 字节的签名
-	signature, err := crypto.Sign(hash[:], privateKey)
+	// 使用本地矿工私钥签名哈希值，生成 65 字节的签名；外部签名器见 authorizeBLSKey
+	signature, err := SignHash(hash[:])
 	if err != nil {
 		return nil
 	}
-
 	return signature
 }
 
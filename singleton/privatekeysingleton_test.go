@@ -0,0 +1,169 @@
+package singleton
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// writeKeyFile writes a legacy plaintext key file with the given lines.
+func writeKeyFile(t *testing.T, lines ...string) string {
+	path := filepath.Join(t.TempDir(), "miner_private_key.txt")
+	var data []byte
+	for _, line := range lines {
+		data = append(data, line+"\n"...)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("failed to write key file: %v", err)
+	}
+	return path
+}
+
+func TestReadKeyFile(t *testing.T) {
+	var (
+		key, _   = crypto.GenerateKey()
+		other, _ = crypto.GenerateKey()
+		hexkey   = fmt.Sprintf("%x", crypto.FromECDSA(key))
+		addr     = crypto.PubkeyToAddress(key.PublicKey)
+	)
+	tests := []struct {
+		name  string
+		lines []string
+		fail  bool
+	}{
+		{"plain", []string{hexkey, addr.Hex()}, false},
+		{"prefixed", []string{"0x" + hexkey, "", addr.Hex()}, false},
+		{"missing address", []string{hexkey}, true},
+		{"foreign address", []string{hexkey, crypto.PubkeyToAddress(other.PublicKey).Hex()}, true},
+		{"invalid hex", []string{"0xzz", addr.Hex()}, true},
+		{"invalid key", []string{"00", addr.Hex()}, true},
+	}
+	for _, tt := range tests {
+		have, err := ReadKeyFile(writeKeyFile(t, tt.lines...))
+		if tt.fail {
+			if err == nil {
+				t.Errorf("%s: expected failure", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: failed to read key file: %v", tt.name, err)
+			continue
+		}
+		if !have.Equal(key) {
+			t.Errorf("%s: key mismatch", tt.name)
+		}
+	}
+	if _, err := ReadKeyFile(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Errorf("missing file read without error")
+	}
+}
+
+// Tests that loading the legacy key file configures the local miner key, and
+// that a failed load keeps the previously configured one.
+func TestLoadKeyFile(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	have, err := LoadKeyFile(writeKeyFile(t, fmt.Sprintf("%x", crypto.FromECDSA(key)), addr.Hex()))
+	if err != nil {
+		t.Fatalf("failed to load key file: %v", err)
+	}
+	if have != addr {
+		t.Fatalf("address mismatch: have %s, want %s", have.Hex(), addr.Hex())
+	}
+	if local, loaded, err := New(); err != nil || loaded != addr || !local.Equal(key) {
+		t.Fatalf("loaded key mismatch: have %s, %v", loaded.Hex(), err)
+	}
+	if _, err := LoadKeyFile(writeKeyFile(t, "0xzz")); err == nil {
+		t.Fatalf("invalid key file loaded without error")
+	}
+	if loaded := GetETHAddress(); loaded != addr {
+		t.Fatalf("failed load replaced the miner: have %s, want %s", loaded.Hex(), addr.Hex())
+	}
+	hash := crypto.Keccak256([]byte("vote"))
+	sig, err := SignVote(1, common.BytesToHash(hash), false)
+	if err != nil {
+		t.Fatalf("failed to sign vote: %v", err)
+	}
+	if err := verifyHashSignature(accounts.ZkscamVoteHash(1, common.BytesToHash(hash)), sig, addr); err != nil {
+		t.Fatalf("vote signature invalid: %v", err)
+	}
+	if sig, err = SignVote(1, common.BytesToHash(hash), true); err != nil {
+		t.Fatalf("failed to sign legacy vote: %v", err)
+	}
+	if err := verifyHashSignature(hash, sig, addr); err != nil {
+		t.Fatalf("legacy vote signature invalid: %v", err)
+	}
+}
+
+// Tests that miners using an external signer have their BLS key authorized by
+// the signer, and that authorizations from any other key are refused.
+func TestBLSAuthorizationExternalSigner(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	other, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	defer SetBLSKey(nil)
+
+	var (
+		calls  int
+		signer = key
+		fail   error
+	)
+	SetSigner(addr, func(pub []byte) ([]byte, error) {
+		calls++
+		if fail != nil {
+			return nil, fail
+		}
+		hash := sha256.Sum256(pub)
+		return crypto.Sign(hash[:], signer)
+	}, nil)
+
+	if _, err := SignHash(make([]byte, 32)); !errors.Is(err, errNotInitialized) {
+		t.Fatalf("raw digest signing error mismatch: have %v, want %v", err, errNotInitialized)
+	}
+	// Unauthorized keys are signed by the external signer once and cached
+	bls := GenerateBLSKey(addr)
+	SetBLSKey(bls)
+
+	auth := BLSAuthorization()
+	if auth == nil {
+		t.Fatalf("external signer did not authorize the BLS key")
+	}
+	if ok, err := VerifyAnyLengthMessageSignatureWithAddress(bls.PublicKey(), auth, addr); !ok {
+		t.Fatalf("authorization invalid: %v", err)
+	}
+	if cached := BLSAuthorization(); !bytes.Equal(cached, auth) || calls != 1 {
+		t.Fatalf("authorization not cached: %d signer calls", calls)
+	}
+	// Failing signers and signatures by another key authorize nothing
+	SetBLSKey(GenerateBLSKey(addr))
+	fail = errors.New("refused")
+	if auth := BLSAuthorization(); auth != nil {
+		t.Fatalf("authorized despite signer failure")
+	}
+	fail, signer = nil, other
+	if auth := BLSAuthorization(); auth != nil {
+		t.Fatalf("authorized by a foreign key")
+	}
+	// Keys carrying their own authorization don't need the signer
+	signer = key
+	stored := GenerateBLSKey(addr)
+	if err := stored.Authorize(func(hash []byte) ([]byte, error) { return crypto.Sign(hash, key) }); err != nil {
+		t.Fatalf("failed to authorize key: %v", err)
+	}
+	SetBLSKey(stored)
+	calls = 0
+	if auth := BLSAuthorization(); !bytes.Equal(auth, stored.Authorization) || calls != 0 {
+		t.Fatalf("stored authorization not used: %d signer calls", calls)
+	}
+}
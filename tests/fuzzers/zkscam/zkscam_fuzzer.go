@@ -0,0 +1,181 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package zkscam fuzzes the handling of the vote-based consensus data received
+// from peers: block headers carrying votes, vote messages and BLS keys.
+package zkscam
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/clique"
+	"github.com/ethereum/go-ethereum/contracts"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/fetcher"
+	"github.com/ethereum/go-ethereum/eth/protocols/eth"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	single "github.com/ethereum/go-ethereum/singleton"
+)
+
+var (
+	engine *clique.Clique   // Engine verifying the fuzzed headers
+	chain  *core.BlockChain // Chain holding the parent of the seed header
+
+	seedHeader []byte // Valid header voted by the seed voter
+	seedVotes  []byte // Valid vote message of the seed voter
+	seedBLSKey []byte // Authorized BLS key of the seed voter
+)
+
+// stakeService serves the stake reads of the engine and the vote fetcher, with
+// every account holding the same stake at every block.
+type stakeService struct{}
+
+func (stakeService) Call(args map[string]interface{}, number string) (hexutil.Bytes, error) {
+	return common.LeftPadBytes(big.NewInt(params.Ether).Bytes(), 32), nil
+}
+
+func (stakeService) GetBlockByNumber(number string, full bool) (*types.Header, error) {
+	return chain.CurrentHeader(), nil
+}
+
+func init() {
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", stakeService{}); err != nil {
+		panic(err)
+	}
+	contracts.UseClient(rpc.DialInProc(server))
+
+	// Generate a voted block on top of the genesis, as sealed by the engine
+	config := *params.AllCliqueProtocolChanges
+	engine = clique.New(config.Clique, rawdb.NewMemoryDatabase())
+
+	key, _ := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	voter, err := core.NewVoter(key, big.NewInt(params.Ether))
+	if err != nil {
+		panic(err)
+	}
+	genesis := &core.Genesis{
+		Config:     &config,
+		BaseFee:    big.NewInt(params.InitialBaseFee),
+		ExtraData:  make([]byte, 32+65),
+		TotalVotes: new(big.Int),
+	}
+	_, blocks, _ := core.GenerateChainWithGenesis(genesis, engine, 1, func(i int, b *core.BlockGen) {
+		b.SetExtra(make([]byte, 32+65))
+		b.SetVoters(voter)
+	})
+	if chain, err = core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, genesis, nil, engine, vm.Config{}, nil, nil); err != nil {
+		panic(err)
+	}
+	header := blocks[0].Header()
+	if err := engine.VerifyHeader(chain, header); err != nil {
+		panic(fmt.Sprintf("invalid seed header: %v", err))
+	}
+	if seedHeader, err = rlp.EncodeToBytes(header); err != nil {
+		panic(err)
+	}
+	// Vote for the same block in a message, the way the fetcher broadcasts it,
+	// and have the fetcher check the votes against the same chain config
+	fetcher.NewVtFetcher(&config)
+	sig, _ := crypto.Sign(accounts.ZkscamVoteDigest(&config, header.Number, header.ZkscamHash), key)
+	blsSig, _ := voter.BLS.Sign(header.ZkscamHash[:])
+	votes := &eth.Votes{Votes: []eth.Vote{{
+		Number:           header.Number,
+		MinerAddress:     voter.BLS.Address,
+		BlockHash:        header.ZkscamHash,
+		Signature:        sig,
+		BLSPublicKey:     voter.BLS.PublicKey(),
+		AuthBLSSignature: voter.BLS.Authorization,
+		BLSSignature:     blsSig,
+	}}}
+	if seedVotes, err = rlp.EncodeToBytes(votes); err != nil {
+		panic(err)
+	}
+	seedBLSKey = voter.BLS.PublicKey()
+}
+
+// fuzzHeader decodes a header and runs it through the verification of headers
+// received from peers, which must reject malformed ones without crashing. Only
+// the genesis may be accepted without votes.
+func fuzzHeader(input []byte) int {
+	header := new(types.Header)
+	if err := rlp.DecodeBytes(input, header); err != nil {
+		return 0
+	}
+	err := engine.VerifyHeader(chain, header)
+	if v := engine.VerifyVotes(chain, header); err == nil && header.Number.Sign() > 0 && !v.Valid {
+		panic(fmt.Sprintf("header with invalid votes accepted: %+v", v.Checks))
+	}
+	if err != nil {
+		return 0
+	}
+	return 1
+}
+
+// fuzzVotes decodes a vote message and hands it to the vote fetcher, like the
+// eth protocol handler does for the messages of peers.
+func fuzzVotes(input []byte) int {
+	votes := new(eth.Votes)
+	if err := rlp.DecodeBytes(input, votes); err != nil {
+		return 0
+	}
+	if err := fetcher.NewVtFetcher().ReceiveVotes(*votes); err != nil {
+		return 0
+	}
+	return 1
+}
+
+// fuzzBLSKey decodes a BLS public key, checks that it survives a round trip and
+// verifies a signature against it.
+func fuzzBLSKey(input []byte) int {
+	if len(input) < 1 {
+		return 0
+	}
+	// Split the input into a key and a signature of it over a fixed message
+	split := int(input[0])
+	input = input[1:]
+	if split > len(input) {
+		split = len(input)
+	}
+	key, sig := input[:split], input[split:]
+
+	point, err := single.UnmarshalBLSKeyBytes(key)
+	if err != nil {
+		return 0
+	}
+	encoded, err := point.MarshalBinary()
+	if err != nil {
+		panic(fmt.Sprintf("failed to encode decoded key: %v", err))
+	}
+	if _, err := single.UnmarshalBLSKeyBytes(encoded); err != nil {
+		panic(fmt.Sprintf("failed to decode re-encoded key %x: %v", encoded, err))
+	}
+	if ok, err := single.BLSVerify([]byte("zkscam"), sig, key); ok != (err == nil) {
+		panic(errors.New("verification outcome inconsistent with its error"))
+	}
+	return 1
+}
@@ -0,0 +1,40 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package zkscam
+
+import "testing"
+
+func FuzzHeader(f *testing.F) {
+	f.Add(seedHeader)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzHeader(data)
+	})
+}
+
+func FuzzVotes(f *testing.F) {
+	f.Add(seedVotes)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzVotes(data)
+	})
+}
+
+func FuzzBLSKey(f *testing.F) {
+	f.Add(append([]byte{byte(len(seedBLSKey))}, seedBLSKey...))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzBLSKey(data)
+	})
+}
@@ -197,6 +197,25 @@ var Forks = map[string]*params.ChainConfig{
 		BerlinBlock:         big.NewInt(0),
 		LondonBlock:         big.NewInt(0),
 	},
+	"Zkscam": {
+		ChainID:                big.NewInt(1),
+		HomesteadBlock:         big.NewInt(0),
+		EIP150Block:            big.NewInt(0),
+		EIP155Block:            big.NewInt(0),
+		EIP158Block:            big.NewInt(0),
+		ByzantiumBlock:         big.NewInt(0),
+		ConstantinopleBlock:    big.NewInt(0),
+		PetersburgBlock:        big.NewInt(0),
+		IstanbulBlock:          big.NewInt(0),
+		MuirGlacierBlock:       big.NewInt(0),
+		BerlinBlock:            big.NewInt(0),
+		LondonBlock:            big.NewInt(0),
+		ZkscamBLSBlock:         big.NewInt(0),
+		ZkscamVoteDataBlock:    big.NewInt(0),
+		ZkscamVotePayloadBlock: big.NewInt(0),
+		ZkscamVoteDigestBlock:  big.NewInt(0),
+		Clique:                 &params.CliqueConfig{Period: 3, Epoch: 30000},
+	},
 	"ArrowGlacier": {
 		ChainID:             big.NewInt(1),
 		HomesteadBlock:      big.NewInt(0),
@@ -44,6 +44,7 @@ var (
 	executionSpecBlockchainTestDir = filepath.Join(".", "spec-tests", "fixtures", "blockchain_tests")
 	executionSpecStateTestDir      = filepath.Join(".", "spec-tests", "fixtures", "state_tests")
 	benchmarksDir                  = filepath.Join(".", "evm-benchmarks", "benchmarks")
+	zkscamStateTestDir             = filepath.Join(".", "zkscam", "StateTests")
 )
 
 func readJSON(reader io.Reader, value interface{}) error {
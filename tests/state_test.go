@@ -98,6 +98,14 @@ func TestExecutionSpecState(t *testing.T) {
 	})
 }
 
+// TestZkscamState runs the state tests of the vote-based clique forks.
+func TestZkscamState(t *testing.T) {
+	st := new(testMatcher)
+	st.walk(t, zkscamStateTestDir, func(t *testing.T, name string, test *StateTest) {
+		execStateTest(t, st, test)
+	})
+}
+
 func execStateTest(t *testing.T, st *testMatcher, test *StateTest) {
 	if runtime.GOARCH == "386" && runtime.GOOS == "windows" && rand.Int63()%2 == 0 {
 		t.Skip("test (randomly) skipped on 32-bit windows")
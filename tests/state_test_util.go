@@ -279,6 +279,7 @@ func (t *StateTest) RunNoVerify(subtest StateSubtest, vmconfig vm.Config, snapsh
 	txContext := core.NewEVMTxContext(msg)
 	context := core.NewEVMBlockContext(block.Header(), nil, &t.json.Env.Coinbase)
 	context.GetHash = vmTestBlockHash
+	context.GetVoteData = vmTestVoteData
 	context.BaseFee = baseFee
 	context.Random = nil
 	if t.json.Env.Difficulty != nil {
@@ -435,6 +436,21 @@ func vmTestBlockHash(n uint64) common.Hash {
 	return common.BytesToHash(crypto.Keccak256([]byte(big.NewInt(int64(n)).String())))
 }
 
+// vmTestVoteData returns the vote data of the n'th block: n%3 voters, each
+// voting with a stake of n.
+func vmTestVoteData(n uint64) (vm.VoteData, bool) {
+	miners := make([]common.Address, n%3)
+	for i := range miners {
+		miners[i] = common.BigToAddress(new(big.Int).SetUint64(n*3 + uint64(i) + 1))
+	}
+	return vm.VoteData{
+		ZkscamHash:     crypto.Keccak256Hash([]byte("zkscam"), []byte(big.NewInt(int64(n)).String())),
+		Votes:          new(big.Int).SetUint64(n * uint64(len(miners))),
+		TotalVotes:     new(big.Int).SetUint64(n * (n + 1)),
+		MinerAddresses: miners,
+	}, true
+}
+
 // StateTestState groups all the state database objects together for use in tests.
 type StateTestState struct {
 	StateDB   *state.StateDB